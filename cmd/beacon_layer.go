@@ -7,6 +7,7 @@ import (
 	consclient "github.com/kilnfi/go-utils/ethereum/consensus/client"
 	consclienthttp "github.com/kilnfi/go-utils/ethereum/consensus/client/http"
 	"github.com/kilnfi/go-utils/ethereum/consensus/flag"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -47,6 +48,11 @@ func NewCmdEthCL(
 
 	cmds.AddCommand(newCmdCLSpec(ethCLCtx))
 	cmds.AddCommand(newCmdCLGetValidator(ethCLCtx))
+	cmds.AddCommand(newCmdCLGetSyncing(ethCLCtx))
+	cmds.AddCommand(newCmdCLGetHealth(ethCLCtx))
+	cmds.AddCommand(newCmdCLGetPeers(ethCLCtx))
+	cmds.AddCommand(newCmdCLGetPeerCount(ethCLCtx))
+	cmds.AddCommand(newCmdCLGetIdentity(ethCLCtx))
 
 	return cmds
 }
@@ -65,23 +71,94 @@ func newCmdCLSpec(ctx *ethCLContext) *cobra.Command {
 
 func newCmdCLGetValidator(ctx *ethCLContext) *cobra.Command {
 	var (
-		validatorID string
+		validatorID types.ValidatorID
+		stateID     types.StateID
 		slot        beaconcommon.Slot
 	)
 	cmd := &cobra.Command{
 		Use:   "get-validator",
 		Short: "Print validator data",
 		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
-			return ctx.client.GetValidator(ctx, slot.String(), validatorID)
+			if cmd.Flags().Changed("slot") {
+				stateID = types.StateIDFromSlot(slot)
+			}
+			return ctx.client.GetValidator(ctx, stateID, validatorID)
 		}),
 	}
 
 	cmd.Flags().SortFlags = false
 
-	cmd.Flags().StringVar(&validatorID, "id", "", "Required validator id on beacon chain")
+	flag.ValidatorIDVar(cmd.Flags(), &validatorID, "id", "", "Required validator index or public key")
 	_ = cmd.MarkFlagRequired("id")
-	flag.SlotVarP(cmd.Flags(), &slot, "slot", "s", 0, "Required beacon chain state-id for which to get validator")
-	_ = cmd.MarkFlagRequired("slot")
+	flag.StateIDVarP(cmd.Flags(), &stateID, "state", "s", types.StateIDHead, "Beacon chain state-id for which to get validator (head, genesis, finalized, justified, a slot or a state root)")
+	flag.SlotVar(cmd.Flags(), &slot, "slot", 0, "Beacon chain slot for which to get validator")
+	_ = cmd.Flags().MarkDeprecated("slot", "use --state instead")
+
+	return cmd
+}
+
+func newCmdCLGetSyncing(ctx *ethCLContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-syncing",
+		Short: "Print node sync status",
+		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
+			return ctx.client.GetSyncing(ctx)
+		}),
+	}
+
+	return cmd
+}
+
+func newCmdCLGetHealth(ctx *ethCLContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-health",
+		Short: "Print node health (ready, syncing or not_initialized)",
+		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
+			return ctx.client.GetHealth(ctx)
+		}),
+	}
+
+	return cmd
+}
+
+func newCmdCLGetPeers(ctx *ethCLContext) *cobra.Command {
+	var states, directions []string
+	cmd := &cobra.Command{
+		Use:   "get-peers",
+		Short: "Print node peers",
+		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
+			return ctx.client.GetPeers(ctx, states, directions)
+		}),
+	}
+
+	cmd.Flags().SortFlags = false
+
+	cmd.Flags().StringSliceVar(&states, "state", nil, "Optional peer states to filter on (disconnected, connecting, connected, disconnecting)")
+	cmd.Flags().StringSliceVar(&directions, "direction", nil, "Optional peer directions to filter on (inbound, outbound)")
+
+	return cmd
+}
+
+func newCmdCLGetPeerCount(ctx *ethCLContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-peer-count",
+		Short: "Print number of node peers by state",
+		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
+			return ctx.client.GetPeerCount(ctx)
+		}),
+	}
+
+	return cmd
+}
+
+func newCmdCLGetIdentity(ctx *ethCLContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-identity",
+		Short: "Print node network identity",
+		RunE: utils.PrintJSON(func(cmd *cobra.Command, args []string) (res interface{}, err error) {
+			return ctx.client.GetIdentity(ctx)
+		}),
+	}
 
 	return cmd
 }
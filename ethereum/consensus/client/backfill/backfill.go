@@ -0,0 +1,228 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+type Config struct {
+	// Parallelism is the maximum number of slots loaded concurrently
+	Parallelism int
+}
+
+const defaultParallelism = 8
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.Parallelism == 0 {
+		cfg.Parallelism = defaultParallelism
+	}
+
+	return cfg
+}
+
+// Slot is the content of the canonical chain at a slot
+type Slot struct {
+	Slot beaconcommon.Slot
+
+	// Missed indicates no block has been proposed at Slot, in which case Header and Block are nil
+	Missed bool
+
+	Header *types.BeaconBlockHeader
+	Block  *types.SignedBeaconBlock
+}
+
+// Progress is the state of an iteration, reported after each slot
+type Progress struct {
+	FromSlot, ToSlot beaconcommon.Slot
+
+	// Checkpoint is the last slot processed, nil if no slot has been processed yet
+	//
+	// Iteration can be resumed after it with Iterator.Resume
+	Checkpoint *beaconcommon.Slot
+
+	// Blocks and Missed count slots processed so far with a block and without
+	Blocks, Missed uint64
+}
+
+// Iterator walks the canonical chain over a range of slots
+//
+// Slots are loaded concurrently but delivered in order, so an iteration
+// interrupted at any point can be resumed from its last checkpoint.
+type Iterator struct {
+	cfg *Config
+
+	client client.Client
+
+	onProgress func(*Progress)
+
+	logger logrus.FieldLogger
+}
+
+// New creates an iterator loading blocks from cli
+func New(cfg *Config, cli client.Client) *Iterator {
+	return &Iterator{
+		cfg:    cfg,
+		client: cli,
+		logger: logrus.StandardLogger().WithField("component", "eth.consensus.backfill"),
+	}
+}
+
+func (it *Iterator) Logger() logrus.FieldLogger {
+	return it.logger
+}
+
+func (it *Iterator) SetLogger(logger logrus.FieldLogger) {
+	it.logger = logger.WithField("component", "eth.consensus.backfill")
+}
+
+// OnProgress sets a callback called after each slot has been processed
+func (it *Iterator) OnProgress(fn func(*Progress)) {
+	it.onProgress = fn
+}
+
+// Iterate calls fn on each slot in [fromSlot, toSlot] by increasing slot
+//
+// It stops on the first error returned by fn or met while loading a slot.
+func (it *Iterator) Iterate(ctx context.Context, fromSlot, toSlot beaconcommon.Slot, fn func(*Slot) error) error {
+	if toSlot < fromSlot {
+		return fmt.Errorf("invalid slot range [%v, %v]", fromSlot, toSlot)
+	}
+
+	return it.iterate(ctx, fromSlot, &Progress{FromSlot: fromSlot, ToSlot: toSlot}, fn)
+}
+
+// Resume calls fn on each slot of progress range not processed yet by increasing slot
+//
+// progress is typically the last Progress reported by an interrupted iteration, slots after its checkpoint
+// are processed, or all slots from FromSlot if it has no checkpoint. Reported progresses carry on counting.
+func (it *Iterator) Resume(ctx context.Context, progress *Progress, fn func(*Slot) error) error {
+	if progress.ToSlot < progress.FromSlot {
+		return fmt.Errorf("invalid slot range [%v, %v]", progress.FromSlot, progress.ToSlot)
+	}
+
+	fromSlot := progress.FromSlot
+	if progress.Checkpoint != nil {
+		if *progress.Checkpoint >= progress.ToSlot {
+			return nil
+		}
+		fromSlot = *progress.Checkpoint + 1
+	}
+
+	p := *progress
+	return it.iterate(ctx, fromSlot, &p, fn)
+}
+
+// result is a slot being loaded
+type result struct {
+	slot *Slot
+	err  error
+}
+
+// iterate processes slots from fromSlot to progress.ToSlot, updating progress
+func (it *Iterator) iterate(ctx context.Context, fromSlot beaconcommon.Slot, progress *Progress, fn func(*Slot) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// pending holds results in slot order, its capacity bounds the number of slots loaded concurrently
+	pending := make(chan chan *result, it.cfg.Parallelism)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(pending)
+
+		for slot := fromSlot; ; slot++ {
+			resultC := make(chan *result, 1)
+			select {
+			case pending <- resultC:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(slot beaconcommon.Slot) {
+				defer wg.Done()
+				s, err := it.loadSlot(ctx, slot)
+				resultC <- &result{slot: s, err: err}
+			}(slot)
+
+			if slot == progress.ToSlot {
+				return
+			}
+		}
+	}()
+
+	for resultC := range pending {
+		var res *result
+		select {
+		case res = <-resultC:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if res.err != nil {
+			return res.err
+		}
+
+		if err := fn(res.slot); err != nil {
+			return err
+		}
+
+		if res.slot.Missed {
+			progress.Missed++
+		} else {
+			progress.Blocks++
+		}
+		checkpoint := res.slot.Slot
+		progress.Checkpoint = &checkpoint
+
+		if it.onProgress != nil {
+			p := *progress
+			it.onProgress(&p)
+		}
+
+		if res.slot.Slot == progress.ToSlot {
+			return nil
+		}
+	}
+
+	// pending is closed early if ctx is canceled before all slots have been scheduled
+	return ctx.Err()
+}
+
+// loadSlot loads the canonical block at slot
+func (it *Iterator) loadSlot(ctx context.Context, slot beaconcommon.Slot) (*Slot, error) {
+	header, err := it.client.GetBlockHeader(ctx, types.BlockIDFromSlot(slot))
+	if client.IsNotFound(err) {
+		it.logger.WithField("slot", slot).Debugf("Missed slot")
+		return &Slot{Slot: slot, Missed: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block header at slot %v: %w", slot, err)
+	}
+
+	// some nodes return the last block before a missed slot
+	if header.Header.Message.Slot != slot {
+		return &Slot{Slot: slot, Missed: true}, nil
+	}
+
+	// block is loaded by root so it is consistent with the header in case of reorg
+	block, err := it.client.GetBlock(ctx, types.BlockIDFromRoot(header.Root))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block at slot %v: %w", slot, err)
+	}
+
+	return &Slot{Slot: slot, Header: header, Block: block}, nil
+}
@@ -0,0 +1,211 @@
+//go:build !integration
+// +build !integration
+
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// expectSlot sets expectations for loading slot, missed slots are those with a nil root
+//
+// Calls are delayed so that later slots are loaded before earlier ones
+func expectSlot(mockCli *mock.MockClient, slot beaconcommon.Slot, root *beaconcommon.Root, headerSlot beaconcommon.Slot) {
+	delay := time.Duration(20-slot) * time.Millisecond
+	call := mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockIDFromSlot(slot))
+	if root == nil {
+		call.DoAndReturn(func(context.Context, types.BlockID) (*types.BeaconBlockHeader, error) {
+			time.Sleep(delay)
+			return nil, &types.Error{Code: 404, Message: "not found"}
+		})
+		return
+	}
+
+	header := &types.BeaconBlockHeader{Root: *root, Canonical: true}
+	header.Header.Message.Slot = headerSlot
+	call.DoAndReturn(func(context.Context, types.BlockID) (*types.BeaconBlockHeader, error) {
+		time.Sleep(delay)
+		return header, nil
+	})
+
+	if headerSlot == slot {
+		mockCli.EXPECT().GetBlock(gomock.Any(), types.BlockIDFromRoot(*root)).Return(&types.SignedBeaconBlock{Version: "phase0"}, nil)
+	}
+}
+
+func TestIterate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	expectSlot(mockCli, 10, &beaconcommon.Root{0x10}, 10)
+	expectSlot(mockCli, 11, nil, 0)
+	// node returns the block of slot 10 for missed slot 12
+	expectSlot(mockCli, 12, &beaconcommon.Root{0x10}, 10)
+	expectSlot(mockCli, 13, &beaconcommon.Root{0x13}, 13)
+	expectSlot(mockCli, 14, &beaconcommon.Root{0x14}, 14)
+
+	it := New((&Config{Parallelism: 2}).SetDefault(), mockCli)
+
+	var progresses []*Progress
+	it.OnProgress(func(p *Progress) { progresses = append(progresses, p) })
+
+	var slots []beaconcommon.Slot
+	var missed []beaconcommon.Slot
+	err := it.Iterate(context.Background(), 10, 14, func(s *Slot) error {
+		slots = append(slots, s.Slot)
+		if s.Missed {
+			assert.Nil(t, s.Block)
+			missed = append(missed, s.Slot)
+		} else {
+			assert.NotNil(t, s.Block)
+			assert.Equal(t, s.Slot, s.Header.Header.Message.Slot)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []beaconcommon.Slot{10, 11, 12, 13, 14}, slots)
+	assert.Equal(t, []beaconcommon.Slot{11, 12}, missed)
+	require.Len(t, progresses, 5)
+	assert.Equal(t, &Progress{FromSlot: 10, ToSlot: 14, Checkpoint: slotPtr(11), Blocks: 1, Missed: 1}, progresses[1])
+	assert.Equal(t, &Progress{FromSlot: 10, ToSlot: 14, Checkpoint: slotPtr(14), Blocks: 3, Missed: 2}, progresses[4])
+}
+
+func TestIterateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	expectSlot(mockCli, 10, &beaconcommon.Root{0x10}, 10)
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("11")).Return(nil, fmt.Errorf("connection refused"))
+	// slots loaded concurrently before the error is met may or may not be requested
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), gomock.Any()).Return(nil, &types.Error{Code: 404}).AnyTimes()
+
+	it := New((&Config{Parallelism: 4}).SetDefault(), mockCli)
+
+	var progress *Progress
+	it.OnProgress(func(p *Progress) { progress = p })
+
+	var slots []beaconcommon.Slot
+	err := it.Iterate(context.Background(), 10, 100, func(s *Slot) error {
+		slots = append(slots, s.Slot)
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slot 11")
+
+	// iteration can be resumed from the last checkpoint
+	assert.Equal(t, []beaconcommon.Slot{10}, slots)
+	assert.Equal(t, slotPtr(10), progress.Checkpoint)
+}
+
+func TestIterateCallbackError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), gomock.Any()).Return(nil, &types.Error{Code: 404}).AnyTimes()
+
+	it := New((&Config{Parallelism: 2}).SetDefault(), mockCli)
+
+	stopErr := fmt.Errorf("stop")
+	err := it.Iterate(context.Background(), 0, 1000, func(s *Slot) error {
+		if s.Slot == 5 {
+			return stopErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, stopErr)
+}
+
+func TestIterateContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), gomock.Any()).Return(nil, &types.Error{Code: 404}).AnyTimes()
+
+	it := New((&Config{Parallelism: 2}).SetDefault(), mockCli)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := it.Iterate(ctx, 0, 1000, func(s *Slot) error {
+		if s.Slot == 5 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	expectSlot(mockCli, 13, &beaconcommon.Root{0x13}, 13)
+	expectSlot(mockCli, 14, nil, 0)
+
+	it := New((&Config{}).SetDefault(), mockCli)
+
+	var progress *Progress
+	it.OnProgress(func(p *Progress) { progress = p })
+
+	var slots []beaconcommon.Slot
+	interrupted := &Progress{FromSlot: 10, ToSlot: 14, Checkpoint: slotPtr(12), Blocks: 2, Missed: 1}
+	err := it.Resume(context.Background(), interrupted, func(s *Slot) error {
+		slots = append(slots, s.Slot)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []beaconcommon.Slot{13, 14}, slots)
+	assert.Equal(t, &Progress{FromSlot: 10, ToSlot: 14, Checkpoint: slotPtr(14), Blocks: 3, Missed: 2}, progress)
+	assert.Equal(t, slotPtr(12), interrupted.Checkpoint)
+
+	// nothing to do once the checkpoint is the last slot
+	require.NoError(t, it.Resume(context.Background(), progress, func(*Slot) error {
+		t.Fatalf("callback must not be called")
+		return nil
+	}))
+
+	require.Error(t, it.Iterate(context.Background(), 14, 13, func(*Slot) error { return nil }))
+}
+
+func TestResumeWithoutCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	expectSlot(mockCli, 0, &beaconcommon.Root{0x00}, 0)
+	expectSlot(mockCli, 1, nil, 0)
+
+	it := New((&Config{}).SetDefault(), mockCli)
+
+	// iteration interrupted before slot 0 has been processed
+	var slots []beaconcommon.Slot
+	err := it.Resume(context.Background(), &Progress{FromSlot: 0, ToSlot: 1}, func(s *Slot) error {
+		slots = append(slots, s.Slot)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []beaconcommon.Slot{0, 1}, slots)
+}
+
+func slotPtr(slot beaconcommon.Slot) *beaconcommon.Slot {
+	return &slot
+}
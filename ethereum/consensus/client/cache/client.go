@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/prometheus/client_golang/prometheus"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+
+	types "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	consensustypes "github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+var _ client.Client = (*Client)(nil)
+
+type Config struct {
+	// Size is the maximum number of responses held in cache
+	Size int
+
+	// FinalizedRefreshPeriod is the minimum period between two refreshes of the finalized slot
+	FinalizedRefreshPeriod *types.Duration
+
+	// FetchTimeout bounds calls to the underlying client, which are detached from callers cancellation
+	FetchTimeout *types.Duration
+}
+
+const (
+	defaultSize                   = 1024
+	defaultFinalizedRefreshPeriod = time.Minute
+	defaultFetchTimeout           = 30 * time.Second
+)
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.Size == 0 {
+		cfg.Size = defaultSize
+	}
+
+	if cfg.FinalizedRefreshPeriod == nil {
+		cfg.FinalizedRefreshPeriod = &types.Duration{Duration: defaultFinalizedRefreshPeriod}
+	}
+
+	if cfg.FetchTimeout == nil {
+		cfg.FetchTimeout = &types.Duration{Duration: defaultFetchTimeout}
+	}
+
+	return cfg
+}
+
+// Client is a consensus client decorator caching immutable data
+//
+// Responses are cached for stateID and blockID that can not change anymore, that is
+//   - "genesis"
+//   - hex encoded roots
+//   - slots that are finalized
+//
+// "head", "justified" and "finalized" are never cached. Concurrent identical calls are collapsed
+// into a single call to the underlying client, whatever the stateID or blockID.
+//
+// Cached values are shared between callers so they must not be modified.
+// Full states (GetState) are never cached given their size.
+type Client struct {
+	client.Client
+
+	cfg *Config
+
+	cache *lru.Cache[string, interface{}]
+	group singleflight.Group
+
+	finalizedMux       sync.Mutex
+	finalizedSlot      beaconcommon.Slot
+	finalizedRefreshed time.Time
+
+	logger logrus.FieldLogger
+
+	hitsCounter   *prometheus.CounterVec
+	missesCounter *prometheus.CounterVec
+}
+
+// NewClient creates a client caching responses of cli
+func NewClient(cfg *Config, cli client.Client) (*Client, error) {
+	if cfg.Size <= 0 {
+		return nil, fmt.Errorf("invalid cache size %v", cfg.Size)
+	}
+
+	return &Client{
+		Client: cli,
+		cfg:    cfg,
+		cache:  lru.NewCache[string, interface{}](cfg.Size),
+		logger: logrus.StandardLogger().WithField("component", "eth.consensus.cache"),
+		hitsCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "consensus_client",
+				Name:      "cache_hits_total",
+				Help:      "Number of calls served from cache",
+			},
+			[]string{"method"},
+		),
+		missesCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "consensus_client",
+				Name:      "cache_misses_total",
+				Help:      "Number of cacheable calls not found in cache",
+			},
+			[]string{"method"},
+		),
+	}, nil
+}
+
+func (c *Client) Logger() logrus.FieldLogger {
+	return c.logger
+}
+
+func (c *Client) SetLogger(logger logrus.FieldLogger) {
+	c.logger = logger.WithField("component", "eth.consensus.cache")
+}
+
+// RegisterMetrics registers cache hits and misses metrics
+func (c *Client) RegisterMetrics(reg prometheus.Registerer) error {
+	if err := reg.Register(c.hitsCounter); err != nil {
+		return err
+	}
+	return reg.Register(c.missesCounter)
+}
+
+// cached returns the cached response for key if any, otherwise it calls fetch
+//
+// The response is cached if id is immutable. Concurrent calls for the same key share a single fetch,
+// which is detached from callers cancellation so one caller giving up does not fail the others.
+// The shared fetch is bounded by FetchTimeout instead.
+func cached[T any](ctx context.Context, c *Client, method, id, key string, fetch func(context.Context) (T, error)) (T, error) {
+	cacheable := c.isImmutable(ctx, id)
+	if cacheable {
+		if v, ok := c.cache.Get(key); ok {
+			c.hitsCounter.WithLabelValues(method).Inc()
+			return v.(T), nil
+		}
+		c.missesCounter.WithLabelValues(method).Inc()
+	}
+
+	v, err := shared(ctx, &c.group, key, func() (interface{}, error) {
+		fetchCtx, cancel := c.fetchContext(ctx)
+		defer cancel()
+
+		rv, err := fetch(fetchCtx)
+		if err == nil && cacheable {
+			c.cache.Add(key, rv)
+		}
+		return rv, err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return v.(T), nil
+}
+
+// shared calls fn once for all concurrent calls with the same key, each caller waiting for the result
+// until its own ctx is done
+func shared(ctx context.Context, group *singleflight.Group, key string, fn func() (interface{}, error)) (interface{}, error) {
+	select {
+	case res := <-group.DoChan(key, fn):
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetchContext returns a context for a call shared between callers
+//
+// It keeps ctx values but is detached from ctx cancellation and bounded by FetchTimeout
+func (c *Client) fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), c.cfg.FetchTimeout.Duration)
+}
+
+// isImmutable indicates whether data addressed by a stateID or blockID can not change anymore
+func (c *Client) isImmutable(ctx context.Context, id string) bool {
+	stateID := consensustypes.StateID(id)
+	switch stateID {
+	case consensustypes.StateIDGenesis:
+		return true
+	case consensustypes.StateIDHead, consensustypes.StateIDJustified, consensustypes.StateIDFinalized:
+		return false
+	}
+
+	if _, ok := stateID.Root(); ok {
+		return true
+	}
+
+	slot, ok := stateID.Slot()
+	if !ok {
+		return false
+	}
+
+	return c.isFinalized(ctx, slot)
+}
+
+// isFinalized indicates whether slot is finalized
+//
+// Finalized slot is refreshed from the underlying client when slot is beyond the last known
+// finalized slot, at most once per FinalizedRefreshPeriod
+func (c *Client) isFinalized(ctx context.Context, slot beaconcommon.Slot) bool {
+	c.finalizedMux.Lock()
+	finalizedSlot, refreshed := c.finalizedSlot, c.finalizedRefreshed
+	c.finalizedMux.Unlock()
+
+	if slot <= finalizedSlot {
+		return true
+	}
+
+	if time.Since(refreshed) < c.cfg.FinalizedRefreshPeriod.Duration {
+		return false
+	}
+
+	v, err := shared(ctx, &c.group, "isFinalized/refresh", func() (interface{}, error) {
+		fetchCtx, cancel := c.fetchContext(ctx)
+		defer cancel()
+
+		return c.refreshFinalized(fetchCtx)
+	})
+	if err != nil {
+		return false
+	}
+
+	return slot <= v.(beaconcommon.Slot)
+}
+
+// refreshFinalized fetches the finalized slot from the underlying client and returns the last known finalized slot
+func (c *Client) refreshFinalized(ctx context.Context) (beaconcommon.Slot, error) {
+	header, err := c.Client.GetBlockHeader(ctx, "finalized")
+
+	c.finalizedMux.Lock()
+	defer c.finalizedMux.Unlock()
+
+	c.finalizedRefreshed = time.Now()
+	if err != nil {
+		c.logger.WithError(err).Warnf("failed to refresh finalized slot")
+		return c.finalizedSlot, err
+	}
+
+	if header.Header.Message.Slot > c.finalizedSlot {
+		c.finalizedSlot = header.Header.Message.Slot
+	}
+
+	return c.finalizedSlot, nil
+}
+
+// cacheKey builds a cache key from a method and its arguments
+func cacheKey(method string, args ...string) string {
+	return method + "/" + strings.Join(args, "/")
+}
+
+func optional[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", *v)
+}
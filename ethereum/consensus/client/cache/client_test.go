@@ -0,0 +1,296 @@
+//go:build !integration
+// +build !integration
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commontypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+const testRoot = "0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360"
+
+func newTestClient(t *testing.T, ctrl *gomock.Controller) (*Client, *mock.MockClient) {
+	mockCli := mock.NewMockClient(ctrl)
+	c, err := NewClient((&Config{}).SetDefault(), mockCli)
+	require.NoError(t, err)
+	return c, mockCli
+}
+
+func finalizedHeader(slot beaconcommon.Slot) *types.BeaconBlockHeader {
+	header := new(types.BeaconBlockHeader)
+	header.Header.Message.Slot = slot
+	return header
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Root", testClientRoot)
+	t.Run("Head", testClientHead)
+	t.Run("FinalizedSlot", testClientFinalizedSlot)
+	t.Run("Error", testClientError)
+	t.Run("Singleflight", testClientSingleflight)
+	t.Run("SingleflightCanceledCaller", testClientSingleflightCanceledCaller)
+	t.Run("FetchTimeout", testClientFetchTimeout)
+	t.Run("FinalizedRefreshNotBlocking", testClientFinalizedRefreshNotBlocking)
+	t.Run("LRU", testClientLRU)
+}
+
+func testClientRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	block := new(types.SignedBeaconBlock)
+	mockCli.EXPECT().GetBlock(gomock.Any(), types.BlockID(testRoot)).Return(block, nil)
+
+	for i := 0; i < 3; i++ {
+		rv, err := c.GetBlock(context.Background(), testRoot)
+		require.NoError(t, err)
+		assert.Same(t, block, rv)
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.hitsCounter.WithLabelValues("GetBlock")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.missesCounter.WithLabelValues("GetBlock")))
+}
+
+func testClientHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	for _, id := range []types.StateID{"head", "justified", "finalized"} {
+		mockCli.EXPECT().GetStateFork(gomock.Any(), id).Return(&beaconcommon.Fork{}, nil).Times(2)
+		for i := 0; i < 2; i++ {
+			_, err := c.GetStateFork(context.Background(), id)
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.hitsCounter.WithLabelValues("GetStateFork")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.missesCounter.WithLabelValues("GetStateFork")))
+}
+
+func testClientFinalizedSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	// finalized slot is fetched once then slots below it are cached
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("finalized")).Return(finalizedHeader(100), nil)
+
+	root := &beaconcommon.Root{0x1}
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID("90")).Return(root, nil)
+	for i := 0; i < 2; i++ {
+		rv, err := c.GetBlockRoot(context.Background(), "90")
+		require.NoError(t, err)
+		assert.Equal(t, root, rv)
+	}
+
+	// slots beyond finalized slot are not cached, and finalized slot is not refreshed before period
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID("110")).Return(root, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := c.GetBlockRoot(context.Background(), "110")
+		require.NoError(t, err)
+	}
+
+	// once period elapsed finalized slot is refreshed
+	c.finalizedRefreshed = time.Now().Add(-c.cfg.FinalizedRefreshPeriod.Duration)
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("finalized")).Return(finalizedHeader(120), nil)
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID("110")).Return(root, nil)
+	for i := 0; i < 2; i++ {
+		_, err := c.GetBlockRoot(context.Background(), "110")
+		require.NoError(t, err)
+	}
+}
+
+func testClientError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	gomock.InOrder(
+		mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID(testRoot)).Return(nil, fmt.Errorf("test error")),
+		mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID(testRoot)).Return(new(types.BeaconBlockHeader), nil),
+	)
+
+	_, err := c.GetBlockHeader(context.Background(), testRoot)
+	require.Error(t, err)
+
+	// errors are not cached
+	_, err = c.GetBlockHeader(context.Background(), testRoot)
+	require.NoError(t, err)
+}
+
+func testClientSingleflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	release := make(chan struct{})
+	mockCli.EXPECT().GetValidators(gomock.Any(), types.StateID("head"), []types.ValidatorID{"1", "2"}, nil).
+		DoAndReturn(func(context.Context, types.StateID, []types.ValidatorID, []string) ([]*types.Validator, error) {
+			<-release
+			return []*types.Validator{{}}, nil
+		})
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rv, err := c.GetValidators(context.Background(), "head", []types.ValidatorID{"1", "2"}, nil)
+			assert.NoError(t, err)
+			assert.Len(t, rv, 1)
+		}()
+	}
+
+	// give time to all calls to join the in-flight request
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func testClientSingleflightCanceledCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	release := make(chan struct{})
+	mockCli.EXPECT().GetValidators(gomock.Any(), types.StateID("head"), []types.ValidatorID{"1"}, nil).
+		DoAndReturn(func(ctx context.Context, _ types.StateID, _ []types.ValidatorID, _ []string) ([]*types.Validator, error) {
+			<-release
+			// first caller gave up but the shared call goes on
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return []*types.Validator{{}}, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetValidators(ctx, "head", []types.ValidatorID{"1"}, nil)
+		firstErr <- err
+	}()
+
+	// give time to the first call to start the in-flight request
+	time.Sleep(50 * time.Millisecond)
+
+	secondRes := make(chan []*types.Validator, 1)
+	go func() {
+		rv, err := c.GetValidators(context.Background(), "head", []types.ValidatorID{"1"}, nil)
+		assert.NoError(t, err)
+		secondRes <- rv
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	assert.Len(t, <-secondRes, 1)
+}
+
+func testClientFetchTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	c, err := NewClient((&Config{FetchTimeout: &commontypes.Duration{Duration: 50 * time.Millisecond}}).SetDefault(), mockCli)
+	require.NoError(t, err)
+
+	// underlying call hangs until its context is done
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID(testRoot)).
+		DoAndReturn(func(ctx context.Context, _ types.BlockID) (*beaconcommon.Root, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	_, err = c.GetBlockRoot(context.Background(), testRoot)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func testClientFinalizedRefreshNotBlocking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mockCli := newTestClient(t, ctrl)
+
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("finalized")).Return(finalizedHeader(100), nil)
+	assert.True(t, c.isFinalized(context.Background(), 90))
+
+	// once period elapsed a refresh is in-flight
+	c.finalizedRefreshed = time.Now().Add(-c.cfg.FinalizedRefreshPeriod.Duration)
+	release := make(chan struct{})
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("finalized")).
+		DoAndReturn(func(context.Context, types.BlockID) (*types.BeaconBlockHeader, error) {
+			<-release
+			return finalizedHeader(120), nil
+		})
+
+	refreshed := make(chan bool, 1)
+	go func() { refreshed <- c.isFinalized(context.Background(), 110) }()
+	time.Sleep(50 * time.Millisecond)
+
+	// slots below last known finalized slot do not wait for the refresh
+	done := make(chan bool, 1)
+	go func() { done <- c.isFinalized(context.Background(), 90) }()
+	select {
+	case rv := <-done:
+		assert.True(t, rv)
+	case <-time.After(time.Second):
+		t.Fatalf("isFinalized blocked by in-flight refresh")
+	}
+
+	close(release)
+	assert.True(t, <-refreshed)
+}
+
+func testClientLRU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	c, err := NewClient((&Config{Size: 1}).SetDefault(), mockCli)
+	require.NoError(t, err)
+
+	otherRoot := types.BlockID("0x" + testRoot[4:] + "00")
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID(testRoot)).Return(&beaconcommon.Root{}, nil).Times(2)
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), otherRoot).Return(&beaconcommon.Root{}, nil)
+
+	for _, id := range []types.BlockID{testRoot, otherRoot, testRoot} {
+		_, err := c.GetBlockRoot(context.Background(), id)
+		require.NoError(t, err)
+	}
+}
+
+func TestIsImmutable(t *testing.T) {
+	c, err := NewClient((&Config{}).SetDefault(), nil)
+	require.NoError(t, err)
+
+	assert.True(t, c.isImmutable(context.Background(), testRoot))
+	assert.True(t, c.isImmutable(context.Background(), "genesis"))
+	assert.True(t, c.isImmutable(context.Background(), "0"))
+	assert.False(t, c.isImmutable(context.Background(), "head"))
+	assert.False(t, c.isImmutable(context.Background(), testRoot[:64]))
+	assert.False(t, c.isImmutable(context.Background(), "0x"+testRoot[3:]+"z"))
+}
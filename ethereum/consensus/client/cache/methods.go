@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// Methods below are cached, other methods are passed through to the underlying client
+
+func (c *Client) GetGenesis(ctx context.Context) (*types.Genesis, error) {
+	return cached(ctx, c, "GetGenesis", "genesis", cacheKey("GetGenesis"), func(ctx context.Context) (*types.Genesis, error) {
+		return c.Client.GetGenesis(ctx)
+	})
+}
+
+func (c *Client) GetDepositContract(ctx context.Context) (*types.DepositContract, error) {
+	return cached(ctx, c, "GetDepositContract", "genesis", cacheKey("GetDepositContract"), func(ctx context.Context) (*types.DepositContract, error) {
+		return c.Client.GetDepositContract(ctx)
+	})
+}
+
+func (c *Client) GetStateRoot(ctx context.Context, stateID types.StateID) (*beaconcommon.Root, error) {
+	return cached(ctx, c, "GetStateRoot", stateID.String(), cacheKey("GetStateRoot", stateID.String()), func(ctx context.Context) (*beaconcommon.Root, error) {
+		return c.Client.GetStateRoot(ctx, stateID)
+	})
+}
+
+func (c *Client) GetStateFork(ctx context.Context, stateID types.StateID) (*beaconcommon.Fork, error) {
+	return cached(ctx, c, "GetStateFork", stateID.String(), cacheKey("GetStateFork", stateID.String()), func(ctx context.Context) (*beaconcommon.Fork, error) {
+		return c.Client.GetStateFork(ctx, stateID)
+	})
+}
+
+func (c *Client) GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error) {
+	key := cacheKey("GetStateFinalityCheckpoints", stateID.String())
+	return cached(ctx, c, "GetStateFinalityCheckpoints", stateID.String(), key, func(ctx context.Context) (*types.StateFinalityCheckpoints, error) {
+		return c.Client.GetStateFinalityCheckpoints(ctx, stateID)
+	})
+}
+
+func (c *Client) GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
+	key := cacheKey("GetValidators", stateID.String(), strings.Join(types.ValidatorIDStrings(validatorIDs), ","), strings.Join(statuses, ","))
+	return cached(ctx, c, "GetValidators", stateID.String(), key, func(ctx context.Context) ([]*types.Validator, error) {
+		return c.Client.GetValidators(ctx, stateID, validatorIDs, statuses)
+	})
+}
+
+func (c *Client) GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error) {
+	key := cacheKey("GetValidator", stateID.String(), validatorID.String())
+	return cached(ctx, c, "GetValidator", stateID.String(), key, func(ctx context.Context) (*types.Validator, error) {
+		return c.Client.GetValidator(ctx, stateID, validatorID)
+	})
+}
+
+func (c *Client) GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
+	key := cacheKey("GetValidatorBalances", stateID.String(), strings.Join(types.ValidatorIDStrings(validatorIDs), ","))
+	return cached(ctx, c, "GetValidatorBalances", stateID.String(), key, func(ctx context.Context) ([]*types.ValidatorBalance, error) {
+		return c.Client.GetValidatorBalances(ctx, stateID, validatorIDs)
+	})
+}
+
+func (c *Client) GetCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error) {
+	key := cacheKey("GetCommittees", stateID.String(), optional(epoch), optional(index), optional(slot))
+	return cached(ctx, c, "GetCommittees", stateID.String(), key, func(ctx context.Context) ([]*types.Committee, error) {
+		return c.Client.GetCommittees(ctx, stateID, epoch, index, slot)
+	})
+}
+
+func (c *Client) GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error) {
+	key := cacheKey("GetSyncCommittees", stateID.String(), optional(epoch))
+	return cached(ctx, c, "GetSyncCommittees", stateID.String(), key, func(ctx context.Context) (*types.SyncCommittees, error) {
+		return c.Client.GetSyncCommittees(ctx, stateID, epoch)
+	})
+}
+
+func (c *Client) GetBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
+	key := cacheKey("GetBlockHeader", blockID.String())
+	return cached(ctx, c, "GetBlockHeader", blockID.String(), key, func(ctx context.Context) (*types.BeaconBlockHeader, error) {
+		return c.Client.GetBlockHeader(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error) {
+	return cached(ctx, c, "GetBlock", blockID.String(), cacheKey("GetBlock", blockID.String()), func(ctx context.Context) (*types.SignedBeaconBlock, error) {
+		return c.Client.GetBlock(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error) {
+	strIndices := make([]string, 0, len(indices))
+	for _, index := range indices {
+		strIndices = append(strIndices, strconv.FormatUint(index, 10))
+	}
+
+	key := cacheKey("GetBlobSidecars", blockID.String(), strings.Join(strIndices, ","))
+	return cached(ctx, c, "GetBlobSidecars", blockID.String(), key, func(ctx context.Context) ([]*types.BlobSidecar, error) {
+		return c.Client.GetBlobSidecars(ctx, blockID, indices)
+	})
+}
+
+func (c *Client) GetBlockRoot(ctx context.Context, blockID types.BlockID) (*beaconcommon.Root, error) {
+	return cached(ctx, c, "GetBlockRoot", blockID.String(), cacheKey("GetBlockRoot", blockID.String()), func(ctx context.Context) (*beaconcommon.Root, error) {
+		return c.Client.GetBlockRoot(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockAttestations(ctx context.Context, blockID types.BlockID) (beaconphase0.Attestations, error) {
+	key := cacheKey("GetBlockAttestations", blockID.String())
+	return cached(ctx, c, "GetBlockAttestations", blockID.String(), key, func(ctx context.Context) (beaconphase0.Attestations, error) {
+		return c.Client.GetBlockAttestations(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error) {
+	key := cacheKey("GetBlockRewards", blockID.String())
+	return cached(ctx, c, "GetBlockRewards", blockID.String(), key, func(ctx context.Context) (*types.BlockRewards, error) {
+		return c.Client.GetBlockRewards(ctx, blockID)
+	})
+}
+
+func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error) {
+	key := cacheKey("GetSyncCommitteeRewards", blockID.String(), strings.Join(types.ValidatorIDStrings(validatorIDs), ","))
+	return cached(ctx, c, "GetSyncCommitteeRewards", blockID.String(), key, func(ctx context.Context) ([]*types.SyncCommitteeReward, error) {
+		return c.Client.GetSyncCommitteeRewards(ctx, blockID, validatorIDs)
+	})
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 
-	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
 )
@@ -14,6 +13,9 @@ import (
 
 // Note:
 
+// stateID, blockID and validatorID arguments are typed (see types.StateID, types.BlockID and types.ValidatorID),
+// use types.ParseStateID, types.ParseBlockID and types.ParseValidatorID to build them from strings.
+
 // For every method receiving stateID argument, stateID can be one of:
 // - "head" (canonical head in node's view)
 // - "genesis"
@@ -36,6 +38,7 @@ import (
 //go:generate mockgen -source client.go -destination mock/client.go -package mock client
 type Client interface {
 	BeaconClient
+	ValidatorClient
 	NodeClient
 	ConfigClient
 }
@@ -45,49 +48,58 @@ type BeaconClient interface {
 	GetGenesis(ctx context.Context) (*types.Genesis, error)
 
 	// GetStateRoot calculates HashTreeRoot of the state for the given stateID
-	GetStateRoot(ctx context.Context, stateID string) (*beaconcommon.Root, error)
+	GetStateRoot(ctx context.Context, stateID types.StateID) (*beaconcommon.Root, error)
 
 	// GetStateFork returns Fork object for state with given stateID
-	GetStateFork(ctx context.Context, stateID string) (*beaconcommon.Fork, error)
+	GetStateFork(ctx context.Context, stateID types.StateID) (*beaconcommon.Fork, error)
 
 	// GetStateFinalityCheckpoints returns finality checkpoints for state with given stateID
 	// In case finality is not yet achieved returns epoch 0 and ZERO_HASH as root.
-	GetStateFinalityCheckpoints(ctx context.Context, stateID string) (*types.StateFinalityCheckpoints, error)
+	GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error)
 
 	// GetValidators returns list of validators
 	// Set validatorsIDs and/or statuses to filter result (if empty no filter is applied)
-	GetValidators(ctx context.Context, stateID string, validatorIDs, statuses []string) ([]*types.Validator, error)
+	GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error)
 
 	// GetValidator returns validator specified by stateID and validatorID
-	GetValidator(ctx context.Context, stateID, validatorID string) (*types.Validator, error)
+	GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error)
 
 	// GetValidatorBalances returns list of validator balances.
 	// Set validatorsIDs to filter validator result (if empty no filter is applied)
-	GetValidatorBalances(ctx context.Context, stateID string, validatorIDs []string) ([]*types.ValidatorBalance, error)
+	GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error)
 
 	// GetCommittees returns the committees for the given state.
 	// Set epoch and/or index and/or slot to filter result (if nil no filter is applied)
-	GetCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error)
+	GetCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error)
 
 	// GetSyncCommittees returns the sync committees for given stateID
 	// Set epoch to filter result (if nil no filter is applied)
-	GetSyncCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error)
+	GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error)
+
+	// GetState returns full beacon state for given stateID
+	GetState(ctx context.Context, stateID types.StateID) (*types.BeaconState, error)
 
 	// GetBlockHeaders return block headers
 	// Set slot and/or parentRoot to filter result (if nil no filter is applied)
 	GetBlockHeaders(ctx context.Context, slot *beaconcommon.Slot, parentRoot *beaconcommon.Root) ([]*types.BeaconBlockHeader, error)
 
 	// GetBlockHeader returns block header for given blockID
-	GetBlockHeader(ctx context.Context, blockID string) (*types.BeaconBlockHeader, error)
+	GetBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error)
 
 	// GetBlock returns block details for given block id.
-	GetBlock(ctx context.Context, blockID string) (*bellatrix.SignedBeaconBlock, error)
+	GetBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error)
 
 	// GetBlockRoot returns hashTreeRoot of block
-	GetBlockRoot(ctx context.Context, blockID string) (*beaconcommon.Root, error)
+	GetBlockRoot(ctx context.Context, blockID types.BlockID) (*beaconcommon.Root, error)
+
+	// GetBlobSidecars returns blob sidecars of the block with given blockID
+	// Set indices to filter result (if empty all sidecars of the block are returned)
+	//
+	// Use types.VerifyBlobSidecarCommitments with the same indices to check sidecars against the block's KZG commitments
+	GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error)
 
 	// GetBlockAttestations returns attestations included in requested block with given blockID
-	GetBlockAttestations(ctx context.Context, blockID string) (beaconphase0.Attestations, error)
+	GetBlockAttestations(ctx context.Context, blockID types.BlockID) (beaconphase0.Attestations, error)
 
 	// GetAttestations returns attestations known by the node but not necessarily incorporated into any block.
 	GetAttestations(ctx context.Context) (beaconphase0.Attestations, error)
@@ -100,6 +112,45 @@ type BeaconClient interface {
 
 	// GetVoluntaryExits returns voluntary exits known by the node but not necessarily incorporated into any block.
 	GetVoluntaryExits(ctx context.Context) (beaconphase0.VoluntaryExits, error)
+
+	// GetBlockRewards returns rewards earned by the proposer of the block with given blockID
+	GetBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error)
+
+	// GetAttestationRewards returns attestation rewards for given epoch
+	// Set validatorIDs to filter result (if empty rewards of all validators are returned)
+	GetAttestationRewards(ctx context.Context, epoch beaconcommon.Epoch, validatorIDs []types.ValidatorID) (*types.AttestationRewards, error)
+
+	// GetSyncCommitteeRewards returns sync committee rewards for the block with given blockID
+	// Set validatorIDs to filter result (if empty rewards of all sync committee members are returned)
+	GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error)
+
+	// SubmitVoluntaryExit submits a signed voluntary exit to the node's pool, it is broadcast to the network if valid
+	SubmitVoluntaryExit(ctx context.Context, exit *beaconphase0.SignedVoluntaryExit) error
+
+	// SubmitBLSToExecutionChanges submits signed BLS to execution credentials changes to the node's pool, they are broadcast to the network if valid
+	//
+	// In case some changes are invalid, returned error wraps a *types.Error listing failures
+	SubmitBLSToExecutionChanges(ctx context.Context, changes beaconcommon.SignedBLSToExecutionChanges) error
+
+	// SubmitAttestations submits attestations to the node's pool, they are broadcast to the network if valid
+	//
+	// In case some attestations are invalid, returned error wraps a *types.Error listing failures
+	SubmitAttestations(ctx context.Context, attestations beaconphase0.Attestations) error
+
+	// SubmitProposerSlashing submits a proposer slashing to the node's pool, it is broadcast to the network if valid
+	SubmitProposerSlashing(ctx context.Context, slashing *beaconphase0.ProposerSlashing) error
+}
+
+type ValidatorClient interface {
+	// GetProposerDuties returns block proposers duties for given epoch
+	GetProposerDuties(ctx context.Context, epoch beaconcommon.Epoch) (*types.ProposerDuties, error)
+
+	// GetAttesterDuties returns attestation duties for given epoch and validator indices
+	GetAttesterDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.AttesterDuties, error)
+
+	// GetSyncCommitteeDuties returns sync committee duties for given validator indices
+	// over the sync committee period of given epoch
+	GetSyncCommitteeDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.SyncCommitteeDuties, error)
 }
 
 type NodeClient interface {
@@ -107,9 +158,57 @@ type NodeClient interface {
 
 	// Example: teku/v0.12.6-dev-994997f8/osx-x86_64/adoptopenjdk-java-11
 	GetNodeVersion(ctx context.Context) (string, error)
+
+	// GetSyncing returns node's sync status
+	GetSyncing(ctx context.Context) (*types.Syncing, error)
+
+	// GetHealth returns node's health
+	//
+	// A node not initialized (503) is reported as types.HealthNotInitialized and not as an error
+	GetHealth(ctx context.Context) (types.Health, error)
+
+	// GetPeers returns node's peers
+	// Set states and/or directions to filter result (if empty no filter is applied)
+	GetPeers(ctx context.Context, states, directions []string) ([]*types.Peer, error)
+
+	// GetPeerCount returns number of node's peers by connection state
+	GetPeerCount(ctx context.Context) (*types.PeerCount, error)
+
+	// GetIdentity returns node's network identity (peer id, ENR, addresses and metadata)
+	GetIdentity(ctx context.Context) (*types.Identity, error)
 }
 
 type ConfigClient interface {
 	// GetSpec returns Ethreum 2.0 specifications configuration used on the node.
-	GetSpec(ctx context.Context) (*beaconcommon.Spec, error)
+	//
+	// Values unknown to beaconcommon.Spec are available in Spec.Extra
+	GetSpec(ctx context.Context) (*types.Spec, error)
+
+	// GetForkSchedule returns all forks, past present and future, the node is aware of
+	GetForkSchedule(ctx context.Context) ([]*beaconcommon.Fork, error)
+
+	// GetDepositContract returns Eth1 deposit contract address and chain ID used by the node
+	GetDepositContract(ctx context.Context) (*types.DepositContract, error)
+}
+
+// EventsClient is implemented by clients able to stream beacon node events
+//
+// It is not part of Client so callers should check for it with a type assertion
+type EventsClient interface {
+	// SubscribeEvents subscribes to beacon node events for the given topics (see types.EventTopic*)
+	//
+	// Events are sent on the returned channel. In case the stream is interrupted, connection is automatically
+	// re-established. The channel is closed once ctx is canceled.
+	SubscribeEvents(ctx context.Context, topics []string) (<-chan *types.Event, error)
+}
+
+// ValidatorsStreamClient is implemented by clients able to decode validators one by one
+//
+// It is not part of Client so callers should check for it with a type assertion
+type ValidatorsStreamClient interface {
+	// StreamValidators calls fn on each validator as it is decoded, without loading the full list in memory
+	// Set validatorsIDs and/or statuses to filter result (if empty no filter is applied)
+	//
+	// If fn returns an error, streaming stops and StreamValidators returns this error
+	StreamValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string, fn func(*types.Validator) error) error
 }
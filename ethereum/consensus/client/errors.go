@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// IsNotFound indicates whether err is a 404 response (e.g. block requested on a missed slot)
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var beaconErr *types.Error
+	if errors.As(err, &beaconErr) && beaconErr.Code == http.StatusNotFound {
+		return true
+	}
+
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if status, ok := detailedErr.StatusCode.(int); ok {
+			return status == http.StatusNotFound
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package eth2http
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// chunkIDs splits ids into chunks of at most size elements
+func chunkIDs[T any](ids []T, size int) [][]T {
+	var chunks [][]T
+	for size < len(ids) {
+		ids, chunks = ids[size:], append(chunks, ids[:size])
+	}
+
+	return append(chunks, ids)
+}
+
+// mergeByValidatorIndex flattens chunk results, removing duplicates and sorting items by validator index
+//
+// It makes chunked calls return the same result whatever the order chunks complete,
+// and the same result as a single POST call
+func mergeByValidatorIndex[T any](chunks [][]T, index func(T) beaconcommon.ValidatorIndex) []T {
+	seen := make(map[beaconcommon.ValidatorIndex]struct{})
+	rv := []T{}
+	for _, chunk := range chunks {
+		for _, item := range chunk {
+			if _, ok := seen[index(item)]; ok {
+				continue
+			}
+			seen[index(item)] = struct{}{}
+			rv = append(rv, item)
+		}
+	}
+
+	sort.SliceStable(rv, func(i, j int) bool { return index(rv[i]) < index(rv[j]) })
+
+	return rv
+}
+
+// isUnsupportedEndpoint indicates whether err may be caused by the node not exposing the called endpoint
+func isUnsupportedEndpoint(err error) bool {
+	var detailedErr autorest.DetailedError
+	if !errors.As(err, &detailedErr) {
+		return false
+	}
+
+	switch detailedErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
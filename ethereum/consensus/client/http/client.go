@@ -1,13 +1,26 @@
 package eth2http
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 
 	kilnhttp "github.com/kilnfi/go-utils/net/http"
+	httpmetrics "github.com/kilnfi/go-utils/net/http/metrics"
 	httppreparer "github.com/kilnfi/go-utils/net/http/preparer"
 )
 
@@ -15,12 +28,38 @@ import (
 type Client struct {
 	client autorest.Sender
 
+	// eventsReconnectMinWait and eventsReconnectMaxWait bound the backoff between events stream reconnections
+	eventsReconnectMinWait time.Duration
+	eventsReconnectMaxWait time.Duration
+
+	// sszDisabled is set when SSZ responses are disabled by configuration
+	// or once the node rejected SSZ negotiation
+	sszDisabled atomic.Bool
+
+	validatorsChunkSize   int
+	validatorsParallelism int
+
+	// postValidatorsUnsupported is set once the node has been detected
+	// not to support POST validators endpoints
+	postValidatorsUnsupported atomic.Bool
+
+	specMux   sync.RWMutex
+	spec      *beaconcommon.Spec
+	specGroup singleflight.Group
+
+	metrics *httpmetrics.Collector
+
 	logger logrus.FieldLogger
 }
 
 func NewClientFromClient(s autorest.Sender) *Client {
 	c := &Client{
-		client: s,
+		client:                 s,
+		eventsReconnectMinWait: defaultEventsReconnectMinWait,
+		eventsReconnectMaxWait: defaultEventsReconnectMaxWait,
+		validatorsChunkSize:    defaultValidatorsChunkSize,
+		validatorsParallelism:  defaultValidatorsParallelism,
+		metrics:                newMetrics(),
 	}
 
 	c.SetLogger(logrus.StandardLogger())
@@ -35,12 +74,47 @@ func NewClient(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 
-	return NewClientFromClient(
+	var sender autorest.Sender = httpc
+	if cfg.RateLimit != nil && cfg.RateLimit.Rate > 0 {
+		// a limiter with no burst would never allow any request
+		burst := cfg.RateLimit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		sender = autorest.DecorateSender(sender, WithRateLimit(rate.NewLimiter(rate.Limit(cfg.RateLimit.Rate), burst)))
+	}
+
+	// retries are decorated last so each attempt goes through rate limiting
+	if cfg.Retry != nil && cfg.Retry.MaxAttempts > 1 {
+		sender = autorest.DecorateSender(sender, WithRetry(cfg.Retry))
+	}
+
+	c := NewClientFromClient(
 		autorest.Client{
-			Sender:           httpc,
+			Sender:           sender,
 			RequestInspector: httppreparer.WithBaseURL(cfg.Address),
 		},
-	), nil
+	)
+	c.sszDisabled.Store(cfg.DisableSSZ)
+
+	if cfg.ValidatorsChunkSize > 0 {
+		c.validatorsChunkSize = cfg.ValidatorsChunkSize
+	}
+
+	if cfg.ValidatorsParallelism > 0 {
+		c.validatorsParallelism = cfg.ValidatorsParallelism
+	}
+
+	if cfg.EventsReconnect != nil {
+		if cfg.EventsReconnect.MinWait != nil {
+			c.eventsReconnectMinWait = cfg.EventsReconnect.MinWait.Duration
+		}
+		if cfg.EventsReconnect.MaxWait != nil {
+			c.eventsReconnectMaxWait = cfg.EventsReconnect.MaxWait.Duration
+		}
+	}
+
+	return c, nil
 }
 
 func (c *Client) Logger() logrus.FieldLogger {
@@ -51,6 +125,52 @@ func (c *Client) SetLogger(logger logrus.FieldLogger) {
 	c.logger = logger.WithField("component", "eth.consensus.client")
 }
 
+// RegisterMetrics registers requests, latency and errors metrics by endpoint
+func (c *Client) RegisterMetrics(reg prometheus.Registerer) error {
+	return c.metrics.Register(reg)
+}
+
+// SetSpec sets the chain specification used to decode SSZ responses
+//
+// If not set, specification is loaded from the node on the first SSZ response
+func (c *Client) SetSpec(spec *beaconcommon.Spec) {
+	c.specMux.Lock()
+	c.spec = spec
+	c.specMux.Unlock()
+}
+
+// sszSpec returns the chain specification, loading it from the node if not set
+//
+// Concurrent calls share a single spec request which is sent without holding specMux
+func (c *Client) sszSpec(ctx context.Context) (*beaconcommon.Spec, error) {
+	c.specMux.RLock()
+	spec := c.spec
+	c.specMux.RUnlock()
+	if spec != nil {
+		return spec, nil
+	}
+
+	v, err, _ := c.specGroup.Do("spec", func() (interface{}, error) {
+		spec, err := c.getSpec(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.specMux.Lock()
+		defer c.specMux.Unlock()
+		// keep spec set by SetSpec while the request was in-flight
+		if c.spec == nil {
+			c.spec = &spec.Spec
+		}
+		return c.spec, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*beaconcommon.Spec), nil
+}
+
 func newRequest(ctx context.Context) *http.Request {
 	req, _ := http.NewRequestWithContext(ctx, "", "", http.NoBody)
 	return req
@@ -64,3 +184,163 @@ func inspectResponse(resp *http.Response, msg interface{}) error {
 		autorest.ByClosing(),
 	)
 }
+
+// inspectSubmitResponse inspects response of endpoints returning no data on success
+func inspectSubmitResponse(resp *http.Response) error {
+	return autorest.Respond(
+		resp,
+		WithBeaconErrorUnlessOK(),
+		autorest.ByDiscardingBody(),
+		autorest.ByClosing(),
+	)
+}
+
+// consensusVersion returns the fork version of a response payload
+// It defaults to Eth-Consensus-Version header if version is empty
+func consensusVersion(resp *http.Response, version string) string {
+	if version != "" {
+		return version
+	}
+
+	return resp.Header.Get("Eth-Consensus-Version")
+}
+
+const (
+	mimeTypeJSON = "application/json"
+	mimeTypeSSZ  = "application/octet-stream"
+
+	acceptSSZ = mimeTypeSSZ + ";q=1.0," + mimeTypeJSON + ";q=0.9"
+)
+
+// doNegotiated sends a request accepting either SSZ or JSON encoded response
+//
+// If the node rejects SSZ negotiation, SSZ is disabled for following calls
+// and the request is replayed accepting JSON only
+func (c *Client) doNegotiated(req *http.Request) (*http.Response, error) {
+	if c.sszDisabled.Load() {
+		req.Header.Set("Accept", mimeTypeJSON)
+		return c.client.Do(req)
+	}
+
+	sszReq := req.Clone(req.Context())
+	sszReq.Header.Set("Accept", acceptSSZ)
+	// transparent decompression drops Content-Length which would require to spool SSZ bodies before decoding
+	sszReq.Header.Set("Accept-Encoding", "identity")
+	resp, err := c.client.Do(sszReq)
+	if err != nil || (resp.StatusCode != http.StatusNotAcceptable && resp.StatusCode != http.StatusUnsupportedMediaType) {
+		return resp, err
+	}
+
+	_ = autorest.Respond(resp, autorest.ByDiscardingBody(), autorest.ByClosing())
+
+	c.logger.Warnf("Node does not support SSZ responses, falling back to JSON")
+	c.sszDisabled.Store(true)
+
+	req.Header.Set("Accept", mimeTypeJSON)
+	return c.client.Do(req)
+}
+
+// isSSZResponse indicates whether a response body is SSZ encoded
+func isSSZResponse(resp *http.Response) bool {
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == mimeTypeSSZ
+}
+
+func inspectSSZResponse(resp *http.Response, unmarshal func(version string, r io.Reader, size uint64) error) error {
+	return autorest.Respond(
+		resp,
+		WithBeaconErrorUnlessOK(),
+		bySSZDecoding(unmarshal),
+		autorest.ByClosing(),
+	)
+}
+
+// bySSZDecoding decodes a SSZ encoded response body for the fork version indicated by Eth-Consensus-Version header
+func bySSZDecoding(unmarshal func(version string, r io.Reader, size uint64) error) autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			err := r.Respond(resp)
+			if err != nil {
+				return err
+			}
+
+			version := resp.Header.Get("Eth-Consensus-Version")
+			if version == "" {
+				return fmt.Errorf("missing Eth-Consensus-Version header on SSZ response")
+			}
+
+			if resp.ContentLength >= 0 {
+				return unmarshal(version, resp.Body, uint64(resp.ContentLength))
+			}
+
+			// SSZ decoding needs to know the full size of the payload upfront
+			// so the body is spooled first when the node streams it without Content-Length
+			body, size, err := spoolBody(resp.Body, sszSpoolMemoryLimit, sszSpoolMaxSize)
+			if err != nil {
+				return err
+			}
+			defer body.Close()
+
+			return unmarshal(version, body, uint64(size))
+		})
+	}
+}
+
+const (
+	// sszSpoolMemoryLimit is the size up to which SSZ bodies of unknown length are buffered in memory
+	sszSpoolMemoryLimit = 16 << 20
+
+	// sszSpoolMaxSize is the maximum size of a SSZ body of unknown length
+	sszSpoolMaxSize = 2 << 30
+)
+
+// spoolBody reads r entirely and returns a reader over its content together with its size
+//
+// Content is buffered in memory up to memLimit bytes, larger content is spooled to a temporary file
+// removed on Close. It fails if content is larger than maxSize.
+func spoolBody(r io.Reader, memLimit, maxSize int64) (io.ReadCloser, int64, error) {
+	buf := new(bytes.Buffer)
+	n, err := io.Copy(buf, io.LimitReader(r, memLimit+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read SSZ response: %w", err)
+	}
+
+	if n <= memLimit {
+		return io.NopCloser(buf), n, nil
+	}
+
+	f, err := os.CreateTemp("", "eth2http-ssz-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to spool SSZ response: %w", err)
+	}
+
+	body := &tempFile{f}
+	n, err = io.Copy(f, io.MultiReader(buf, io.LimitReader(r, maxSize-n+1)))
+	if err == nil && n > maxSize {
+		err = fmt.Errorf("SSZ response larger than %v bytes", maxSize)
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		body.Close()
+		return nil, 0, fmt.Errorf("failed to spool SSZ response: %w", err)
+	}
+
+	return body, n, nil
+}
+
+// tempFile is a file removed once closed
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	_ = os.Remove(f.Name())
+	return err
+}
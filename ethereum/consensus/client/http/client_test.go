@@ -4,11 +4,22 @@
 package eth2http
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/configs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	eth2client "github.com/kilnfi/go-utils/ethereum/consensus/client"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
 )
 
 func TestClientImplementsInterface(t *testing.T) {
@@ -16,3 +27,88 @@ func TestClientImplementsInterface(t *testing.T) {
 	client := new(Client)
 	assert.Implements(t, iClient, client)
 }
+
+func TestSpoolBody(t *testing.T) {
+	data := bytes.Repeat([]byte{0x1, 0x2, 0x3, 0x4}, 16)
+
+	t.Run("InMemory", func(t *testing.T) {
+		body, size, err := spoolBody(bytes.NewReader(data), 64, 128)
+		require.NoError(t, err)
+		defer body.Close()
+
+		assert.Equal(t, int64(len(data)), size)
+		read, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+	})
+
+	t.Run("TempFile", func(t *testing.T) {
+		body, size, err := spoolBody(bytes.NewReader(data), 16, 64)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(len(data)), size)
+		read, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+
+		f, ok := body.(*tempFile)
+		require.True(t, ok)
+		require.NoError(t, body.Close())
+		_, err = os.Stat(f.Name())
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		_, _, err := spoolBody(bytes.NewReader(data), 16, 63)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "larger than 63 bytes")
+	})
+}
+
+func TestSSZSpec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	data, err := os.ReadFile("testdata/spec_mainnet.json")
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	mockCli.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(data)),
+		}, nil
+	})
+
+	// concurrent calls share a single spec request
+	specs := make(chan *beaconcommon.Spec, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			spec, err := c.sszSpec(context.Background())
+			assert.NoError(t, err)
+			specs <- spec
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// spec request is in-flight without holding the lock
+	set := make(chan struct{})
+	go func() {
+		c.SetSpec(configs.Mainnet)
+		close(set)
+	}()
+	select {
+	case <-set:
+	case <-time.After(time.Second):
+		t.Fatalf("SetSpec blocked by in-flight spec request")
+	}
+
+	close(release)
+	assert.Same(t, configs.Mainnet, <-specs)
+	assert.Same(t, configs.Mainnet, <-specs)
+}
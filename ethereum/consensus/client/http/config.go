@@ -1,16 +1,98 @@
 package eth2http
 
 import (
+	"time"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
 	kilnhttp "github.com/kilnfi/go-utils/net/http"
 )
 
 type Config struct {
 	Address string
 
+	// DisableSSZ forces JSON encoded responses on endpoints supporting SSZ
+	DisableSSZ bool
+
+	// ValidatorsChunkSize is the maximum number of validator IDs sent in a single GET request
+	// on validators endpoints. Larger ID lists are sent using POST endpoints if the node supports it,
+	// otherwise they are split into chunks of this size.
+	ValidatorsChunkSize int
+
+	// ValidatorsParallelism is the maximum number of validators chunk requests sent concurrently
+	ValidatorsParallelism int
+
+	// Retry configures retries of GET requests failing with 429 or 5xx responses
+	Retry *RetryConfig
+
+	// RateLimit configures a client-side limit on the rate of requests sent to the node
+	RateLimit *RateLimitConfig
+
+	// EventsReconnect configures the backoff between reconnections of interrupted events streams
+	EventsReconnect *ReconnectConfig
+
 	HTTP *kilnhttp.ClientConfig
 }
 
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of times a request is sent (defaults to 1 which disables retries)
+	MaxAttempts int
+
+	// MinWait and MaxWait bound the exponential backoff between attempts
+	MinWait *kilntypes.Duration
+	MaxWait *kilntypes.Duration
+}
+
+// ReconnectConfig configures an exponential backoff between consecutive reconnection attempts
+type ReconnectConfig struct {
+	// MinWait and MaxWait bound the exponential backoff between attempts
+	MinWait *kilntypes.Duration
+	MaxWait *kilntypes.Duration
+}
+
+// RateLimitConfig configures a token bucket refilled at Rate requests per second
+// holding at most Burst tokens (a zero Rate disables rate limiting)
+type RateLimitConfig struct {
+	Rate  float64
+	Burst int
+}
+
+const (
+	defaultValidatorsChunkSize   = 100
+	defaultValidatorsParallelism = 4
+
+	defaultRetryMaxAttempts = 1
+	defaultRetryMinWait     = 500 * time.Millisecond
+	defaultRetryMaxWait     = 5 * time.Second
+
+	defaultEventsReconnectMinWait = time.Second
+	defaultEventsReconnectMaxWait = 30 * time.Second
+)
+
 func (cfg *Config) SetDefault() *Config {
+	if cfg.ValidatorsChunkSize == 0 {
+		cfg.ValidatorsChunkSize = defaultValidatorsChunkSize
+	}
+
+	if cfg.ValidatorsParallelism == 0 {
+		cfg.ValidatorsParallelism = defaultValidatorsParallelism
+	}
+
+	if cfg.Retry == nil {
+		cfg.Retry = new(RetryConfig)
+	}
+
+	cfg.Retry.SetDefault()
+
+	if cfg.RateLimit == nil {
+		cfg.RateLimit = new(RateLimitConfig)
+	}
+
+	if cfg.EventsReconnect == nil {
+		cfg.EventsReconnect = new(ReconnectConfig)
+	}
+
+	cfg.EventsReconnect.SetDefault()
+
 	if cfg.HTTP == nil {
 		cfg.HTTP = new(kilnhttp.ClientConfig)
 	}
@@ -19,3 +101,31 @@ func (cfg *Config) SetDefault() *Config {
 
 	return cfg
 }
+
+func (cfg *RetryConfig) SetDefault() *RetryConfig {
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultRetryMaxAttempts
+	}
+
+	if cfg.MinWait == nil {
+		cfg.MinWait = &kilntypes.Duration{Duration: defaultRetryMinWait}
+	}
+
+	if cfg.MaxWait == nil {
+		cfg.MaxWait = &kilntypes.Duration{Duration: defaultRetryMaxWait}
+	}
+
+	return cfg
+}
+
+func (cfg *ReconnectConfig) SetDefault() *ReconnectConfig {
+	if cfg.MinWait == nil {
+		cfg.MinWait = &kilntypes.Duration{Duration: defaultEventsReconnectMinWait}
+	}
+
+	if cfg.MaxWait == nil {
+		cfg.MaxWait = &kilntypes.Duration{Duration: defaultEventsReconnectMaxWait}
+	}
+
+	return cfg
+}
@@ -0,0 +1,82 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetAttestationRewards returns attestation rewards for given epoch
+// Set validatorIDs to filter result (if empty rewards of all validators are returned)
+func (c *Client) GetAttestationRewards(ctx context.Context, epoch beaconcommon.Epoch, validatorIDs []types.ValidatorID) (*types.AttestationRewards, error) {
+	start := time.Now()
+	rv, err := c.getAttestationRewards(ctx, epoch, validatorIDs)
+	c.metrics.Observe("GetAttestationRewards", start, err)
+	if err != nil {
+		c.logger.
+			WithField("epoch", epoch).
+			WithField("validator.ids", validatorIDs).
+			WithError(err).Errorf("GetAttestationRewards failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getAttestationRewards(ctx context.Context, epoch beaconcommon.Epoch, validatorIDs []types.ValidatorID) (*types.AttestationRewards, error) {
+	req, err := newGetAttestationRewardsRequest(ctx, epoch, validatorIDs)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttestationRewards", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttestationRewards", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetAttestationRewardsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttestationRewards", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetAttestationRewardsRequest(ctx context.Context, epoch beaconcommon.Epoch, validatorIDs []types.ValidatorID) (*http.Request, error) {
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"epoch": autorest.Encode("path", epoch.String()),
+	}
+
+	if validatorIDs == nil {
+		validatorIDs = []types.ValidatorID{}
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/beacon/rewards/attestations/{epoch}", pathParameters),
+		autorest.WithJSON(validatorIDs),
+	).Prepare(newRequest(ctx))
+}
+
+type getAttestationRewardsResponseMsg struct {
+	Data *types.AttestationRewards `json:"data"`
+}
+
+func inspectGetAttestationRewardsResponse(resp *http.Response) (*types.AttestationRewards, error) {
+	msg := new(getAttestationRewardsResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
@@ -0,0 +1,52 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetAttestationRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetAttestationRewardsStatusOK(t, c, mockCli) })
+}
+
+func testGetAttestationRewardsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/rewards/attestations/100").
+		JSON([]string{"1", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"}).
+		Reply(200).
+		JSON([]byte(`{"execution_optimistic":false,"finalized":true,"data":{"ideal_rewards":[{"effective_balance":"32000000000","head":"2500","target":"5000","source":"5000","inactivity":"0"}],"total_rewards":[{"validator_index":"1","head":"2000","target":"-2000","source":"-1500","inactivity":"-300"}]}}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	rewards, err := c.GetAttestationRewards(
+		context.Background(),
+		beaconcommon.Epoch(100),
+		[]types.ValidatorID{"1", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+	)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&types.AttestationRewards{
+			IdealRewards: []*types.IdealAttestationRewards{{EffectiveBalance: 32000000000, Head: 2500, Target: 5000, Source: 5000}},
+			TotalRewards: []*types.AttestationReward{{ValidatorIndex: 1, Head: 2000, Target: -2000, Source: -1500, Inactivity: -300}},
+		},
+		rewards,
+	)
+}
@@ -0,0 +1,73 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetAttesterDuties returns attestation duties for given epoch and validator indices
+func (c *Client) GetAttesterDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.AttesterDuties, error) {
+	start := time.Now()
+	rv, err := c.getAttesterDuties(ctx, epoch, indices)
+	c.metrics.Observe("GetAttesterDuties", start, err)
+	if err != nil {
+		c.logger.
+			WithField("epoch", epoch).
+			WithField("validator.indices", indices).
+			WithError(err).Errorf("GetAttesterDuties failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getAttesterDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.AttesterDuties, error) {
+	req, err := newGetAttesterDutiesRequest(ctx, epoch, indices)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttesterDuties", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttesterDuties", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetAttesterDutiesResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetAttesterDuties", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetAttesterDutiesRequest(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"epoch": autorest.Encode("path", epoch.String()),
+	}
+
+	if indices == nil {
+		indices = []beaconcommon.ValidatorIndex{}
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/validator/duties/attester/{epoch}", pathParameters),
+		autorest.WithJSON(indices),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectGetAttesterDutiesResponse(resp *http.Response) (*types.AttesterDuties, error) {
+	msg := new(types.AttesterDuties)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
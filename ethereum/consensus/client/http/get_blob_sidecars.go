@@ -0,0 +1,87 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetBlobSidecars returns blob sidecars of the block with given blockID
+// Set indices to filter result (if empty all sidecars of the block are returned)
+func (c *Client) GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error) {
+	start := time.Now()
+	rv, err := c.getBlobSidecars(ctx, blockID, indices)
+	c.metrics.Observe("GetBlobSidecars", start, err)
+	if err != nil {
+		c.logger.
+			WithField("block", blockID).
+			WithField("indices", indices).
+			WithError(err).Errorf("GetBlobSidecars failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error) {
+	req, err := newGetBlobSidecarsRequest(ctx, blockID, indices)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlobSidecars", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlobSidecars", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetBlobSidecarsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlobSidecars", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetBlobSidecarsRequest(ctx context.Context, blockID types.BlockID, indices []uint64) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"blockID": autorest.Encode("path", blockID),
+	}
+
+	queryParameters := map[string]interface{}{}
+	if len(indices) != 0 {
+		strIndices := make([]string, 0, len(indices))
+		for _, index := range indices {
+			strIndices = append(strIndices, strconv.FormatUint(index, 10))
+		}
+		queryParameters["indices"] = strings.Join(strIndices, ",")
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPathParameters("eth/v1/beacon/blob_sidecars/{blockID}", pathParameters),
+		autorest.WithQueryParameters(queryParameters),
+	).Prepare(newRequest(ctx))
+}
+
+type getBlobSidecarsResponseMsg struct {
+	Data []*types.BlobSidecar `json:"data"`
+}
+
+func inspectGetBlobSidecarsResponse(resp *http.Response) ([]*types.BlobSidecar, error) {
+	msg := new(getBlobSidecarsResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
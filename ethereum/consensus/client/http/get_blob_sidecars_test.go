@@ -0,0 +1,62 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetBlobSidecars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetBlobSidecarsStatusOK(t, c, mockCli) })
+	t.Run("Status404", func(t *testing.T) { testGetBlobSidecarsStatus404(t, c, mockCli) })
+}
+
+func testGetBlobSidecarsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	// Blob has been truncated to keep the test readable
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/blob_sidecars/8626178").
+		MatchParam("indices", "1,3").
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"1","blob":"0x0102030405","kzg_commitment":"0xa94170080872584e54a1cf092d845703b13907f2e6b3b1c0ad573b910530499e3bcd48c6378846b80d2bfa58c81cf3d5","kzg_proof":"0xf1df213dd0670c2ae0a3ece0189795c25387325414a9a5488c0777c22a7a000fe0d0d79b90461cc368bacef7154d4a36","signed_block_header":{"message":{"slot":"8626178","proposer_index":"1051593","parent_root":"0xa0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1","state_root":"0x0000000000000000000000000000000000000000000000000000000000000001","body_root":"0x0000000000000000000000000000000000000000000000000000000000000002"},"signature":"0x0073ec266d4fb4adbf3d104aa714f9f11032fd8ab6d8829fc40b52c86f6485d7928cc2ebd4646f3fe3f374be11d905bf4be275fa86f3889d82a9f7dc5e41dd32a543997d84f12798350c09bdef2cdb171bf41ed3e4a5f808af2feb0c56263009"},"kzg_commitment_inclusion_proof":["0x0000000000000000000000000000000000000000000000000000000000000003","0x0000000000000000000000000000000000000000000000000000000000000004"]}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	sidecars, err := c.GetBlobSidecars(context.Background(), "8626178", []uint64{1, 3})
+
+	require.NoError(t, err)
+	require.Len(t, sidecars, 1)
+	assert.Equal(t, uint64(1), uint64(sidecars[0].Index))
+	assert.Equal(t, []byte{0x1, 0x2, 0x3, 0x4, 0x5}, []byte(sidecars[0].Blob))
+	assert.Equal(t, "0xa94170080872584e54a1cf092d845703b13907f2e6b3b1c0ad573b910530499e3bcd48c6378846b80d2bfa58c81cf3d5", sidecars[0].KZGCommitment.String())
+	assert.Equal(t, "0xf1df213dd0670c2ae0a3ece0189795c25387325414a9a5488c0777c22a7a000fe0d0d79b90461cc368bacef7154d4a36", sidecars[0].KZGProof.String())
+	assert.Equal(t, beaconcommon.Slot(8626178), sidecars[0].SignedBlockHeader.Message.Slot)
+	assert.Equal(t, []beaconcommon.Root{{31: 0x3}, {31: 0x4}}, sidecars[0].KZGCommitmentInclusionProof)
+}
+
+func testGetBlobSidecarsStatus404(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/blob_sidecars/head").
+		Reply(404).
+		JSON([]byte(`{"code":404,"message":"Block not found"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	_, err := c.GetBlobSidecars(context.Background(), "head", nil)
+
+	require.Error(t, err)
+}
@@ -2,15 +2,23 @@ package eth2http
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetBlock returns block details for given block id.
-func (c *Client) GetBlock(ctx context.Context, blockID string) (*bellatrix.SignedBeaconBlock, error) {
+func (c *Client) GetBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error) {
+	start := time.Now()
 	rv, err := c.getBlock(ctx, blockID)
+	c.metrics.Observe("GetBlock", start, err)
 	if err != nil {
 		c.logger.
 			WithField("block", blockID).
@@ -20,18 +28,28 @@ func (c *Client) GetBlock(ctx context.Context, blockID string) (*bellatrix.Signe
 	return rv, err
 }
 
-func (c *Client) getBlock(ctx context.Context, blockID string) (*bellatrix.SignedBeaconBlock, error) {
+func (c *Client) getBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error) {
 	req, err := newGetBlockRequest(ctx, blockID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlock", nil, "Failure preparing request")
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.doNegotiated(req)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlock", resp, "Failure sending request")
 	}
 
-	result, err := inspectGetBlockResponse(resp)
+	var result *types.SignedBeaconBlock
+	if isSSZResponse(resp) {
+		spec, specErr := c.sszSpec(ctx)
+		if specErr != nil {
+			_ = autorest.Respond(resp, autorest.ByClosing())
+			return nil, autorest.NewErrorWithError(specErr, "eth2http.Client", "GetBlock", resp, "Failure loading spec")
+		}
+		result, err = inspectGetBlockSSZResponse(resp, spec)
+	} else {
+		result, err = inspectGetBlockResponse(resp)
+	}
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlock", resp, "Invalid response")
 	}
@@ -39,7 +57,11 @@ func (c *Client) getBlock(ctx context.Context, blockID string) (*bellatrix.Signe
 	return result, nil
 }
 
-func newGetBlockRequest(ctx context.Context, blockID string) (*http.Request, error) {
+func newGetBlockRequest(ctx context.Context, blockID types.BlockID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"blockID": autorest.Encode("path", blockID),
 	}
@@ -51,16 +73,39 @@ func newGetBlockRequest(ctx context.Context, blockID string) (*http.Request, err
 }
 
 type getBlockResponseMsg struct {
-	Version string                       `json:"version"`
-	Data    *bellatrix.SignedBeaconBlock `json:"data"`
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
 }
 
-func inspectGetBlockResponse(resp *http.Response) (*bellatrix.SignedBeaconBlock, error) {
+func inspectGetBlockResponse(resp *http.Response) (*types.SignedBeaconBlock, error) {
 	msg := new(getBlockResponseMsg)
 	err := inspectResponse(resp, msg)
 	if err != nil {
 		return nil, err
 	}
 
-	return msg.Data, nil
+	version := consensusVersion(resp, msg.Version)
+	if version == "" {
+		return nil, fmt.Errorf("missing block version")
+	}
+
+	block := new(types.SignedBeaconBlock)
+	err = block.UnmarshalVersionedJSON(version, msg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func inspectGetBlockSSZResponse(resp *http.Response, spec *beaconcommon.Spec) (*types.SignedBeaconBlock, error) {
+	block := new(types.SignedBeaconBlock)
+	err := inspectSSZResponse(resp, func(version string, r io.Reader, size uint64) error {
+		return block.UnmarshalVersionedSSZ(spec, version, r, size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
 }
@@ -3,14 +3,19 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetBlockAttestations returns attestations included in requested block with given blockID
-func (c *Client) GetBlockAttestations(ctx context.Context, blockID string) (beaconphase0.Attestations, error) {
+func (c *Client) GetBlockAttestations(ctx context.Context, blockID types.BlockID) (beaconphase0.Attestations, error) {
+	start := time.Now()
 	rv, err := c.getBlockAttestations(ctx, blockID)
+	c.metrics.Observe("GetBlockAttestations", start, err)
 	if err != nil {
 		c.logger.
 			WithField("block", blockID).
@@ -20,7 +25,7 @@ func (c *Client) GetBlockAttestations(ctx context.Context, blockID string) (beac
 	return rv, err
 }
 
-func (c *Client) getBlockAttestations(ctx context.Context, blockID string) (beaconphase0.Attestations, error) {
+func (c *Client) getBlockAttestations(ctx context.Context, blockID types.BlockID) (beaconphase0.Attestations, error) {
 	req, err := newGetBlockAttestationsRequest(ctx, blockID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockAttestations", nil, "Failure preparing request")
@@ -39,7 +44,11 @@ func (c *Client) getBlockAttestations(ctx context.Context, blockID string) (beac
 	return result, nil
 }
 
-func newGetBlockAttestationsRequest(ctx context.Context, blockID string) (*http.Request, error) {
+func newGetBlockAttestationsRequest(ctx context.Context, blockID types.BlockID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"blockID": autorest.Encode("path", blockID),
 	}
@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 
@@ -10,8 +11,10 @@ import (
 )
 
 // GetBlockHeader returns block header for given blockID
-func (c *Client) GetBlockHeader(ctx context.Context, blockID string) (*types.BeaconBlockHeader, error) {
+func (c *Client) GetBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
+	start := time.Now()
 	rv, err := c.getBlockHeader(ctx, blockID)
+	c.metrics.Observe("GetBlockHeader", start, err)
 	if err != nil {
 		c.logger.
 			WithField("block", blockID).
@@ -22,7 +25,7 @@ func (c *Client) GetBlockHeader(ctx context.Context, blockID string) (*types.Bea
 }
 
 // GetBlockHeader returns block header for given blockID
-func (c *Client) getBlockHeader(ctx context.Context, blockID string) (*types.BeaconBlockHeader, error) {
+func (c *Client) getBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
 	req, err := newGetBlockHeaderRequest(ctx, blockID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockHeader", nil, "Failure preparing request")
@@ -41,7 +44,11 @@ func (c *Client) getBlockHeader(ctx context.Context, blockID string) (*types.Bea
 	return result, nil
 }
 
-func newGetBlockHeaderRequest(ctx context.Context, blockID string) (*http.Request, error) {
+func newGetBlockHeaderRequest(ctx context.Context, blockID types.BlockID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"blockID": autorest.Encode("path", blockID),
 	}
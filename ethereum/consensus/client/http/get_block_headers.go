@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
@@ -13,7 +14,9 @@ import (
 // GetBlockHeaders return block headers
 // Set slot and/or parentRoot to filter result (if nil no filter is applied)
 func (c *Client) GetBlockHeaders(ctx context.Context, slot *beaconcommon.Slot, parentRoot *beaconcommon.Root) ([]*types.BeaconBlockHeader, error) {
+	start := time.Now()
 	rv, err := c.getBlockHeaders(ctx, slot, parentRoot)
+	c.metrics.Observe("GetBlockHeaders", start, err)
 	if err != nil {
 		c.logger.
 			WithField("slot", slot).
@@ -0,0 +1,73 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetBlockRewards returns rewards earned by the proposer of the block with given blockID
+func (c *Client) GetBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error) {
+	start := time.Now()
+	rv, err := c.getBlockRewards(ctx, blockID)
+	c.metrics.Observe("GetBlockRewards", start, err)
+	if err != nil {
+		c.logger.
+			WithField("block", blockID).
+			WithError(err).Errorf("GetBlockRewards failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error) {
+	req, err := newGetBlockRewardsRequest(ctx, blockID)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockRewards", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockRewards", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetBlockRewardsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockRewards", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetBlockRewardsRequest(ctx context.Context, blockID types.BlockID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"blockID": autorest.Encode("path", blockID),
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPathParameters("eth/v1/beacon/rewards/blocks/{blockID}", pathParameters),
+	).Prepare(newRequest(ctx))
+}
+
+type getBlockRewardsResponseMsg struct {
+	Data *types.BlockRewards `json:"data"`
+}
+
+func inspectGetBlockRewardsResponse(resp *http.Response) (*types.BlockRewards, error) {
+	msg := new(getBlockRewardsResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
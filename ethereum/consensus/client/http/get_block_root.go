@@ -3,14 +3,19 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetBlockRoot returns hashTreeRoot of block
-func (c *Client) GetBlockRoot(ctx context.Context, blockID string) (*beaconcommon.Root, error) {
+func (c *Client) GetBlockRoot(ctx context.Context, blockID types.BlockID) (*beaconcommon.Root, error) {
+	start := time.Now()
 	rv, err := c.getBlockRoot(ctx, blockID)
+	c.metrics.Observe("GetBlockRoot", start, err)
 	if err != nil {
 		c.logger.
 			WithField("block", blockID).
@@ -20,7 +25,7 @@ func (c *Client) GetBlockRoot(ctx context.Context, blockID string) (*beaconcommo
 	return rv, err
 }
 
-func (c *Client) getBlockRoot(ctx context.Context, blockID string) (*beaconcommon.Root, error) {
+func (c *Client) getBlockRoot(ctx context.Context, blockID types.BlockID) (*beaconcommon.Root, error) {
 	req, err := newGetBlockRootRequest(ctx, blockID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetBlockRoot", nil, "Failure preparing request")
@@ -39,7 +44,11 @@ func (c *Client) getBlockRoot(ctx context.Context, blockID string) (*beaconcommo
 	return result, nil
 }
 
-func newGetBlockRootRequest(ctx context.Context, blockID string) (*http.Request, error) {
+func newGetBlockRootRequest(ctx context.Context, blockID types.BlockID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"blockID": autorest.Encode("path", blockID),
 	}
@@ -0,0 +1,281 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetBlockStatusOK(t, c, mockCli) })
+	t.Run("VersionHeader", func(t *testing.T) { testGetBlockVersionHeader(t, c, mockCli) })
+	t.Run("UnknownVersion", func(t *testing.T) { testGetBlockUnknownVersion(t, c, mockCli) })
+	t.Run("SSZ", func(t *testing.T) { testGetBlockSSZ(t, c, mockCli) })
+	t.Run("SSZUnknownLength", func(t *testing.T) { testGetBlockSSZUnknownLength(t) })
+	// must run last as it disables SSZ on the client
+	t.Run("SSZNotAcceptable", func(t *testing.T) { testGetBlockSSZNotAcceptable(t, c, mockCli) })
+}
+
+func testGetBlockStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	parentRoot := beaconcommon.Root{0x1}
+
+	phase0Block := new(beaconphase0.SignedBeaconBlock)
+	phase0Block.Message.Slot = 10
+	phase0Block.Message.ParentRoot = parentRoot
+
+	altairBlock := new(altair.SignedBeaconBlock)
+	altairBlock.Message.Slot = 11
+	altairBlock.Message.ParentRoot = parentRoot
+
+	bellatrixBlock := new(bellatrix.SignedBeaconBlock)
+	bellatrixBlock.Message.Slot = 12
+	bellatrixBlock.Message.ParentRoot = parentRoot
+	bellatrixBlock.Message.Body.ExecutionPayload.BlockNumber = 1012
+
+	capellaBlock := new(capella.SignedBeaconBlock)
+	capellaBlock.Message.Slot = 13
+	capellaBlock.Message.ParentRoot = parentRoot
+	capellaBlock.Message.Body.ExecutionPayload.BlockNumber = 1013
+	capellaBlock.Message.Body.ExecutionPayload.Withdrawals = beaconcommon.Withdrawals{{Index: 1, ValidatorIndex: 2, Amount: 3}}
+
+	denebBlock := new(deneb.SignedBeaconBlock)
+	denebBlock.Message.Slot = 14
+	denebBlock.Message.ParentRoot = parentRoot
+	denebBlock.Message.Body.ExecutionPayload.BlockNumber = 1014
+	denebBlock.Message.Body.ExecutionPayload.Withdrawals = beaconcommon.Withdrawals{{Index: 4, ValidatorIndex: 5, Amount: 6}}
+
+	tests := []struct {
+		version string
+		data    interface{}
+
+		expectedSlot        beaconcommon.Slot
+		expectedBlockNumber uint64
+		expectedWithdrawals beaconcommon.Withdrawals
+		noPayload           bool
+	}{
+		{version: types.ForkPhase0, data: phase0Block, expectedSlot: 10, noPayload: true},
+		{version: types.ForkAltair, data: altairBlock, expectedSlot: 11, noPayload: true},
+		{version: types.ForkBellatrix, data: bellatrixBlock, expectedSlot: 12, expectedBlockNumber: 1012},
+		{version: types.ForkCapella, data: capellaBlock, expectedSlot: 13, expectedBlockNumber: 1013, expectedWithdrawals: capellaBlock.Message.Body.ExecutionPayload.Withdrawals},
+		{version: types.ForkDeneb, data: denebBlock, expectedSlot: 14, expectedBlockNumber: 1014, expectedWithdrawals: denebBlock.Message.Body.ExecutionPayload.Withdrawals},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			data, err := json.Marshal(tt.data)
+			require.NoError(t, err)
+
+			req := httptestutils.NewGockRequest()
+			req.Get("/eth/v2/beacon/blocks/head").
+				Reply(200).
+				JSON([]byte(fmt.Sprintf(`{"version":%q,"execution_optimistic":false,"data":%v}`, tt.version, string(data))))
+
+			mockCli.EXPECT().Gock(req)
+
+			block, err := c.GetBlock(context.Background(), "head")
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, block.Version)
+			assert.Equal(t, tt.expectedSlot, block.Slot())
+			assert.Equal(t, parentRoot, block.ParentRoot())
+			assert.Equal(t, tt.expectedWithdrawals, block.Withdrawals())
+			if tt.noPayload {
+				assert.Nil(t, block.ExecutionPayload())
+			} else {
+				require.NotNil(t, block.ExecutionPayload())
+				assert.Equal(t, tt.expectedBlockNumber, uint64(block.ExecutionPayload().BlockNumber))
+			}
+		})
+	}
+}
+
+func testGetBlockVersionHeader(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	block := new(capella.SignedBeaconBlock)
+	block.Message.Slot = 13
+	data, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/beacon/blocks/13").
+		Reply(200).
+		SetHeader("Eth-Consensus-Version", "capella").
+		JSON([]byte(fmt.Sprintf(`{"data":%v}`, string(data))))
+
+	mockCli.EXPECT().Gock(req)
+
+	rv, err := c.GetBlock(context.Background(), "13")
+	require.NoError(t, err)
+	assert.Equal(t, types.ForkCapella, rv.Version)
+	require.NotNil(t, rv.Capella)
+	assert.Equal(t, beaconcommon.Slot(13), rv.Slot())
+}
+
+func testGetBlockUnknownVersion(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/beacon/blocks/head").
+		Reply(200).
+		JSON([]byte(`{"version":"unknown","data":{}}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	_, err := c.GetBlock(context.Background(), "head")
+	require.Error(t, err)
+}
+
+func testGetBlockSSZ(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	c.SetSpec(configs.Mainnet)
+
+	sszData, err := os.ReadFile("testdata/block_deneb.ssz")
+	require.NoError(t, err)
+	jsonData, err := os.ReadFile("testdata/block_deneb.json")
+	require.NoError(t, err)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/beacon/blocks/head").
+		MatchHeader("Accept", "application/octet-stream").
+		Reply(200).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetHeader("Eth-Consensus-Version", "deneb").
+		BodyString(string(sszData))
+
+	mockCli.EXPECT().Gock(req)
+
+	block, err := c.GetBlock(context.Background(), "head")
+	require.NoError(t, err)
+
+	expected := new(types.SignedBeaconBlock)
+	require.NoError(t, json.Unmarshal(jsonData, expected))
+	require.NotNil(t, block.Deneb)
+	// empty lists decode to nil in SSZ and to empty slices in JSON so we compare roots
+	assert.Equal(
+		t,
+		expected.Deneb.HashTreeRoot(configs.Mainnet, tree.GetHashFn()),
+		block.Deneb.HashTreeRoot(configs.Mainnet, tree.GetHashFn()),
+	)
+	assert.Equal(t, expected.Slot(), block.Slot())
+}
+
+func testGetBlockSSZUnknownLength(t *testing.T) {
+	sszData, err := os.ReadFile("testdata/block_deneb.ssz")
+	require.NoError(t, err)
+
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/octet-stream"}, "Eth-Consensus-Version": []string{"deneb"}},
+		ContentLength: -1,
+		Body:          io.NopCloser(bytes.NewReader(sszData)),
+	}
+
+	block, err := inspectGetBlockSSZResponse(resp, configs.Mainnet)
+	require.NoError(t, err)
+	require.NotNil(t, block.Deneb)
+
+	expected := new(types.SignedBeaconBlock)
+	require.NoError(t, expected.UnmarshalVersionedSSZ(configs.Mainnet, types.ForkDeneb, bytes.NewReader(sszData), uint64(len(sszData))))
+	assert.Equal(
+		t,
+		expected.Deneb.HashTreeRoot(configs.Mainnet, tree.GetHashFn()),
+		block.Deneb.HashTreeRoot(configs.Mainnet, tree.GetHashFn()),
+	)
+}
+
+func testGetBlockSSZNotAcceptable(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	block := new(deneb.SignedBeaconBlock)
+	block.Message.Slot = 14
+	data, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	sszReq := httptestutils.NewGockRequest()
+	sszReq.Get("/eth/v2/beacon/blocks/head").
+		MatchHeader("Accept", "application/octet-stream").
+		Reply(406).
+		JSON([]byte(`{"code":406,"message":"Accepted media type not supported"}`))
+
+	jsonReq := httptestutils.NewGockRequest()
+	jsonReq.Get("/eth/v2/beacon/blocks/head").
+		MatchHeader("Accept", "^application/json$").
+		Reply(200).
+		JSON([]byte(fmt.Sprintf(`{"version":"deneb","data":%v}`, string(data))))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(sszReq),
+		mockCli.EXPECT().Gock(jsonReq),
+	)
+
+	rv, err := c.GetBlock(context.Background(), "head")
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Slot(14), rv.Slot())
+
+	// following calls do not try SSZ anymore
+	mockCli.EXPECT().Gock(jsonReq)
+	_, err = c.GetBlock(context.Background(), "head")
+	require.NoError(t, err)
+}
+
+// BenchmarkGetBlockDecoding compares JSON and SSZ decoding of the same mainnet sized Deneb block
+// (testdata/block_deneb.json and testdata/block_deneb.ssz)
+func BenchmarkGetBlockDecoding(b *testing.B) {
+	sszData, err := os.ReadFile("testdata/block_deneb.ssz")
+	require.NoError(b, err)
+	jsonData, err := os.ReadFile("testdata/block_deneb.json")
+	require.NoError(b, err)
+
+	newResponse := func(contentType string, body []byte) *http.Response {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": []string{contentType}, "Eth-Consensus-Version": []string{"deneb"}},
+			ContentLength: int64(len(body)),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+		}
+	}
+
+	b.Run("JSON", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(jsonData)))
+		for i := 0; i < b.N; i++ {
+			_, err := inspectGetBlockResponse(newResponse("application/json", jsonData))
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("SSZ", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(sszData)))
+		for i := 0; i < b.N; i++ {
+			_, err := inspectGetBlockSSZResponse(newResponse("application/octet-stream", sszData), configs.Mainnet)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
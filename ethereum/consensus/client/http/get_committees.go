@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
@@ -12,8 +13,10 @@ import (
 
 // GetCommittees returns the committees for the given state.
 // Set epoch and/or index and/or slot to filter result (if nil no filter is applied)
-func (c *Client) GetCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error) {
+func (c *Client) GetCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error) {
+	start := time.Now()
 	rv, err := c.getCommittees(ctx, stateID, epoch, index, slot)
+	c.metrics.Observe("GetCommittees", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -26,7 +29,7 @@ func (c *Client) GetCommittees(ctx context.Context, stateID string, epoch *beaco
 	return rv, err
 }
 
-func (c *Client) getCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error) {
+func (c *Client) getCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) ([]*types.Committee, error) {
 	req, err := newGetCommitteesRequest(ctx, stateID, epoch, index, slot)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetCommittees", nil, "Failure preparing request")
@@ -45,7 +48,11 @@ func (c *Client) getCommittees(ctx context.Context, stateID string, epoch *beaco
 	return result, nil
 }
 
-func newGetCommitteesRequest(ctx context.Context, stateID string, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) (*http.Request, error) {
+func newGetCommitteesRequest(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
@@ -0,0 +1,63 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetDepositContract returns Eth1 deposit contract address and chain ID used by the node
+func (c *Client) GetDepositContract(ctx context.Context) (*types.DepositContract, error) {
+	start := time.Now()
+	rv, err := c.getDepositContract(ctx)
+	c.metrics.Observe("GetDepositContract", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetDepositContract failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getDepositContract(ctx context.Context) (*types.DepositContract, error) {
+	req, err := newGetDepositContractRequest(ctx)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetDepositContract", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetDepositContract", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetDepositContractResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetDepositContract", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetDepositContractRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("/eth/v1/config/deposit_contract"),
+	).Prepare(newRequest(ctx))
+}
+
+type getDepositContractResponseMsg struct {
+	Data *types.DepositContract `json:"data"`
+}
+
+func inspectGetDepositContractResponse(resp *http.Response) (*types.DepositContract, error) {
+	msg := new(getDepositContractResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
@@ -0,0 +1,45 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetDepositContract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/config/deposit_contract").
+		Reply(200).
+		JSON([]byte(`{"data":{"chain_id":"1","address":"0x00000000219ab540356cbb839cbe05303d7705fa"}}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	depositContract, err := c.GetDepositContract(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&types.DepositContract{
+			ChainID: 1,
+			Address: beaconcommon.Eth1Address(gethcommon.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")),
+		},
+		depositContract,
+	)
+}
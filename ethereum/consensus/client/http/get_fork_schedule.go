@@ -0,0 +1,62 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// GetForkSchedule returns all forks, past present and future, the node is aware of
+func (c *Client) GetForkSchedule(ctx context.Context) ([]*beaconcommon.Fork, error) {
+	start := time.Now()
+	rv, err := c.getForkSchedule(ctx)
+	c.metrics.Observe("GetForkSchedule", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetForkSchedule failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getForkSchedule(ctx context.Context) ([]*beaconcommon.Fork, error) {
+	req, err := newGetForkScheduleRequest(ctx)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetForkSchedule", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetForkSchedule", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetForkScheduleResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetForkSchedule", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetForkScheduleRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("/eth/v1/config/fork_schedule"),
+	).Prepare(newRequest(ctx))
+}
+
+type getForkScheduleResponseMsg struct {
+	Data []*beaconcommon.Fork `json:"data"`
+}
+
+func inspectGetForkScheduleResponse(resp *http.Response) ([]*beaconcommon.Fork, error) {
+	msg := new(getForkScheduleResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
@@ -0,0 +1,43 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetForkSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/config/fork_schedule").
+		Reply(200).
+		JSON([]byte(`{"data":[{"previous_version":"0x00000000","current_version":"0x00000000","epoch":"0"},{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"74240"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	forks, err := c.GetForkSchedule(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*beaconcommon.Fork{
+			{PreviousVersion: beaconcommon.Version{0x0, 0x0, 0x0, 0x0}, CurrentVersion: beaconcommon.Version{0x0, 0x0, 0x0, 0x0}, Epoch: 0},
+			{PreviousVersion: beaconcommon.Version{0x0, 0x0, 0x0, 0x0}, CurrentVersion: beaconcommon.Version{0x1, 0x0, 0x0, 0x0}, Epoch: 74240},
+		},
+		forks,
+	)
+}
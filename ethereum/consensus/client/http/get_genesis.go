@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 
@@ -11,7 +12,9 @@ import (
 
 // GetGenesis returns genesis block
 func (c *Client) GetGenesis(ctx context.Context) (*types.Genesis, error) {
+	start := time.Now()
 	rv, err := c.getGenesis(ctx)
+	c.metrics.Observe("GetGenesis", start, err)
 	if err != nil {
 		c.logger.WithError(err).Errorf("GetGenesis failed")
 	}
@@ -0,0 +1,73 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetHealth returns node's health
+//
+// A node not initialized (503) is reported as types.HealthNotInitialized and not as an error
+func (c *Client) GetHealth(ctx context.Context) (types.Health, error) {
+	start := time.Now()
+	rv, err := c.getHealth(ctx)
+	c.metrics.Observe("GetHealth", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetHealth failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getHealth(ctx context.Context) (types.Health, error) {
+	// 503 means the node is not initialized, it is a valid health status and not a transient failure
+	req, err := newGetHealthRequest(withoutRetry(ctx))
+	if err != nil {
+		return "", autorest.NewErrorWithError(err, "eth2http.Client", "GetHealth", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", autorest.NewErrorWithError(err, "eth2http.Client", "GetHealth", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetHealthResponse(resp)
+	if err != nil {
+		return "", autorest.NewErrorWithError(err, "eth2http.Client", "GetHealth", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetHealthRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/node/health"),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectGetHealthResponse(resp *http.Response) (types.Health, error) {
+	err := autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK, http.StatusPartialContent, http.StatusServiceUnavailable),
+		autorest.ByDiscardingBody(),
+		autorest.ByClosing(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return types.HealthReady, nil
+	case http.StatusPartialContent:
+		return types.HealthSyncing, nil
+	default:
+		return types.HealthNotInitialized, nil
+	}
+}
@@ -0,0 +1,53 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	tests := []struct {
+		status         int
+		expectedHealth types.Health
+		expectErr      bool
+	}{
+		{status: 200, expectedHealth: types.HealthReady},
+		{status: 206, expectedHealth: types.HealthSyncing},
+		{status: 503, expectedHealth: types.HealthNotInitialized},
+		{status: 400, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			req := httptestutils.NewGockRequest()
+			req.Get("/eth/v1/node/health").Reply(tt.status)
+
+			mockCli.EXPECT().Gock(req)
+
+			health, err := c.GetHealth(context.Background())
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHealth, health)
+		})
+	}
+}
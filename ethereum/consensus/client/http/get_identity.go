@@ -0,0 +1,63 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetIdentity returns node's network identity (peer id, ENR, addresses and metadata)
+func (c *Client) GetIdentity(ctx context.Context) (*types.Identity, error) {
+	start := time.Now()
+	rv, err := c.getIdentity(ctx)
+	c.metrics.Observe("GetIdentity", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetIdentity failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getIdentity(ctx context.Context) (*types.Identity, error) {
+	req, err := newGetIdentityRequest(ctx)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetIdentity", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetIdentity", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetIdentityResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetIdentity", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetIdentityRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/node/identity"),
+	).Prepare(newRequest(ctx))
+}
+
+type getIdentityResponseMsg struct {
+	Data *types.Identity `json:"data"`
+}
+
+func inspectGetIdentityResponse(resp *http.Response) (*types.Identity, error) {
+	msg := new(getIdentityResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
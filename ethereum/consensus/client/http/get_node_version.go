@@ -3,13 +3,16 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 )
 
 // GetNodeVersion returns node's version contains informations about the node processing the request
 func (c *Client) GetNodeVersion(ctx context.Context) (string, error) {
+	start := time.Now()
 	rv, err := c.getNodeVersion(ctx)
+	c.metrics.Observe("GetNodeVersion", start, err)
 	if err != nil {
 		c.logger.WithError(err).Errorf("GetNodeVersion failed")
 	}
@@ -0,0 +1,63 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetPeerCount returns number of node's peers by connection state
+func (c *Client) GetPeerCount(ctx context.Context) (*types.PeerCount, error) {
+	start := time.Now()
+	rv, err := c.getPeerCount(ctx)
+	c.metrics.Observe("GetPeerCount", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetPeerCount failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getPeerCount(ctx context.Context) (*types.PeerCount, error) {
+	req, err := newGetPeerCountRequest(ctx)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeerCount", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeerCount", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetPeerCountResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeerCount", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetPeerCountRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/node/peer_count"),
+	).Prepare(newRequest(ctx))
+}
+
+type getPeerCountResponseMsg struct {
+	Data *types.PeerCount `json:"data"`
+}
+
+func inspectGetPeerCountResponse(resp *http.Response) (*types.PeerCount, error) {
+	msg := new(getPeerCountResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
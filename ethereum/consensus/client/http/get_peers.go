@@ -0,0 +1,77 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetPeers returns node's peers
+// Set states and/or directions to filter result (if empty no filter is applied)
+func (c *Client) GetPeers(ctx context.Context, states, directions []string) ([]*types.Peer, error) {
+	start := time.Now()
+	rv, err := c.getPeers(ctx, states, directions)
+	c.metrics.Observe("GetPeers", start, err)
+	if err != nil {
+		c.logger.
+			WithField("states", states).
+			WithField("directions", directions).
+			WithError(err).Errorf("GetPeers failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getPeers(ctx context.Context, states, directions []string) ([]*types.Peer, error) {
+	req, err := newGetPeersRequest(ctx, states, directions)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeers", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeers", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetPeersResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetPeers", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetPeersRequest(ctx context.Context, states, directions []string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{}
+	if len(states) != 0 {
+		queryParameters["state"] = states
+	}
+
+	if len(directions) != 0 {
+		queryParameters["direction"] = directions
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/node/peers"),
+		autorest.WithQueryParameters(queryParameters),
+	).Prepare(newRequest(ctx))
+}
+
+type getPeersResponseMsg struct {
+	Data []*types.Peer `json:"data"`
+}
+
+func inspectGetPeersResponse(resp *http.Response) ([]*types.Peer, error) {
+	msg := new(getPeersResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
@@ -0,0 +1,58 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetPeers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetPeersStatusOK(t, c, mockCli) })
+	t.Run("Filters", testGetPeersFilters)
+}
+
+func testGetPeersFilters(t *testing.T) {
+	req, err := newGetPeersRequest(context.Background(), []string{"connected", "connecting"}, []string{"outbound"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"connected", "connecting"}, req.URL.Query()["state"])
+	assert.Equal(t, []string{"outbound"}, req.URL.Query()["direction"])
+}
+
+func testGetPeersStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/node/peers").
+		MatchParams(map[string]string{
+			"state":     "connected",
+			"direction": "outbound",
+		}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"peer_id":"QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","enr":"enr:-IS4QHCYrYZbAKWCBRlAy5zzaDZXJBGkcnh4MHcBFZntXNFrdvJjX04jRzjzCBOonrkTfj499SZuOh8R33Ls8RRcy5wBgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQPKY0yuDUmstAHYpMa2_oxVtw0RW_QAdpzBQA8yWM0xOIN1ZHCCdl8","last_seen_p2p_address":"/ip4/7.7.7.7/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","state":"connected","direction":"outbound"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	peers, err := c.GetPeers(
+		context.Background(),
+		[]string{types.PeerStateConnected, types.PeerStateConnecting},
+		[]string{types.PeerDirectionOutbound},
+	)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N", peers[0].PeerID)
+	assert.Equal(t, types.PeerStateConnected, peers[0].State)
+	assert.Equal(t, types.PeerDirectionOutbound, peers[0].Direction)
+}
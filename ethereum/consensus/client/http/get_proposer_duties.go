@@ -0,0 +1,66 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetProposerDuties returns block proposers duties for given epoch
+func (c *Client) GetProposerDuties(ctx context.Context, epoch beaconcommon.Epoch) (*types.ProposerDuties, error) {
+	start := time.Now()
+	rv, err := c.getProposerDuties(ctx, epoch)
+	c.metrics.Observe("GetProposerDuties", start, err)
+	if err != nil {
+		c.logger.
+			WithField("epoch", epoch).
+			WithError(err).Errorf("GetProposerDuties failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getProposerDuties(ctx context.Context, epoch beaconcommon.Epoch) (*types.ProposerDuties, error) {
+	req, err := newGetProposerDutiesRequest(ctx, epoch)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetProposerDuties", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetProposerDuties", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetProposerDutiesResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetProposerDuties", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetProposerDutiesRequest(ctx context.Context, epoch beaconcommon.Epoch) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"epoch": autorest.Encode("path", epoch.String()),
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPathParameters("eth/v1/validator/duties/proposer/{epoch}", pathParameters),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectGetProposerDutiesResponse(resp *http.Response) (*types.ProposerDuties, error) {
+	msg := new(types.ProposerDuties)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
@@ -10,7 +11,9 @@ import (
 
 // GetProposerSlashings returns proposer slashings known by the node but not necessarily incorporated into any block.
 func (c *Client) GetProposerSlashings(ctx context.Context) (beaconphase0.ProposerSlashings, error) {
+	start := time.Now()
 	rv, err := c.getProposerSlashings(ctx)
+	c.metrics.Observe("GetProposerSlashings", start, err)
 	if err != nil {
 		c.logger.WithError(err).Errorf("GetProposerSlashings failed")
 	}
@@ -5,11 +5,14 @@ package eth2http
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+	"github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/view"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,9 +25,55 @@ func TestGetSpec(t *testing.T) {
 	c := NewClientFromClient(mockCli)
 
 	t.Run("StatusOK", func(t *testing.T) { testGetSpecStatusOK(t, c, mockCli) })
+	t.Run("PreMerge", func(t *testing.T) { testGetSpecPreMerge(t, c, mockCli) })
 }
 
 func testGetSpecStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	// Response contains current mainnet configuration as well as constants
+	// and parameters unknown to zrnt
+	data, err := os.ReadFile("testdata/spec_mainnet.json")
+	require.NoError(t, err)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/config/spec").
+		Reply(200).
+		JSON(data)
+
+	mockCli.EXPECT().Gock(req)
+
+	spec, err := c.GetSpec(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, *configs.Mainnet, spec.Spec)
+	assert.Equal(
+		t,
+		map[string]string{
+			"BLS_WITHDRAWAL_PREFIX":                    "0x00",
+			"COMPOUNDING_WITHDRAWAL_PREFIX":            "0x02",
+			"DOMAIN_AGGREGATE_AND_PROOF":               "0x06000000",
+			"DOMAIN_APPLICATION_MASK":                  "0x00000001",
+			"DOMAIN_BEACON_ATTESTER":                   "0x01000000",
+			"DOMAIN_BEACON_PROPOSER":                   "0x00000000",
+			"DOMAIN_BLS_TO_EXECUTION_CHANGE":           "0x0a000000",
+			"DOMAIN_CONTRIBUTION_AND_PROOF":            "0x09000000",
+			"DOMAIN_DEPOSIT":                           "0x03000000",
+			"DOMAIN_RANDAO":                            "0x02000000",
+			"DOMAIN_SELECTION_PROOF":                   "0x05000000",
+			"DOMAIN_SYNC_COMMITTEE":                    "0x07000000",
+			"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF":    "0x08000000",
+			"DOMAIN_VOLUNTARY_EXIT":                    "0x04000000",
+			"ETH1_ADDRESS_WITHDRAWAL_PREFIX":           "0x01",
+			"FULL_EXIT_REQUEST_AMOUNT":                 "0",
+			"SYNC_COMMITTEE_SUBNET_COUNT":              "4",
+			"TARGET_AGGREGATORS_PER_COMMITTEE":         "16",
+			"TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE": "16",
+			"UNSET_DEPOSIT_REQUESTS_START_INDEX":       "18446744073709551615",
+		},
+		spec.Extra,
+	)
+}
+
+func testGetSpecPreMerge(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
 	req := httptestutils.NewGockRequest()
 	req.Get("/eth/v1/config/spec").
 		Reply(200).
@@ -51,22 +100,79 @@ func testGetSpecStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSen
 		spec.BellatrixPreset,
 	)
 
-	// TODO: add assert.Equal for spec.ShardPreset
-	assert.Equal(
-		t,
-		configs.Mainnet.Config,
-		spec.Config,
-	)
-	assert.Equal(
-		t,
-		configs.Mainnet.Setup,
-		spec.Setup,
-	)
-}
+	// Response has been recorded on a pre-merge node so later forks config
+	// differs from current mainnet configuration
+	var ttd view.Uint256View
+	require.NoError(t, ttd.UnmarshalText([]byte("115792089237316195423570985008687907853269984665640564039457584007913129638912")))
+
+	expectedConfig := configs.Mainnet.Config
+	expectedConfig.TERMINAL_TOTAL_DIFFICULTY = ttd
+	expectedConfig.BELLATRIX_FORK_EPOCH = ^common.Epoch(0)
+	expectedConfig.CAPELLA_FORK_VERSION = common.Version{}
+	expectedConfig.CAPELLA_FORK_EPOCH = 0
+	expectedConfig.DENEB_FORK_VERSION = common.Version{}
+	expectedConfig.DENEB_FORK_EPOCH = 0
+	expectedConfig.ELECTRA_FORK_VERSION = common.Version{}
+	expectedConfig.ELECTRA_FORK_EPOCH = 0
+	expectedConfig.FULU_FORK_VERSION = common.Version{}
+	expectedConfig.FULU_FORK_EPOCH = 0
+	expectedConfig.EIP7441_FORK_VERSION = common.Version{}
+	expectedConfig.EIP7441_FORK_EPOCH = 0
+	expectedConfig.EIP7732_FORK_VERSION = common.Version{}
+	expectedConfig.EIP7732_FORK_EPOCH = 0
+	expectedConfig.MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT = 0
+	expectedConfig.PROPOSER_SCORE_BOOST = 70
+	expectedConfig.REORG_HEAD_WEIGHT_THRESHOLD = 0
+	expectedConfig.REORG_PARENT_WEIGHT_THRESHOLD = 0
+	expectedConfig.REORG_MAX_EPOCHS_SINCE_FINALIZATION = 0
+	expectedConfig.MAX_PAYLOAD_SIZE = 0
+	expectedConfig.MAX_REQUEST_BLOCKS = 0
+	expectedConfig.EPOCHS_PER_SUBNET_SUBSCRIPTION = 0
+	expectedConfig.MIN_EPOCHS_FOR_BLOCK_REQUESTS = 0
+	expectedConfig.TTFB_TIMEOUT = 0
+	expectedConfig.RESP_TIMEOUT = 0
+	expectedConfig.ATTESTATION_PROPAGATION_SLOT_RANGE = 0
+	expectedConfig.MAXIMUM_GOSSIP_CLOCK_DISPARITY = 0
+	expectedConfig.MESSAGE_DOMAIN_VALID_SNAPPY = common.NetworkMessageDomain{}
+	expectedConfig.SUBNETS_PER_NODE = 0
+	expectedConfig.ATTESTATION_SUBNET_COUNT = 0
+	expectedConfig.ATTESTATION_SUBNET_PREFIX_BITS = 0
+	expectedConfig.MAX_REQUEST_BLOCKS_DENEB = 0
+	expectedConfig.MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS = 0
+	expectedConfig.BLOB_SIDECAR_SUBNET_COUNT = 0
+	expectedConfig.MAX_BLOBS_PER_BLOCK = 0
+	expectedConfig.MAX_REQUEST_BLOB_SIDECARS = 0
+	expectedConfig.MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA = 0
+	expectedConfig.MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT = 0
+	expectedConfig.BLOB_SIDECAR_SUBNET_COUNT_ELECTRA = 0
+	expectedConfig.MAX_BLOBS_PER_BLOCK_ELECTRA = 0
+	expectedConfig.MAX_REQUEST_BLOB_SIDECARS_ELECTRA = 0
+	expectedConfig.NUMBER_OF_COLUMNS = 0
+	expectedConfig.NUMBER_OF_CUSTODY_GROUPS = 0
+	expectedConfig.DATA_COLUMN_SIDECAR_SUBNET_COUNT = 0
+	expectedConfig.MAX_REQUEST_DATA_COLUMN_SIDECARS = 0
+	expectedConfig.SAMPLES_PER_SLOT = 0
+	expectedConfig.CUSTODY_REQUIREMENT = 0
+	expectedConfig.VALIDATOR_CUSTODY_REQUIREMENT = 0
+	expectedConfig.BALANCE_PER_ADDITIONAL_CUSTODY_GROUP = 0
+	expectedConfig.MAX_BLOBS_PER_BLOCK_FULU = 0
+	expectedConfig.MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS = 0
+	expectedConfig.EPOCHS_PER_SHUFFLING_PHASE = 0
+	expectedConfig.PROPOSER_SELECTION_GAP = 0
+	expectedConfig.MAX_REQUEST_PAYLOADS = 0
 
-// Phase0Preset    `json:",inline" yaml:",inline"`
-// 	AltairPreset    `json:",inline" yaml:",inline"`
-// 	BellatrixPreset `json:",inline" yaml:",inline"`
-// 	ShardingPreset  `json:",inline" yaml:",inline"`
-// 	Config          `json:",inline" yaml:",inline"`
-// 	Setup           `json:",inline" yaml:",inline"`
+	// Config holds the setup parameters asserted on configs.Mainnet.Setup with previous zrnt versions
+	assert.Equal(t, expectedConfig, spec.Config)
+
+	// Presets of later forks are unknown to a pre-merge node
+	expected := *configs.Mainnet
+	expected.Config = expectedConfig
+	expected.CapellaPreset = common.CapellaPreset{}
+	expected.DenebPreset = common.DenebPreset{}
+	expected.ElectraPreset = common.ElectraPreset{}
+	assert.Equal(t, expected, spec.Spec)
+
+	// Values of renamed or removed parameters are kept
+	assert.Equal(t, "0x02000000", spec.Extra["MERGE_FORK_VERSION"])
+	assert.Equal(t, "32", spec.Extra["MIN_SLASHING_PENALTY_QUOTIENT_MERGE"])
+}
@@ -0,0 +1,179 @@
+package eth2http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetState returns full beacon state for given stateID
+func (c *Client) GetState(ctx context.Context, stateID types.StateID) (*types.BeaconState, error) {
+	start := time.Now()
+	rv, err := c.getState(ctx, stateID)
+	c.metrics.Observe("GetState", start, err)
+	if err != nil {
+		c.logger.
+			WithField("state", stateID).
+			WithError(err).Errorf("GetState failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getState(ctx context.Context, stateID types.StateID) (*types.BeaconState, error) {
+	req, err := newGetStateRequest(ctx, stateID)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetState", nil, "Failure preparing request")
+	}
+
+	resp, err := c.doNegotiated(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetState", resp, "Failure sending request")
+	}
+
+	var result *types.BeaconState
+	if isSSZResponse(resp) {
+		spec, specErr := c.sszSpec(ctx)
+		if specErr != nil {
+			_ = autorest.Respond(resp, autorest.ByClosing())
+			return nil, autorest.NewErrorWithError(specErr, "eth2http.Client", "GetState", resp, "Failure loading spec")
+		}
+		result, err = inspectGetStateSSZResponse(resp, spec)
+	} else {
+		result, err = inspectGetStateResponse(resp)
+	}
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetState", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetStateRequest(ctx context.Context, stateID types.StateID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"stateID": autorest.Encode("path", stateID),
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPathParameters("eth/v2/debug/beacon/states/{stateID}", pathParameters),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectGetStateResponse(resp *http.Response) (*types.BeaconState, error) {
+	state := new(types.BeaconState)
+	err := autorest.Respond(
+		resp,
+		WithBeaconErrorUnlessOK(),
+		byDecodingStateJSON(state),
+		autorest.ByClosing(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func inspectGetStateSSZResponse(resp *http.Response, spec *beaconcommon.Spec) (*types.BeaconState, error) {
+	state := new(types.BeaconState)
+	err := inspectSSZResponse(resp, func(version string, r io.Reader, size uint64) error {
+		return state.UnmarshalVersionedSSZ(spec, version, r, size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// byDecodingStateJSON decodes a {"version": ..., "data": ...} state response while reading the body
+//
+// States weigh hundreds of MB so, as long as fork version is known before reaching "data"
+// (from Eth-Consensus-Version header or a preceding "version" field), state is decoded
+// directly from the body without being buffered
+func byDecodingStateJSON(state *types.BeaconState) autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			err := r.Respond(resp)
+			if err != nil {
+				return err
+			}
+
+			return decodeStateJSON(json.NewDecoder(resp.Body), resp.Header.Get("Eth-Consensus-Version"), state)
+		})
+	}
+}
+
+func decodeStateJSON(dec *json.Decoder, version string, state *types.BeaconState) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("invalid state response: expected JSON object")
+	}
+
+	var (
+		data    json.RawMessage
+		decoded bool
+	)
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "version":
+			var v string
+			if err = dec.Decode(&v); err != nil {
+				return err
+			}
+			if version == "" {
+				version = v
+			}
+		case "data":
+			if version == "" {
+				// version is not known yet so we have no choice but buffering data
+				err = dec.Decode(&data)
+			} else {
+				err = state.DecodeVersionedJSON(version, dec)
+				decoded = true
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			if err = dec.Decode(new(json.RawMessage)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if decoded {
+		return nil
+	}
+
+	if data == nil {
+		return fmt.Errorf("missing state data")
+	}
+
+	if version == "" {
+		return fmt.Errorf("missing state version")
+	}
+
+	return state.UnmarshalVersionedJSON(version, data)
+}
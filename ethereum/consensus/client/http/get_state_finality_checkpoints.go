@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 
@@ -11,8 +12,10 @@ import (
 
 // GetStateFinalityCheckpoints returns finality checkpoints for state with given stateID
 // In case finality is not yet achieved returns epoch 0 and ZERO_HASH as root.
-func (c *Client) GetStateFinalityCheckpoints(ctx context.Context, stateID string) (*types.StateFinalityCheckpoints, error) {
+func (c *Client) GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error) {
+	start := time.Now()
 	rv, err := c.getStateFinalityCheckpoints(ctx, stateID)
+	c.metrics.Observe("GetStateFinalityCheckpoints", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -22,7 +25,7 @@ func (c *Client) GetStateFinalityCheckpoints(ctx context.Context, stateID string
 	return rv, err
 }
 
-func (c *Client) getStateFinalityCheckpoints(ctx context.Context, stateID string) (*types.StateFinalityCheckpoints, error) {
+func (c *Client) getStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error) {
 	req, err := newGetStateFinalityCheckpointsRequest(ctx, stateID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetStateFinalityCheckpoints", nil, "Failure preparing request")
@@ -41,14 +44,18 @@ func (c *Client) getStateFinalityCheckpoints(ctx context.Context, stateID string
 	return result, nil
 }
 
-func newGetStateFinalityCheckpointsRequest(ctx context.Context, stateID string) (*http.Request, error) {
+func newGetStateFinalityCheckpointsRequest(ctx context.Context, stateID types.StateID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
 
 	return autorest.CreatePreparer(
 		autorest.AsGet(),
-		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}}/finality_checkpoints", pathParameters),
+		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}/finality_checkpoints", pathParameters),
 	).Prepare(newRequest(ctx))
 }
 
@@ -0,0 +1,63 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetStateFinalityCheckpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetStateFinalityCheckpointsStatusOK(t, c, mockCli) })
+	t.Run("Status404", func(t *testing.T) { testGetStateFinalityCheckpointsStatus404(t, c, mockCli) })
+}
+
+func testGetStateFinalityCheckpointsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/head/finality_checkpoints$").
+		Reply(200).
+		JSON([]byte(`{"execution_optimistic":false,"data":{"previous_justified":{"epoch":"2","root":"0x0200000000000000000000000000000000000000000000000000000000000000"},"current_justified":{"epoch":"3","root":"0x0300000000000000000000000000000000000000000000000000000000000000"},"finalized":{"epoch":"1","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}}}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	checkpoints, err := c.GetStateFinalityCheckpoints(context.Background(), "head")
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&types.StateFinalityCheckpoints{
+			PreviousJustifiedCheckpoint: beaconcommon.Checkpoint{Epoch: 2, Root: beaconcommon.Root{0x02}},
+			CurrentJustifiedCheckpoint:  beaconcommon.Checkpoint{Epoch: 3, Root: beaconcommon.Root{0x03}},
+			FinalizedCheckpoint:         beaconcommon.Checkpoint{Epoch: 1, Root: beaconcommon.Root{0x01}},
+		},
+		checkpoints,
+	)
+}
+
+func testGetStateFinalityCheckpointsStatus404(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/123456789/finality_checkpoints$").
+		Reply(404).
+		JSON([]byte(`{"code":404,"message":"State not found"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	_, err := c.GetStateFinalityCheckpoints(context.Background(), "123456789")
+
+	require.Error(t, err)
+}
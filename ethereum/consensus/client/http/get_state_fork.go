@@ -3,14 +3,19 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetStateFork returns Fork object for state with given stateID
-func (c *Client) GetStateFork(ctx context.Context, stateID string) (*beaconcommon.Fork, error) {
+func (c *Client) GetStateFork(ctx context.Context, stateID types.StateID) (*beaconcommon.Fork, error) {
+	start := time.Now()
 	rv, err := c.getStateFork(ctx, stateID)
+	c.metrics.Observe("GetStateFork", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -20,7 +25,7 @@ func (c *Client) GetStateFork(ctx context.Context, stateID string) (*beaconcommo
 	return rv, err
 }
 
-func (c *Client) getStateFork(ctx context.Context, stateID string) (*beaconcommon.Fork, error) {
+func (c *Client) getStateFork(ctx context.Context, stateID types.StateID) (*beaconcommon.Fork, error) {
 	req, err := newGetStateForkRequest(ctx, stateID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetStateFork", nil, "Failure preparing request")
@@ -39,7 +44,11 @@ func (c *Client) getStateFork(ctx context.Context, stateID string) (*beaconcommo
 	return result, nil
 }
 
-func newGetStateForkRequest(ctx context.Context, stateID string) (*http.Request, error) {
+func newGetStateForkRequest(ctx context.Context, stateID types.StateID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
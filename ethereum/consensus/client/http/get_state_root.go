@@ -3,14 +3,19 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetStateRoot returns State root for state with given stateID
-func (c *Client) GetStateRoot(ctx context.Context, stateID string) (*beaconcommon.Root, error) {
+func (c *Client) GetStateRoot(ctx context.Context, stateID types.StateID) (*beaconcommon.Root, error) {
+	start := time.Now()
 	rv, err := c.getStateRoot(ctx, stateID)
+	c.metrics.Observe("GetStateRoot", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -20,7 +25,7 @@ func (c *Client) GetStateRoot(ctx context.Context, stateID string) (*beaconcommo
 	return rv, err
 }
 
-func (c *Client) getStateRoot(ctx context.Context, stateID string) (*beaconcommon.Root, error) {
+func (c *Client) getStateRoot(ctx context.Context, stateID types.StateID) (*beaconcommon.Root, error) {
 	req, err := newGetStateRootRequest(ctx, stateID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetStateRoot", nil, "Failure preparing request")
@@ -39,7 +44,11 @@ func (c *Client) getStateRoot(ctx context.Context, stateID string) (*beaconcommo
 	return result, nil
 }
 
-func newGetStateRootRequest(ctx context.Context, stateID string) (*http.Request, error) {
+func newGetStateRootRequest(ctx context.Context, stateID types.StateID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
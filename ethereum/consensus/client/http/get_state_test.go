@@ -0,0 +1,138 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetStateStatusOK(t, c, mockCli) })
+	t.Run("VersionAfterData", func(t *testing.T) { testGetStateVersionAfterData(t, c, mockCli) })
+	t.Run("SSZ", func(t *testing.T) { testGetStateSSZ(t, c, mockCli) })
+	t.Run("StatusNotFound", func(t *testing.T) { testGetStateStatusNotFound(t, c, mockCli) })
+	t.Run("InvalidStateID", func(t *testing.T) { testGetStateInvalidStateID(t, c) })
+}
+
+func newTestCapellaStateJSON(t *testing.T) []byte {
+	state := new(capella.BeaconState)
+	state.Slot = 100
+	state.Validators = beaconphase0.ValidatorRegistry{{EffectiveBalance: 32000000000}}
+	state.Balances = beaconphase0.Balances{32000001000}
+	state.FinalizedCheckpoint.Epoch = 1
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	return data
+}
+
+func testGetStateStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	data := newTestCapellaStateJSON(t)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/debug/beacon/states/finalized").
+		Reply(200).
+		SetHeader("Eth-Consensus-Version", "capella").
+		JSON([]byte(fmt.Sprintf(`{"version":"capella","execution_optimistic":false,"finalized":true,"data":%v}`, string(data))))
+
+	mockCli.EXPECT().Gock(req)
+
+	state, err := c.GetState(context.Background(), "finalized")
+	require.NoError(t, err)
+	assert.Equal(t, types.ForkCapella, state.Version)
+	require.NotNil(t, state.Capella)
+	assert.Equal(t, beaconcommon.Slot(100), state.Slot())
+	require.Len(t, state.Validators(), 1)
+	assert.Equal(t, beaconcommon.Gwei(32000000000), state.Validators()[0].EffectiveBalance)
+	assert.Equal(t, beaconphase0.Balances{32000001000}, state.Balances())
+	assert.Equal(t, beaconcommon.Epoch(1), state.FinalizedCheckpoint().Epoch)
+}
+
+func testGetStateVersionAfterData(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	data := newTestCapellaStateJSON(t)
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/debug/beacon/states/head").
+		Reply(200).
+		JSON([]byte(fmt.Sprintf(`{"data":%v,"version":"capella"}`, string(data))))
+
+	mockCli.EXPECT().Gock(req)
+
+	state, err := c.GetState(context.Background(), "head")
+	require.NoError(t, err)
+	require.NotNil(t, state.Capella)
+	assert.Equal(t, beaconcommon.Slot(100), state.Slot())
+}
+
+func testGetStateSSZ(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	spec := configs.Minimal
+	c.SetSpec(spec)
+
+	// build a default state with well sized vectors
+	var buf bytes.Buffer
+	require.NoError(t, deneb.BeaconStateType(spec).New().Serialize(codec.NewEncodingWriter(&buf)))
+	state := new(deneb.BeaconState)
+	require.NoError(t, state.Deserialize(spec, codec.NewDecodingReader(bytes.NewReader(buf.Bytes()), uint64(buf.Len()))))
+	state.Slot = 42
+
+	buf.Reset()
+	require.NoError(t, state.Serialize(spec, codec.NewEncodingWriter(&buf)))
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/debug/beacon/states/42").
+		MatchHeader("Accept", "application/octet-stream").
+		Reply(200).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetHeader("Eth-Consensus-Version", "deneb").
+		BodyString(buf.String())
+
+	mockCli.EXPECT().Gock(req)
+
+	rv, err := c.GetState(context.Background(), "42")
+	require.NoError(t, err)
+	assert.Equal(t, types.ForkDeneb, rv.Version)
+	require.NotNil(t, rv.Deneb)
+	assert.Equal(t, beaconcommon.Slot(42), rv.Slot())
+}
+
+func testGetStateStatusNotFound(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v2/debug/beacon/states/0xdeadbeef00000000000000000000000000000000000000000000000000000000").
+		Reply(404).
+		JSON([]byte(`{"code":404,"message":"State not found"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	_, err := c.GetState(context.Background(), "0xdeadbeef00000000000000000000000000000000000000000000000000000000")
+	require.Error(t, err)
+}
+
+func testGetStateInvalidStateID(t *testing.T, c *Client) {
+	// request is rejected before being sent
+	_, err := c.GetState(context.Background(), "0xdeadbeef")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failure preparing request")
+}
@@ -0,0 +1,74 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetSyncCommitteeDuties returns sync committee duties for given validator indices
+// over the sync committee period of given epoch
+func (c *Client) GetSyncCommitteeDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.SyncCommitteeDuties, error) {
+	start := time.Now()
+	rv, err := c.getSyncCommitteeDuties(ctx, epoch, indices)
+	c.metrics.Observe("GetSyncCommitteeDuties", start, err)
+	if err != nil {
+		c.logger.
+			WithField("epoch", epoch).
+			WithField("validator.indices", indices).
+			WithError(err).Errorf("GetSyncCommitteeDuties failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getSyncCommitteeDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*types.SyncCommitteeDuties, error) {
+	req, err := newGetSyncCommitteeDutiesRequest(ctx, epoch, indices)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeDuties", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeDuties", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetSyncCommitteeDutiesResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeDuties", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetSyncCommitteeDutiesRequest(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"epoch": autorest.Encode("path", epoch.String()),
+	}
+
+	if indices == nil {
+		indices = []beaconcommon.ValidatorIndex{}
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/validator/duties/sync/{epoch}", pathParameters),
+		autorest.WithJSON(indices),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectGetSyncCommitteeDutiesResponse(resp *http.Response) (*types.SyncCommitteeDuties, error) {
+	msg := new(types.SyncCommitteeDuties)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,85 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetSyncCommitteeRewards returns sync committee rewards for the block with given blockID
+// Set validatorIDs to filter result (if empty rewards of all sync committee members are returned)
+func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error) {
+	start := time.Now()
+	rv, err := c.getSyncCommitteeRewards(ctx, blockID, validatorIDs)
+	c.metrics.Observe("GetSyncCommitteeRewards", start, err)
+	if err != nil {
+		c.logger.
+			WithField("block", blockID).
+			WithField("validator.ids", validatorIDs).
+			WithError(err).Errorf("GetSyncCommitteeRewards failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error) {
+	req, err := newGetSyncCommitteeRewardsRequest(ctx, blockID, validatorIDs)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeRewards", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeRewards", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetSyncCommitteeRewardsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommitteeRewards", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetSyncCommitteeRewardsRequest(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) (*http.Request, error) {
+	if err := blockID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"blockID": autorest.Encode("path", blockID),
+	}
+
+	if validatorIDs == nil {
+		validatorIDs = []types.ValidatorID{}
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/beacon/rewards/sync_committee/{blockID}", pathParameters),
+		autorest.WithJSON(validatorIDs),
+	).Prepare(newRequest(ctx))
+}
+
+type getSyncCommitteeRewardsResponseMsg struct {
+	Data []*types.SyncCommitteeReward `json:"data"`
+}
+
+func inspectGetSyncCommitteeRewardsResponse(resp *http.Response) ([]*types.SyncCommitteeReward, error) {
+	msg := new(getSyncCommitteeRewardsResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
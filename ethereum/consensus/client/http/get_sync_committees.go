@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
@@ -12,8 +13,10 @@ import (
 
 // GetSyncCommittees returns the sync committees for given stateID
 // Set epoch to filter result (if nil no filter is applied)
-func (c *Client) GetSyncCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error) {
+func (c *Client) GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error) {
+	start := time.Now()
 	rv, err := c.getSyncCommittees(ctx, stateID, epoch)
+	c.metrics.Observe("GetSyncCommittees", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -24,7 +27,7 @@ func (c *Client) GetSyncCommittees(ctx context.Context, stateID string, epoch *b
 	return rv, err
 }
 
-func (c *Client) getSyncCommittees(ctx context.Context, stateID string, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error) {
+func (c *Client) getSyncCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (*types.SyncCommittees, error) {
 	req, err := newGetSyncCommitteesRequest(ctx, stateID, epoch)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncCommittees", nil, "Failure preparing request")
@@ -43,7 +46,11 @@ func (c *Client) getSyncCommittees(ctx context.Context, stateID string, epoch *b
 	return result, nil
 }
 
-func newGetSyncCommitteesRequest(ctx context.Context, stateID string, epoch *beaconcommon.Epoch) (*http.Request, error) {
+func newGetSyncCommitteesRequest(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
@@ -0,0 +1,63 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// GetSyncing returns node's sync status
+func (c *Client) GetSyncing(ctx context.Context) (*types.Syncing, error) {
+	start := time.Now()
+	rv, err := c.getSyncing(ctx)
+	c.metrics.Observe("GetSyncing", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("GetSyncing failed")
+	}
+
+	return rv, err
+}
+
+func (c *Client) getSyncing(ctx context.Context) (*types.Syncing, error) {
+	req, err := newGetSyncingRequest(ctx)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncing", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncing", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetSyncingResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetSyncing", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetSyncingRequest(ctx context.Context) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/node/syncing"),
+	).Prepare(newRequest(ctx))
+}
+
+type getSyncingResponseMsg struct {
+	Data *types.Syncing `json:"data"`
+}
+
+func inspectGetSyncingResponse(resp *http.Response) (*types.Syncing, error) {
+	msg := new(getSyncingResponseMsg)
+	err := inspectResponse(resp, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 
@@ -10,8 +11,10 @@ import (
 )
 
 // GetValidator returns validator specified by stateID and validatorID
-func (c *Client) GetValidator(ctx context.Context, stateID, validatorID string) (*types.Validator, error) {
+func (c *Client) GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error) {
+	start := time.Now()
 	rv, err := c.getValidator(ctx, stateID, validatorID)
+	c.metrics.Observe("GetValidator", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -22,7 +25,7 @@ func (c *Client) GetValidator(ctx context.Context, stateID, validatorID string)
 	return rv, err
 }
 
-func (c *Client) getValidator(ctx context.Context, stateID, validatorID string) (*types.Validator, error) {
+func (c *Client) getValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error) {
 	req, err := newGetValidatorRequest(ctx, stateID, validatorID)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidator", nil, "Failure preparing request")
@@ -41,7 +44,15 @@ func (c *Client) getValidator(ctx context.Context, stateID, validatorID string)
 	return result, nil
 }
 
-func newGetValidatorRequest(ctx context.Context, stateID, validatorID string) (*http.Request, error) {
+func newGetValidatorRequest(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validatorID.Validate(); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID":     autorest.Encode("path", stateID),
 		"validatorID": autorest.Encode("path", validatorID),
@@ -3,16 +3,26 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetValidatorBalances returns list of validator balances.
 // Set validatorsIDs to filter validator result (if empty no filter is applied)
-func (c *Client) GetValidatorBalances(ctx context.Context, stateID string, validatorIDs []string) ([]*types.ValidatorBalance, error) {
+//
+// If there are more validatorIDs than the configured chunk size, POST endpoint is used if the node
+// supports it otherwise validatorIDs are split into chunks queried concurrently. In both cases,
+// balances are returned sorted by validator index so the result does not depend on the endpoint used.
+func (c *Client) GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
+	start := time.Now()
 	rv, err := c.getValidatorBalances(ctx, stateID, validatorIDs)
+	c.metrics.Observe("GetValidatorBalances", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -23,7 +33,59 @@ func (c *Client) GetValidatorBalances(ctx context.Context, stateID string, valid
 	return rv, err
 }
 
-func (c *Client) getValidatorBalances(ctx context.Context, stateID string, validatorIDs []string) ([]*types.ValidatorBalance, error) {
+func (c *Client) getValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
+	if len(validatorIDs) <= c.validatorsChunkSize {
+		return c.getValidatorBalancesPage(ctx, stateID, validatorIDs)
+	}
+
+	if !c.postValidatorsUnsupported.Load() {
+		rv, err := c.postValidatorBalances(ctx, stateID, validatorIDs)
+		if err == nil {
+			return mergeByValidatorIndex([][]*types.ValidatorBalance{rv}, balanceValidatorIndex), nil
+		}
+		if !isUnsupportedEndpoint(err) {
+			return nil, err
+		}
+
+		rv, err = c.getValidatorBalancesByChunks(ctx, stateID, validatorIDs)
+		if err == nil {
+			// node can serve the state but failed on POST, so it does not support it
+			c.logger.Warnf("Node does not support POST validators endpoints, falling back to chunked GET requests")
+			c.postValidatorsUnsupported.Store(true)
+		}
+
+		return rv, err
+	}
+
+	return c.getValidatorBalancesByChunks(ctx, stateID, validatorIDs)
+}
+
+func (c *Client) getValidatorBalancesByChunks(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
+	chunks := chunkIDs(validatorIDs, c.validatorsChunkSize)
+	results := make([][]*types.ValidatorBalance, len(chunks))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.validatorsParallelism)
+	for i := range chunks {
+		i := i
+		g.Go(func() (err error) {
+			results[i], err = c.getValidatorBalancesPage(gctx, stateID, chunks[i])
+			return
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return mergeByValidatorIndex(results, balanceValidatorIndex), nil
+}
+
+func balanceValidatorIndex(bal *types.ValidatorBalance) beaconcommon.ValidatorIndex {
+	return bal.Index
+}
+
+func (c *Client) getValidatorBalancesPage(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
 	req, err := newGetValidatorBalancesRequest(ctx, stateID, validatorIDs)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidatorBalances", nil, "Failure preparing request")
@@ -42,23 +104,71 @@ func (c *Client) getValidatorBalances(ctx context.Context, stateID string, valid
 	return result, nil
 }
 
-func newGetValidatorBalancesRequest(ctx context.Context, stateID string, validatorIDs []string) (*http.Request, error) {
+func (c *Client) postValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
+	req, err := newPostValidatorBalancesRequest(ctx, stateID, validatorIDs)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidatorBalances", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidatorBalances", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetValidatorBalancesResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidatorBalances", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetValidatorBalancesRequest(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
 
 	queryParameters := map[string]interface{}{}
 	if len(validatorIDs) != 0 {
-		queryParameters["validator_id"] = validatorIDs
+		queryParameters["id"] = strings.Join(types.ValidatorIDStrings(validatorIDs), ",")
 	}
 
 	return autorest.CreatePreparer(
 		autorest.AsGet(),
-		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}/ValidatorBalances", pathParameters),
+		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}/validator_balances", pathParameters),
 		autorest.WithQueryParameters(queryParameters),
 	).Prepare(newRequest(ctx))
 }
 
+func newPostValidatorBalancesRequest(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"stateID": autorest.Encode("path", stateID),
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}/validator_balances", pathParameters),
+		autorest.WithJSON(validatorIDs),
+	).Prepare(newRequest(ctx))
+}
+
 type getValidatorBalancesResponseMsg struct {
 	Data []*types.ValidatorBalance `json:"data"`
 }
@@ -0,0 +1,65 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetValidatorBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testGetValidatorBalancesStatusOK(t, c, mockCli) })
+
+	c.validatorsChunkSize = 1
+	t.Run("POST", func(t *testing.T) { testGetValidatorBalancesPOST(t, c, mockCli) })
+}
+
+func testGetValidatorBalancesStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/head/validator_balances").
+		MatchParams(map[string]string{"id": "1,2"}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"1","balance":"32000000000"},{"index":"2","balance":"31000000000"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	balances, err := c.GetValidatorBalances(context.Background(), "head", []types.ValidatorID{"1", "2"})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*types.ValidatorBalance{{Index: 1, Balance: 32000000000}, {Index: 2, Balance: 31000000000}},
+		balances,
+	)
+}
+
+func testGetValidatorBalancesPOST(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/states/head/validator_balances").
+		JSON([]string{"1", "2"}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"2","balance":"31000000000"},{"index":"1","balance":"32000000000"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	balances, err := c.GetValidatorBalances(context.Background(), "head", []types.ValidatorID{"1", "2"})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	// sorted by index as for chunked requests
+	assert.Equal(t, beaconcommon.ValidatorIndex(1), balances[0].Index)
+	assert.Equal(t, beaconcommon.ValidatorIndex(2), balances[1].Index)
+}
@@ -0,0 +1,102 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestGetValidatorDuties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("ProposerDuties", func(t *testing.T) { testGetProposerDutiesStatusOK(t, c, mockCli) })
+	t.Run("AttesterDuties", func(t *testing.T) { testGetAttesterDutiesStatusOK(t, c, mockCli) })
+	t.Run("SyncCommitteeDuties", func(t *testing.T) { testGetSyncCommitteeDutiesStatusOK(t, c, mockCli) })
+}
+
+func testGetProposerDutiesStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/validator/duties/proposer/100").
+		Reply(200).
+		JSON([]byte(`{"dependent_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2","execution_optimistic":false,"data":[{"pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a","validator_index":"1","slot":"3200"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	duties, err := c.GetProposerDuties(context.Background(), beaconcommon.Epoch(100))
+	require.NoError(t, err)
+	assert.Equal(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2", duties.DependentRoot.String())
+	assert.Equal(
+		t,
+		[]*types.ProposerDuty{{
+			Pubkey:         mustBLSPubkey(t, "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"),
+			ValidatorIndex: 1,
+			Slot:           3200,
+		}},
+		duties.Duties,
+	)
+}
+
+func testGetAttesterDutiesStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/validator/duties/attester/100").
+		JSON([]string{"1", "2"}).
+		Reply(200).
+		JSON([]byte(`{"dependent_root":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2","execution_optimistic":true,"data":[{"pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a","validator_index":"1","committee_index":"3","committee_length":"128","committees_at_slot":"64","validator_committee_index":"12","slot":"3205"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	duties, err := c.GetAttesterDuties(context.Background(), beaconcommon.Epoch(100), []beaconcommon.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2", duties.DependentRoot.String())
+	assert.True(t, duties.ExecutionOptimistic)
+	assert.Equal(
+		t,
+		[]*types.AttesterDuty{{
+			Pubkey:                  mustBLSPubkey(t, "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"),
+			ValidatorIndex:          1,
+			CommitteeIndex:          3,
+			CommitteeLength:         128,
+			CommitteesAtSlot:        64,
+			ValidatorCommitteeIndex: 12,
+			Slot:                    3205,
+		}},
+		duties.Duties,
+	)
+}
+
+func testGetSyncCommitteeDutiesStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/validator/duties/sync/100").
+		JSON([]string{"1"}).
+		Reply(200).
+		JSON([]byte(`{"execution_optimistic":false,"data":[{"pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a","validator_index":"1","validator_sync_committee_indices":["0","300"]}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	duties, err := c.GetSyncCommitteeDuties(context.Background(), beaconcommon.Epoch(100), []beaconcommon.ValidatorIndex{1})
+	require.NoError(t, err)
+	require.Len(t, duties.Duties, 1)
+	assert.Equal(t, beaconcommon.ValidatorIndex(1), duties.Duties[0].ValidatorIndex)
+	assert.Equal(t, []view.Uint64View{0, 300}, duties.Duties[0].ValidatorSyncCommitteeIndices)
+}
+
+func mustBLSPubkey(t *testing.T, s string) beaconcommon.BLSPubkey {
+	var pubkey beaconcommon.BLSPubkey
+	require.NoError(t, pubkey.UnmarshalText([]byte(s)))
+	return pubkey
+}
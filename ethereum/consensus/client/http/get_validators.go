@@ -4,16 +4,25 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/kilnfi/go-utils/ethereum/consensus/types"
 )
 
 // GetValidators returns list of validators
 // Set validatorsIDs and/or statuses to filter result (if empty no filter is applied)
-func (c *Client) GetValidators(ctx context.Context, stateID string, validatorIDs, statuses []string) ([]*types.Validator, error) {
+//
+// If there are more validatorIDs than the configured chunk size, POST endpoint is used if the node
+// supports it otherwise validatorIDs are split into chunks queried concurrently. In both cases,
+// validators are returned sorted by index so the result does not depend on the endpoint used.
+func (c *Client) GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
+	start := time.Now()
 	rv, err := c.getValidators(ctx, stateID, validatorIDs, statuses)
+	c.metrics.Observe("GetValidators", start, err)
 	if err != nil {
 		c.logger.
 			WithField("state", stateID).
@@ -25,7 +34,59 @@ func (c *Client) GetValidators(ctx context.Context, stateID string, validatorIDs
 	return rv, err
 }
 
-func (c *Client) getValidators(ctx context.Context, stateID string, validatorIDs, statuses []string) ([]*types.Validator, error) {
+func (c *Client) getValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
+	if len(validatorIDs) <= c.validatorsChunkSize {
+		return c.getValidatorsPage(ctx, stateID, validatorIDs, statuses)
+	}
+
+	if !c.postValidatorsUnsupported.Load() {
+		rv, err := c.postValidators(ctx, stateID, validatorIDs, statuses)
+		if err == nil {
+			return mergeByValidatorIndex([][]*types.Validator{rv}, validatorIndex), nil
+		}
+		if !isUnsupportedEndpoint(err) {
+			return nil, err
+		}
+
+		rv, err = c.getValidatorsByChunks(ctx, stateID, validatorIDs, statuses)
+		if err == nil {
+			// node can serve the state but failed on POST, so it does not support it
+			c.logger.Warnf("Node does not support POST validators endpoints, falling back to chunked GET requests")
+			c.postValidatorsUnsupported.Store(true)
+		}
+
+		return rv, err
+	}
+
+	return c.getValidatorsByChunks(ctx, stateID, validatorIDs, statuses)
+}
+
+func (c *Client) getValidatorsByChunks(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
+	chunks := chunkIDs(validatorIDs, c.validatorsChunkSize)
+	results := make([][]*types.Validator, len(chunks))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.validatorsParallelism)
+	for i := range chunks {
+		i := i
+		g.Go(func() (err error) {
+			results[i], err = c.getValidatorsPage(gctx, stateID, chunks[i], statuses)
+			return
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return mergeByValidatorIndex(results, validatorIndex), nil
+}
+
+func validatorIndex(val *types.Validator) beaconcommon.ValidatorIndex {
+	return val.Index
+}
+
+func (c *Client) getValidatorsPage(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
 	req, err := newGetValidatorsRequest(ctx, stateID, validatorIDs, statuses)
 	if err != nil {
 		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidators", nil, "Failure preparing request")
@@ -44,14 +105,41 @@ func (c *Client) getValidators(ctx context.Context, stateID string, validatorIDs
 	return result, nil
 }
 
-func newGetValidatorsRequest(ctx context.Context, stateID string, validatorIDs, statuses []string) (*http.Request, error) {
+func (c *Client) postValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
+	req, err := newPostValidatorsRequest(ctx, stateID, validatorIDs, statuses)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidators", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidators", resp, "Failure sending request")
+	}
+
+	result, err := inspectGetValidatorsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "GetValidators", resp, "Invalid response")
+	}
+
+	return result, nil
+}
+
+func newGetValidatorsRequest(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
 	pathParameters := map[string]interface{}{
 		"stateID": autorest.Encode("path", stateID),
 	}
 
 	queryParameters := map[string]interface{}{}
 	if len(validatorIDs) != 0 {
-		queryParameters["id"] = strings.Join(validatorIDs, ",")
+		queryParameters["id"] = strings.Join(types.ValidatorIDStrings(validatorIDs), ",")
 	}
 
 	if len(statuses) != 0 {
@@ -65,6 +153,35 @@ func newGetValidatorsRequest(ctx context.Context, stateID string, validatorIDs,
 	).Prepare(newRequest(ctx))
 }
 
+type postValidatorsRequestMsg struct {
+	IDs      []types.ValidatorID `json:"ids,omitempty"`
+	Statuses []string            `json:"statuses,omitempty"`
+}
+
+func newPostValidatorsRequest(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) (*http.Request, error) {
+	if err := stateID.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateValidatorIDs(validatorIDs); err != nil {
+		return nil, err
+	}
+
+	pathParameters := map[string]interface{}{
+		"stateID": autorest.Encode("path", stateID),
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPathParameters("eth/v1/beacon/states/{stateID}/validators", pathParameters),
+		autorest.WithJSON(&postValidatorsRequestMsg{
+			IDs:      validatorIDs,
+			Statuses: statuses,
+		}),
+	).Prepare(newRequest(ctx))
+}
+
 type getValidatorsResponseMsg struct {
 	Data []*types.Validator `json:"data"`
 }
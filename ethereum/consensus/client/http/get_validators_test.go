@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -23,21 +24,27 @@ func TestGetValidators(t *testing.T) {
 	c := NewClientFromClient(mockCli)
 
 	t.Run("StatusOK", func(t *testing.T) { testGetValidatorsStatusOK(t, c, mockCli) })
+	t.Run("InvalidValidatorID", func(t *testing.T) { testGetValidatorsInvalidValidatorID(t, c) })
+
+	c.validatorsChunkSize = 2
+	t.Run("POST", func(t *testing.T) { testGetValidatorsPOST(t, c, mockCli) })
+	// must run last as it disables POST on the client
+	t.Run("Chunks", func(t *testing.T) { testGetValidatorsChunks(t, c, mockCli) })
 }
 
 func testGetValidatorsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
 	req := httptestutils.NewGockRequest()
-	req.Get("/eth/v1/beacon/states/test-state/validators").
+	req.Get("/eth/v1/beacon/states/finalized/validators").
 		MatchParams(map[string]string{
 			"status": "sA,sB",
-			"id":     "vA,vB,vC",
+			"id":     "1,2,3",
 		}).
 		Reply(200).
 		JSON([]byte(`{"data":[]}`))
 
 	mockCli.EXPECT().Gock(req)
 
-	vals, err := c.GetValidators(context.Background(), "test-state", []string{"vA", "vB", "vC"}, []string{"sA", "sB"})
+	vals, err := c.GetValidators(context.Background(), "finalized", []types.ValidatorID{"1", "2", "3"}, []string{"sA", "sB"})
 	require.NoError(t, err)
 	assert.Equal(
 		t,
@@ -45,3 +52,70 @@ func testGetValidatorsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.M
 		vals,
 	)
 }
+
+func testGetValidatorsInvalidValidatorID(t *testing.T, c *Client) {
+	// request is rejected before being sent
+	_, err := c.GetValidators(context.Background(), "head", []types.ValidatorID{"1", "vB"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid validator ID "vB"`)
+}
+
+func testGetValidatorsPOST(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/states/head/validators").
+		JSON(map[string]interface{}{"ids": []string{"1", "2", "3"}, "statuses": []string{"active_ongoing"}}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"2","status":"active_ongoing","balance":"2"},{"index":"1","status":"active_ongoing","balance":"1"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	vals, err := c.GetValidators(context.Background(), "head", []types.ValidatorID{"1", "2", "3"}, []string{"active_ongoing"})
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+	// sorted by index as for chunked requests
+	assert.Equal(t, beaconcommon.ValidatorIndex(1), vals[0].Index)
+	assert.Equal(t, beaconcommon.ValidatorIndex(2), vals[1].Index)
+}
+
+func testGetValidatorsChunks(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	postReq := httptestutils.NewGockRequest()
+	postReq.Post("/eth/v1/beacon/states/head/validators").
+		Reply(405).
+		JSON([]byte(`{"code":405,"message":"Method not allowed"}`))
+
+	mockCli.EXPECT().Gock(postReq)
+
+	// second call goes directly to chunks
+	for i := 0; i < 2; i++ {
+		chunkReq1 := httptestutils.NewGockRequest()
+		chunkReq1.Get("/eth/v1/beacon/states/head/validators").
+			MatchParams(map[string]string{"id": "^5,3$"}).
+			Reply(200).
+			JSON([]byte(`{"data":[{"index":"3","status":"active_ongoing","balance":"3"},{"index":"5","status":"active_ongoing","balance":"5"}]}`))
+
+		chunkReq2 := httptestutils.NewGockRequest()
+		chunkReq2.Get("/eth/v1/beacon/states/head/validators").
+			MatchParams(map[string]string{"id": "^1,4$"}).
+			Reply(200).
+			JSON([]byte(`{"data":[{"index":"1","status":"active_ongoing","balance":"1"},{"index":"4","status":"active_ongoing","balance":"4"}]}`))
+
+		chunkReq3 := httptestutils.NewGockRequest()
+		chunkReq3.Get("/eth/v1/beacon/states/head/validators").
+			MatchParams(map[string]string{"id": "^3$"}).
+			Reply(200).
+			JSON([]byte(`{"data":[{"index":"3","status":"active_ongoing","balance":"3"}]}`))
+
+		mockCli.EXPECT().Gock(chunkReq1)
+		mockCli.EXPECT().Gock(chunkReq2)
+		mockCli.EXPECT().Gock(chunkReq3)
+
+		vals, err := c.GetValidators(context.Background(), "head", []types.ValidatorID{"5", "3", "1", "4", "3"}, nil)
+		require.NoError(t, err)
+
+		indices := []beaconcommon.ValidatorIndex{}
+		for _, val := range vals {
+			indices = append(indices, val.Index)
+		}
+		assert.Equal(t, []beaconcommon.ValidatorIndex{1, 3, 4, 5}, indices)
+	}
+}
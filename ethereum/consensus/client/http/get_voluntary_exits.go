@@ -3,6 +3,7 @@ package eth2http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
@@ -10,7 +11,9 @@ import (
 
 // GetVoluntaryExits returns voluntary exits known by the node but not necessarily incorporated into any block.
 func (c *Client) GetVoluntaryExits(ctx context.Context) (beaconphase0.VoluntaryExits, error) {
+	start := time.Now()
 	rv, err := c.getVoluntaryExits(ctx)
+	c.metrics.Observe("GetVoluntaryExits", start, err)
 	if err != nil {
 		c.logger.WithError(err).Errorf("GetVoluntaryExits failed")
 	}
@@ -0,0 +1,32 @@
+package eth2http
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httpmetrics "github.com/kilnfi/go-utils/net/http/metrics"
+)
+
+// newMetrics creates client metrics labeled by endpoint (i.e. client method name)
+func newMetrics() *httpmetrics.Collector {
+	return httpmetrics.NewCollector(&httpmetrics.CollectorOpts{
+		Subsystem: "consensus_client",
+		Label:     "endpoint",
+		Calls:     "calls to the beacon node API",
+		ErrorCode: errorCode,
+	})
+}
+
+// errorCode returns the beacon error code of err
+//
+// It defaults to the response status code if the node did not return a beacon error,
+// and to "none" if no response was received
+func errorCode(err error) string {
+	var beaconErr *types.Error
+	if errors.As(err, &beaconErr) {
+		return strconv.Itoa(beaconErr.Code)
+	}
+
+	return httpmetrics.StatusCode(err)
+}
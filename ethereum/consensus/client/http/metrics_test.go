@@ -0,0 +1,70 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, c.RegisterMetrics(reg))
+
+	okReq := httptestutils.NewGockRequest()
+	okReq.Get("/eth/v1/node/version").
+		Reply(200).
+		JSON([]byte(`{"data":{"version":"Lighthouse/v0.1.5"}}`))
+	errReq := httptestutils.NewGockRequest()
+	errReq.Get("/eth/v1/node/version").
+		Reply(503).
+		JSON([]byte(`{"code":503,"message":"Beacon node is currently syncing"}`))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(okReq),
+		mockCli.EXPECT().Gock(errReq),
+		mockCli.EXPECT().Do(gomock.Any()).Return(nil, fmt.Errorf("connection refused")),
+	)
+
+	_, err := c.GetNodeVersion(context.Background())
+	require.NoError(t, err)
+	_, err = c.GetNodeVersion(context.Background())
+	require.Error(t, err)
+	_, err = c.GetNodeVersion(context.Background())
+	require.Error(t, err)
+
+	expected := `
+# HELP consensus_client_errors_total Number of failed calls to the beacon node API by error code
+# TYPE consensus_client_errors_total counter
+consensus_client_errors_total{code="503",endpoint="GetNodeVersion"} 1
+consensus_client_errors_total{code="none",endpoint="GetNodeVersion"} 1
+# HELP consensus_client_requests_total Number of calls to the beacon node API
+# TYPE consensus_client_requests_total counter
+consensus_client_requests_total{endpoint="GetNodeVersion"} 3
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "consensus_client_errors_total", "consensus_client_requests_total"))
+	count, err := testutil.GatherAndCount(reg, "consensus_client_request_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// a second client registering on the same registry shares collectors
+	other := NewClientFromClient(mockCli)
+	require.NoError(t, other.RegisterMetrics(reg))
+}
@@ -0,0 +1,152 @@
+package eth2http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"golang.org/x/time/rate"
+)
+
+// WithRetry returns a SendDecorator retrying GET requests that failed with a transport error,
+// a 429 Too Many Requests or a 5xx response (except 501 Not Implemented)
+//
+// Requests are attempted at most cfg.MaxAttempts times. Between attempts, the decorator waits
+// for the duration indicated by the Retry-After response header if any, otherwise for an exponential
+// backoff starting at cfg.MinWait with jitter and capped at cfg.MaxWait. If Retry-After is longer than
+// cfg.MaxWait, the response is returned as is. Other methods are sent once as they are not idempotent,
+// as well as requests whose context has been marked with withoutRetry.
+func WithRetry(cfg *RetryConfig) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet || isRetryDisabled(req.Context()) {
+				return s.Do(req)
+			}
+
+			for attempt := 1; ; attempt++ {
+				resp, err := s.Do(req)
+				if attempt >= cfg.MaxAttempts || !isRetriable(req.Context(), resp, err) {
+					return resp, err
+				}
+
+				wait, ok := retryWait(cfg, attempt, resp)
+				if !ok {
+					return resp, err
+				}
+
+				if resp != nil {
+					// drain body so the connection can be re-used
+					_, _ = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				}
+			}
+		})
+	}
+}
+
+type noRetryKey struct{}
+
+// withoutRetry returns a context for requests that must be sent once
+//
+// It is used on endpoints reporting state through error status codes (e.g. 503 on health endpoint)
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+func isRetryDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return disabled
+}
+
+// isRetriable indicates whether a request that returned resp and err may succeed if sent again
+func isRetriable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented:
+		// used to detect unsupported endpoints, it would be the same on next attempt
+		return false
+	default:
+		return resp.StatusCode >= 500
+	}
+}
+
+// retryWait returns how long to wait before the next attempt, and false if the request should not be retried
+func retryWait(cfg *RetryConfig, attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, wait <= cfg.MaxWait.Duration
+		}
+	}
+
+	return backoff(cfg.MinWait.Duration, cfg.MaxWait.Duration, attempt), true
+}
+
+// backoff returns how long to wait before the given attempt (starting at 1), growing exponentially
+// from minWait and capped at maxWait
+func backoff(minWait, maxWait time.Duration, attempt int) time.Duration {
+	wait := maxWait
+	if shift := attempt - 1; shift < 32 {
+		if b := minWait << shift; b > 0 && b < wait {
+			wait = b
+		}
+	}
+
+	// equal jitter: wait between half and full backoff so concurrent clients spread their retries
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
+
+// WithRateLimit returns a SendDecorator waiting for limiter to allow each request before sending it
+func WithRateLimit(limiter *rate.Limiter) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return s.Do(req)
+		})
+	}
+}
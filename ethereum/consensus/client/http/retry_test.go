@@ -0,0 +1,314 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+const genesisResponse = `{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","genesis_fork_version":"0x00000000"}}`
+
+func newTestRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts: 3,
+		MinWait:     &kilntypes.Duration{Duration: time.Millisecond},
+		MaxWait:     &kilntypes.Duration{Duration: 10 * time.Millisecond},
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(autorest.DecorateSender(mockCli, WithRetry(newTestRetryConfig())))
+
+	t.Run("RetryOn5xx", func(t *testing.T) { testWithRetryOn5xx(t, c, mockCli) })
+	t.Run("RetryOnTransportError", func(t *testing.T) { testWithRetryOnTransportError(t, c, mockCli) })
+	t.Run("RetryAfter", func(t *testing.T) { testWithRetryAfter(t, c, mockCli) })
+	t.Run("RetryAfterTooLong", func(t *testing.T) { testWithRetryAfterTooLong(t, c, mockCli) })
+	t.Run("MaxAttempts", func(t *testing.T) { testWithRetryMaxAttempts(t, c, mockCli) })
+	t.Run("NotRetriable", func(t *testing.T) { testWithRetryNotRetriable(t, c, mockCli) })
+	t.Run("POST", func(t *testing.T) { testWithRetryPOST(t, c, mockCli) })
+	t.Run("Health", func(t *testing.T) { testWithRetryHealth(t, c, mockCli) })
+}
+
+func testWithRetryOn5xx(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	failReq := httptestutils.NewGockRequest()
+	failReq.Get("/eth/v1/beacon/genesis").
+		Reply(503).
+		JSON([]byte(`{"code":503,"message":"Service unavailable"}`))
+
+	okReq := httptestutils.NewGockRequest()
+	okReq.Get("/eth/v1/beacon/genesis").
+		Reply(200).
+		JSON([]byte(genesisResponse))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(failReq),
+		mockCli.EXPECT().Gock(okReq),
+	)
+
+	genesis, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1606824023), uint64(genesis.GenesisTime))
+}
+
+func testWithRetryOnTransportError(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	okReq := httptestutils.NewGockRequest()
+	okReq.Get("/eth/v1/beacon/genesis").
+		Reply(200).
+		JSON([]byte(genesisResponse))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Do(gomock.Any()).Return(nil, fmt.Errorf("connection reset by peer")),
+		mockCli.EXPECT().Gock(okReq),
+	)
+
+	_, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+}
+
+func testWithRetryAfter(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	failReq := httptestutils.NewGockRequest()
+	failReq.Get("/eth/v1/beacon/genesis").
+		Reply(429).
+		SetHeader("Retry-After", "0").
+		JSON([]byte(`{"code":429,"message":"Too many requests"}`))
+
+	okReq := httptestutils.NewGockRequest()
+	okReq.Get("/eth/v1/beacon/genesis").
+		Reply(200).
+		JSON([]byte(genesisResponse))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(failReq),
+		mockCli.EXPECT().Gock(okReq),
+	)
+
+	_, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+}
+
+func testWithRetryAfterTooLong(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	failReq := httptestutils.NewGockRequest()
+	failReq.Get("/eth/v1/beacon/genesis").
+		Reply(429).
+		SetHeader("Retry-After", "60").
+		JSON([]byte(`{"code":429,"message":"Too many requests"}`))
+
+	mockCli.EXPECT().Gock(failReq)
+
+	_, err := c.GetGenesis(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "429", errorCode(err))
+}
+
+func testWithRetryMaxAttempts(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	// a gock request matches once, so each attempt needs its own
+	for i := 0; i < 3; i++ {
+		failReq := httptestutils.NewGockRequest()
+		failReq.Get("/eth/v1/beacon/genesis").
+			Reply(502).
+			JSON([]byte(`{"code":502,"message":"Bad gateway"}`))
+
+		mockCli.EXPECT().Gock(failReq)
+	}
+
+	_, err := c.GetGenesis(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "502", errorCode(err))
+}
+
+func testWithRetryNotRetriable(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	for _, status := range []int{400, 404, 501} {
+		req := httptestutils.NewGockRequest()
+		req.Get("/eth/v1/beacon/genesis").
+			Reply(status).
+			JSON([]byte(fmt.Sprintf(`{"code":%d,"message":"error"}`, status)))
+
+		mockCli.EXPECT().Gock(req)
+
+		_, err := c.GetGenesis(context.Background())
+		require.Error(t, err)
+	}
+}
+
+func testWithRetryPOST(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/attestations").
+		Reply(503).
+		JSON([]byte(`{"code":503,"message":"Service unavailable"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitAttestations(context.Background(), beaconphase0.Attestations{})
+	require.Error(t, err)
+}
+
+func testWithRetryHealth(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/node/health").
+		Reply(503)
+
+	mockCli.EXPECT().Gock(req)
+
+	health, err := c.GetHealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, types.HealthNotInitialized, health)
+}
+
+func TestConfigDefault(t *testing.T) {
+	cfg := (&Config{}).SetDefault()
+	assert.Equal(t, 1, cfg.Retry.MaxAttempts)
+	assert.Equal(t, time.Second, cfg.EventsReconnect.MinWait.Duration)
+	assert.Equal(t, 30*time.Second, cfg.EventsReconnect.MaxWait.Duration)
+}
+
+func TestWithRetryContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+
+	cfg := newTestRetryConfig()
+	cfg.MinWait.Duration, cfg.MaxWait.Duration = time.Hour, time.Hour
+	c := NewClientFromClient(autorest.DecorateSender(mockCli, WithRetry(cfg)))
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/genesis").
+		Reply(503)
+
+	mockCli.EXPECT().Gock(req)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetGenesis(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{value: ""},
+		{value: "invalid"},
+		{value: "-1"},
+		{value: "0", ok: true},
+		{value: "120", wait: 2 * time.Minute, ok: true},
+		{value: "Mon, 01 Jan 2024 00:00:30 GMT", wait: 30 * time.Second, ok: true},
+		{value: "Sun, 31 Dec 2023 23:59:00 GMT", ok: true},
+	} {
+		t.Run(tt.value, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	cfg := &RetryConfig{
+		MinWait: &kilntypes.Duration{Duration: 100 * time.Millisecond},
+		MaxWait: &kilntypes.Duration{Duration: time.Second},
+	}
+
+	for _, tt := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 4, min: 400 * time.Millisecond, max: 800 * time.Millisecond},
+		{attempt: 5, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 100, min: 500 * time.Millisecond, max: time.Second},
+	} {
+		t.Run(fmt.Sprintf("attempt=%v", tt.attempt), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				wait, ok := retryWait(cfg, tt.attempt, nil)
+				require.True(t, ok)
+				assert.GreaterOrEqual(t, wait, tt.min)
+				assert.LessOrEqual(t, wait, tt.max)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, tt := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 1, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 3, min: 2 * time.Second, max: 4 * time.Second},
+		{attempt: 6, min: 15 * time.Second, max: 30 * time.Second},
+		{attempt: 1000, min: 15 * time.Second, max: 30 * time.Second},
+	} {
+		t.Run(fmt.Sprintf("attempt=%v", tt.attempt), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				wait := backoff(time.Second, 30*time.Second, tt.attempt)
+				assert.GreaterOrEqual(t, wait, tt.min)
+				assert.LessOrEqual(t, wait, tt.max)
+			}
+		})
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+
+	// bucket holds a single token and is refilled every hour
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	c := NewClientFromClient(autorest.DecorateSender(mockCli, WithRateLimit(limiter)))
+
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/genesis").
+		Reply(200).
+		JSON([]byte(genesisResponse))
+
+	mockCli.EXPECT().Gock(req)
+
+	_, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+
+	// second request is not sent as no token is available before ctx expires
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.GetGenesis(ctx)
+	require.Error(t, err)
+}
+
+func TestIsRetriable(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, isRetriable(ctx, nil, fmt.Errorf("connection refused")))
+	assert.False(t, isRetriable(ctx, nil, context.Canceled))
+	assert.True(t, isRetriable(ctx, &http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, isRetriable(ctx, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+	assert.False(t, isRetriable(ctx, &http.Response{StatusCode: http.StatusNotImplemented}, nil))
+	assert.False(t, isRetriable(ctx, &http.Response{StatusCode: http.StatusNotFound}, nil))
+	assert.False(t, isRetriable(ctx, &http.Response{StatusCode: http.StatusOK}, nil))
+}
@@ -0,0 +1,180 @@
+package eth2http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// StreamValidators calls fn on each validator as it is decoded from the response
+// Set validatorsIDs and/or statuses to filter result (if empty no filter is applied)
+//
+// Contrary to GetValidators, the response is never fully loaded in memory, which is preferable
+// when querying all validators of a large network. If fn returns an error, streaming stops
+// and StreamValidators returns this error.
+//
+// If there are more validatorIDs than the configured chunk size, POST endpoint is used if the node
+// supports it otherwise validatorIDs are split into chunks queried sequentially. In this case,
+// validators are not sorted by index across chunks.
+func (c *Client) StreamValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string, fn func(*types.Validator) error) error {
+	start := time.Now()
+	err := c.streamValidators(ctx, stateID, validatorIDs, statuses, fn)
+	c.metrics.Observe("StreamValidators", start, err)
+	if err != nil {
+		c.logger.
+			WithField("state", stateID).
+			WithField("validator.ids", validatorIDs).
+			WithField("statuses", statuses).
+			WithError(err).Errorf("StreamValidators failed")
+	}
+
+	return err
+}
+
+func (c *Client) streamValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string, fn func(*types.Validator) error) error {
+	if len(validatorIDs) <= c.validatorsChunkSize {
+		return c.streamValidatorsRequest(ctx, fn, func() (*http.Request, error) {
+			return newGetValidatorsRequest(ctx, stateID, validatorIDs, statuses)
+		})
+	}
+
+	if !c.postValidatorsUnsupported.Load() {
+		err := c.streamValidatorsRequest(ctx, fn, func() (*http.Request, error) {
+			return newPostValidatorsRequest(ctx, stateID, validatorIDs, statuses)
+		})
+		if !isUnsupportedEndpoint(err) {
+			return err
+		}
+
+		// unsupported endpoint is detected on status code so fn has not been called yet
+		err = c.streamValidatorsByChunks(ctx, stateID, validatorIDs, statuses, fn)
+		if err == nil {
+			c.logger.Warnf("Node does not support POST validators endpoints, falling back to chunked GET requests")
+			c.postValidatorsUnsupported.Store(true)
+		}
+
+		return err
+	}
+
+	return c.streamValidatorsByChunks(ctx, stateID, validatorIDs, statuses, fn)
+}
+
+func (c *Client) streamValidatorsByChunks(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string, fn func(*types.Validator) error) error {
+	for _, chunk := range chunkIDs(validatorIDs, c.validatorsChunkSize) {
+		chunk := chunk
+		err := c.streamValidatorsRequest(ctx, fn, func() (*http.Request, error) {
+			return newGetValidatorsRequest(ctx, stateID, chunk, statuses)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) streamValidatorsRequest(ctx context.Context, fn func(*types.Validator) error, newReq func() (*http.Request, error)) error {
+	req, err := newReq()
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "StreamValidators", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "StreamValidators", resp, "Failure sending request")
+	}
+
+	err = inspectStreamValidatorsResponse(resp, fn)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "StreamValidators", resp, "Invalid response")
+	}
+
+	return nil
+}
+
+func inspectStreamValidatorsResponse(resp *http.Response, fn func(*types.Validator) error) error {
+	return autorest.Respond(
+		resp,
+		WithBeaconErrorUnlessOK(),
+		byStreamingJSONData(fn),
+		autorest.ByClosing(),
+	)
+}
+
+// byStreamingJSONData decodes items of the "data" array of a JSON response body one by one,
+// calling fn on each item as soon as it is decoded
+func byStreamingJSONData[T any](fn func(*T) error) autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			err := r.Respond(resp)
+			if err != nil {
+				return err
+			}
+
+			return decodeJSONDataStream(resp.Body, fn)
+		})
+	}
+}
+
+func decodeJSONDataStream[T any](body io.Reader, fn func(*T) error) error {
+	dec := json.NewDecoder(body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key, _ := tok.(string); key != "data" {
+			// skip other fields (e.g. execution_optimistic, finalized)
+			if err := dec.Decode(new(json.RawMessage)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return fmt.Errorf("invalid data field: %w", err)
+		}
+
+		for dec.More() {
+			item := new(T)
+			if err := dec.Decode(item); err != nil {
+				return err
+			}
+
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("invalid JSON: expected %q but got %v", delim, tok)
+	}
+
+	return nil
+}
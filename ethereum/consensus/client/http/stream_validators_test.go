@@ -0,0 +1,310 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestStreamValidators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testStreamValidatorsStatusOK(t, c, mockCli) })
+	t.Run("CallbackError", func(t *testing.T) { testStreamValidatorsCallbackError(t, c, mockCli) })
+	t.Run("StatusNotFound", func(t *testing.T) { testStreamValidatorsStatusNotFound(t, c, mockCli) })
+
+	c.validatorsChunkSize = 2
+	t.Run("POST", func(t *testing.T) { testStreamValidatorsPOST(t, c, mockCli) })
+	// must run last as it disables POST on the client
+	t.Run("Chunks", func(t *testing.T) { testStreamValidatorsChunks(t, c, mockCli) })
+}
+
+func testStreamValidatorsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/head/validators").
+		MatchParams(map[string]string{
+			"status": "active_ongoing",
+			"id":     "1,2",
+		}).
+		Reply(200).
+		JSON([]byte(`{"execution_optimistic":false,"data":[{"index":"1","status":"active_ongoing","balance":"1"},{"index":"2","status":"active_ongoing","balance":"2"}],"finalized":true}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	var vals []*types.Validator
+	err := c.StreamValidators(context.Background(), "head", []types.ValidatorID{"1", "2"}, []string{"active_ongoing"}, func(val *types.Validator) error {
+		vals = append(vals, val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*types.Validator{
+			{Index: 1, Status: types.ValidatorStatusActiveOngoing, Balance: 1},
+			{Index: 2, Status: types.ValidatorStatusActiveOngoing, Balance: 2},
+		},
+		vals,
+	)
+}
+
+func testStreamValidatorsCallbackError(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/head/validators").
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"1","status":"active_ongoing","balance":"1"},{"index":"2","status":"active_ongoing","balance":"2"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	stopErr := fmt.Errorf("stop")
+	calls := 0
+	err := c.StreamValidators(context.Background(), "head", nil, nil, func(*types.Validator) error {
+		calls++
+		return stopErr
+	})
+	require.ErrorIs(t, err, stopErr)
+	assert.Equal(t, 1, calls)
+}
+
+func testStreamValidatorsStatusNotFound(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Get("/eth/v1/beacon/states/123456789/validators").
+		Reply(404).
+		JSON([]byte(`{"code":404,"message":"State not found"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.StreamValidators(context.Background(), "123456789", nil, nil, func(*types.Validator) error {
+		t.Fatalf("callback must not be called")
+		return nil
+	})
+	require.Error(t, err)
+
+	var beaconErr *types.Error
+	require.ErrorAs(t, err, &beaconErr)
+	assert.Equal(t, 404, beaconErr.Code)
+}
+
+func testStreamValidatorsPOST(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/states/head/validators").
+		JSON(map[string]interface{}{"ids": []string{"1", "2", "3"}}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"1","status":"active_ongoing","balance":"1"},{"index":"2","status":"active_ongoing","balance":"2"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	var indices []beaconcommon.ValidatorIndex
+	err := c.StreamValidators(context.Background(), "head", []types.ValidatorID{"1", "2", "3"}, nil, func(val *types.Validator) error {
+		indices = append(indices, val.Index)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []beaconcommon.ValidatorIndex{1, 2}, indices)
+}
+
+func testStreamValidatorsChunks(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	postReq := httptestutils.NewGockRequest()
+	postReq.Post("/eth/v1/beacon/states/head/validators").
+		Reply(405).
+		JSON([]byte(`{"code":405,"message":"Method not allowed"}`))
+
+	chunkReq1 := httptestutils.NewGockRequest()
+	chunkReq1.Get("/eth/v1/beacon/states/head/validators").
+		MatchParams(map[string]string{"id": "^5,3$"}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"3","status":"active_ongoing","balance":"3"},{"index":"5","status":"active_ongoing","balance":"5"}]}`))
+
+	chunkReq2 := httptestutils.NewGockRequest()
+	chunkReq2.Get("/eth/v1/beacon/states/head/validators").
+		MatchParams(map[string]string{"id": "^1$"}).
+		Reply(200).
+		JSON([]byte(`{"data":[{"index":"1","status":"active_ongoing","balance":"1"}]}`))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(postReq),
+		mockCli.EXPECT().Gock(chunkReq1),
+		mockCli.EXPECT().Gock(chunkReq2),
+	)
+
+	var indices []beaconcommon.ValidatorIndex
+	err := c.StreamValidators(context.Background(), "head", []types.ValidatorID{"5", "3", "1"}, nil, func(val *types.Validator) error {
+		indices = append(indices, val.Index)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []beaconcommon.ValidatorIndex{3, 5, 1}, indices)
+	assert.True(t, c.postValidatorsUnsupported.Load())
+}
+
+func TestDecodeJSONDataStream(t *testing.T) {
+	for _, tt := range []struct {
+		body    string
+		indices []beaconcommon.ValidatorIndex
+		wantErr bool
+	}{
+		{body: `{"data":[]}`},
+		{body: `{"finalized":true,"data":[{"index":"7"}],"meta":{"a":[1,2]}}`, indices: []beaconcommon.ValidatorIndex{7}},
+		{body: `{"data":[{"index":"7"},{"index":"8"}]}`, indices: []beaconcommon.ValidatorIndex{7, 8}},
+		{body: `[]`, wantErr: true},
+		{body: `{"data":{}}`, wantErr: true},
+		{body: `{"data":[{"index":"7"},`, indices: []beaconcommon.ValidatorIndex{7}, wantErr: true},
+		{body: `{"data":[{"index":"x"}]}`, wantErr: true},
+	} {
+		t.Run(tt.body, func(t *testing.T) {
+			var indices []beaconcommon.ValidatorIndex
+			err := decodeJSONDataStream(strings.NewReader(tt.body), func(val *types.Validator) error {
+				indices = append(indices, val.Index)
+				return nil
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.indices, indices)
+		})
+	}
+}
+
+// newValidatorsResponseBody returns the body of a validators response with n validators
+func newValidatorsResponseBody(b *testing.B, n int) []byte {
+	vals := make([]*types.Validator, n)
+	for i := range vals {
+		vals[i] = &types.Validator{
+			Index:   beaconcommon.ValidatorIndex(i),
+			Status:  types.ValidatorStatusActiveOngoing,
+			Balance: 32000000000,
+			Validator: &beaconphase0.Validator{
+				Pubkey:                beaconcommon.BLSPubkey{byte(i), byte(i >> 8), byte(i >> 16)},
+				WithdrawalCredentials: beaconcommon.Root{0x01},
+				EffectiveBalance:      32000000000,
+				ExitEpoch:             beaconcommon.FAR_FUTURE_EPOCH,
+				WithdrawableEpoch:     beaconcommon.FAR_FUTURE_EPOCH,
+			},
+		}
+	}
+
+	body, err := json.Marshal(&getValidatorsResponseMsg{Data: vals})
+	require.NoError(b, err)
+
+	return body
+}
+
+func newValidatorsResponse(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// benchmarkValidatorsCount is the number of validators in benchmarked responses
+//
+// B/op only counts allocated bytes, most of which are garbage collected while streaming. Compare peak-live-B/op
+// of BenchmarkGetValidators and BenchmarkStreamValidators to see memory held at once, i.e. saved by streaming.
+const benchmarkValidatorsCount = 100000
+
+// liveHeapSampleEvery is the number of streamed validators between two live heap samples
+const liveHeapSampleEvery = 10000
+
+// liveHeap returns the heap bytes still reachable after a garbage collection
+func liveHeap() uint64 {
+	// second collection frees objects in sync.Pool victim caches
+	runtime.GC()
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// reportPeakLiveHeap decodes body once more and reports the peak of the live heap held by decode
+//
+// decode calls sample whenever the live heap should be measured and returns the data it retains, which is kept
+// alive for a last sample. Body is kept alive so it being collected does not hide memory held by decode.
+func reportPeakLiveHeap(b *testing.B, body []byte, decode func(resp *http.Response, sample func()) interface{}) {
+	resp := newValidatorsResponse(body)
+	base := liveHeap()
+	var peak uint64
+	sample := func() {
+		if live := liveHeap(); live > base && live-base > peak {
+			peak = live - base
+		}
+	}
+
+	retained := decode(resp, sample)
+	sample()
+	runtime.KeepAlive(retained)
+	runtime.KeepAlive(body)
+
+	b.ReportMetric(float64(peak), "peak-live-B/op")
+}
+
+func BenchmarkGetValidators(b *testing.B) {
+	body := newValidatorsResponseBody(b, benchmarkValidatorsCount)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := inspectGetValidatorsResponse(newValidatorsResponse(body))
+		require.NoError(b, err)
+	}
+
+	b.StopTimer()
+	reportPeakLiveHeap(b, body, func(resp *http.Response, _ func()) interface{} {
+		vals, err := inspectGetValidatorsResponse(resp)
+		require.NoError(b, err)
+		return vals
+	})
+}
+
+func BenchmarkStreamValidators(b *testing.B) {
+	body := newValidatorsResponseBody(b, benchmarkValidatorsCount)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var total beaconcommon.Gwei
+		err := inspectStreamValidatorsResponse(newValidatorsResponse(body), func(val *types.Validator) error {
+			total += val.Balance
+			return nil
+		})
+		require.NoError(b, err)
+	}
+
+	b.StopTimer()
+	reportPeakLiveHeap(b, body, func(resp *http.Response, sample func()) interface{} {
+		var total beaconcommon.Gwei
+		count := 0
+		err := inspectStreamValidatorsResponse(resp, func(val *types.Validator) error {
+			total += val.Balance
+			if count++; count%liveHeapSampleEvery == 0 {
+				sample()
+			}
+			return nil
+		})
+		require.NoError(b, err)
+		return total
+	})
+}
@@ -0,0 +1,52 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// SubmitAttestations submits attestations to the node's pool, they are broadcast to the network if valid
+//
+// In case some attestations are invalid, returned error wraps a *types.Error listing failures
+func (c *Client) SubmitAttestations(ctx context.Context, attestations beaconphase0.Attestations) error {
+	start := time.Now()
+	err := c.submitAttestations(ctx, attestations)
+	c.metrics.Observe("SubmitAttestations", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("SubmitAttestations failed")
+	}
+
+	return err
+}
+
+func (c *Client) submitAttestations(ctx context.Context, attestations beaconphase0.Attestations) error {
+	req, err := newSubmitAttestationsRequest(ctx, attestations)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitAttestations", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitAttestations", resp, "Failure sending request")
+	}
+
+	err = inspectSubmitResponse(resp)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitAttestations", resp, "Invalid response")
+	}
+
+	return nil
+}
+
+func newSubmitAttestationsRequest(ctx context.Context, attestations beaconphase0.Attestations) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPath("/eth/v1/beacon/pool/attestations"),
+		autorest.WithJSON(attestations),
+	).Prepare(newRequest(ctx))
+}
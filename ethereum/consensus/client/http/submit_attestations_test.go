@@ -0,0 +1,58 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestSubmitAttestations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testSubmitAttestationsStatusOK(t, c, mockCli) })
+	t.Run("Failures", func(t *testing.T) { testSubmitAttestationsFailures(t, c, mockCli) })
+}
+
+func testSubmitAttestationsStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/attestations").
+		MatchType("json").
+		Reply(200)
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitAttestations(context.Background(), beaconphase0.Attestations{{AggregationBits: []byte{0x01}}})
+	require.NoError(t, err)
+}
+
+func testSubmitAttestationsFailures(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/attestations").
+		Reply(400).
+		JSON([]byte(`{"code":400,"message":"some failures","failures":[{"index":1,"message":"invalid signature"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitAttestations(context.Background(), beaconphase0.Attestations{{AggregationBits: []byte{0x01}}, {AggregationBits: []byte{0x01}}})
+	require.Error(t, err)
+
+	beaconErr := new(types.Error)
+	require.True(t, errors.As(err, &beaconErr))
+	assert.Equal(t, 400, beaconErr.Code)
+	assert.Equal(t, []*types.IndexedError{{Index: 1, Message: "invalid signature"}}, beaconErr.Failures)
+}
@@ -0,0 +1,52 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// SubmitBLSToExecutionChanges submits signed BLS to execution credentials changes to the node's pool, they are broadcast to the network if valid
+//
+// In case some changes are invalid, returned error wraps a *types.Error listing failures
+func (c *Client) SubmitBLSToExecutionChanges(ctx context.Context, changes beaconcommon.SignedBLSToExecutionChanges) error {
+	start := time.Now()
+	err := c.submitBLSToExecutionChanges(ctx, changes)
+	c.metrics.Observe("SubmitBLSToExecutionChanges", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("SubmitBLSToExecutionChanges failed")
+	}
+
+	return err
+}
+
+func (c *Client) submitBLSToExecutionChanges(ctx context.Context, changes beaconcommon.SignedBLSToExecutionChanges) error {
+	req, err := newSubmitBLSToExecutionChangesRequest(ctx, changes)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitBLSToExecutionChanges", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitBLSToExecutionChanges", resp, "Failure sending request")
+	}
+
+	err = inspectSubmitResponse(resp)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitBLSToExecutionChanges", resp, "Invalid response")
+	}
+
+	return nil
+}
+
+func newSubmitBLSToExecutionChangesRequest(ctx context.Context, changes beaconcommon.SignedBLSToExecutionChanges) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPath("/eth/v1/beacon/pool/bls_to_execution_changes"),
+		autorest.WithJSON(changes),
+	).Prepare(newRequest(ctx))
+}
@@ -0,0 +1,72 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestSubmitBLSToExecutionChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testSubmitBLSToExecutionChangesStatusOK(t, c, mockCli) })
+	t.Run("Failures", func(t *testing.T) { testSubmitBLSToExecutionChangesFailures(t, c, mockCli) })
+}
+
+func testSubmitBLSToExecutionChangesStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/bls_to_execution_changes").
+		MatchType("json").
+		BodyString(`"validator_index":"7"`).
+		Reply(200)
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitBLSToExecutionChanges(context.Background(), beaconcommon.SignedBLSToExecutionChanges{
+		{BLSToExecutionChange: beaconcommon.BLSToExecutionChange{ValidatorIndex: 7}},
+	})
+	require.NoError(t, err)
+}
+
+func testSubmitBLSToExecutionChangesFailures(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/bls_to_execution_changes").
+		Reply(400).
+		JSON([]byte(`{"code":400,"message":"some BLS to execution changes failed validation","failures":[{"index":0,"message":"invalid signature"},{"index":2,"message":"validator has execution credentials"}]}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitBLSToExecutionChanges(context.Background(), beaconcommon.SignedBLSToExecutionChanges{
+		{BLSToExecutionChange: beaconcommon.BLSToExecutionChange{ValidatorIndex: 1}},
+		{BLSToExecutionChange: beaconcommon.BLSToExecutionChange{ValidatorIndex: 2}},
+		{BLSToExecutionChange: beaconcommon.BLSToExecutionChange{ValidatorIndex: 3}},
+	})
+	require.Error(t, err)
+
+	beaconErr := new(types.Error)
+	require.True(t, errors.As(err, &beaconErr))
+	assert.Equal(t, 400, beaconErr.Code)
+	assert.Equal(
+		t,
+		[]*types.IndexedError{
+			{Index: 0, Message: "invalid signature"},
+			{Index: 2, Message: "validator has execution credentials"},
+		},
+		beaconErr.Failures,
+	)
+}
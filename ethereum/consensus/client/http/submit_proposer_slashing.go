@@ -0,0 +1,50 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// SubmitProposerSlashing submits a proposer slashing to the node's pool, it is broadcast to the network if valid
+func (c *Client) SubmitProposerSlashing(ctx context.Context, slashing *beaconphase0.ProposerSlashing) error {
+	start := time.Now()
+	err := c.submitProposerSlashing(ctx, slashing)
+	c.metrics.Observe("SubmitProposerSlashing", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("SubmitProposerSlashing failed")
+	}
+
+	return err
+}
+
+func (c *Client) submitProposerSlashing(ctx context.Context, slashing *beaconphase0.ProposerSlashing) error {
+	req, err := newSubmitProposerSlashingRequest(ctx, slashing)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitProposerSlashing", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitProposerSlashing", resp, "Failure sending request")
+	}
+
+	err = inspectSubmitResponse(resp)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitProposerSlashing", resp, "Invalid response")
+	}
+
+	return nil
+}
+
+func newSubmitProposerSlashingRequest(ctx context.Context, slashing *beaconphase0.ProposerSlashing) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPath("/eth/v1/beacon/pool/proposer_slashings"),
+		autorest.WithJSON(slashing),
+	).Prepare(newRequest(ctx))
+}
@@ -0,0 +1,71 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestSubmitProposerSlashing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testSubmitProposerSlashingStatusOK(t, c, mockCli) })
+	t.Run("Status400", func(t *testing.T) { testSubmitProposerSlashingStatus400(t, c, mockCli) })
+}
+
+func newTestProposerSlashing() *beaconphase0.ProposerSlashing {
+	return &beaconphase0.ProposerSlashing{
+		SignedHeader1: beaconcommon.SignedBeaconBlockHeader{
+			Message: beaconcommon.BeaconBlockHeader{Slot: 10, ProposerIndex: 5, BodyRoot: beaconcommon.Root{0x01}},
+		},
+		SignedHeader2: beaconcommon.SignedBeaconBlockHeader{
+			Message: beaconcommon.BeaconBlockHeader{Slot: 10, ProposerIndex: 5, BodyRoot: beaconcommon.Root{0x02}},
+		},
+	}
+}
+
+func testSubmitProposerSlashingStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/proposer_slashings").
+		MatchType("json").
+		BodyString(`"signed_header_1":\{"message":\{"slot":"10","proposer_index":"5"`).
+		Reply(200)
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitProposerSlashing(context.Background(), newTestProposerSlashing())
+	require.NoError(t, err)
+}
+
+func testSubmitProposerSlashingStatus400(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/proposer_slashings").
+		Reply(400).
+		JSON([]byte(`{"code":400,"message":"Invalid proposer slashing, it will never pass validation so it's rejected"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitProposerSlashing(context.Background(), newTestProposerSlashing())
+	require.Error(t, err)
+
+	beaconErr := new(types.Error)
+	require.True(t, errors.As(err, &beaconErr))
+	assert.Equal(t, 400, beaconErr.Code)
+	assert.Equal(t, "Invalid proposer slashing, it will never pass validation so it's rejected", beaconErr.Message)
+}
@@ -0,0 +1,50 @@
+package eth2http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+)
+
+// SubmitVoluntaryExit submits a signed voluntary exit to the node's pool, it is broadcast to the network if valid
+func (c *Client) SubmitVoluntaryExit(ctx context.Context, exit *beaconphase0.SignedVoluntaryExit) error {
+	start := time.Now()
+	err := c.submitVoluntaryExit(ctx, exit)
+	c.metrics.Observe("SubmitVoluntaryExit", start, err)
+	if err != nil {
+		c.logger.WithError(err).Errorf("SubmitVoluntaryExit failed")
+	}
+
+	return err
+}
+
+func (c *Client) submitVoluntaryExit(ctx context.Context, exit *beaconphase0.SignedVoluntaryExit) error {
+	req, err := newSubmitVoluntaryExitRequest(ctx, exit)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitVoluntaryExit", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitVoluntaryExit", resp, "Failure sending request")
+	}
+
+	err = inspectSubmitResponse(resp)
+	if err != nil {
+		return autorest.NewErrorWithError(err, "eth2http.Client", "SubmitVoluntaryExit", resp, "Invalid response")
+	}
+
+	return nil
+}
+
+func newSubmitVoluntaryExitRequest(ctx context.Context, exit *beaconphase0.SignedVoluntaryExit) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsJSON(),
+		autorest.WithPath("/eth/v1/beacon/pool/voluntary_exits"),
+		autorest.WithJSON(exit),
+	).Prepare(newRequest(ctx))
+}
@@ -0,0 +1,61 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestSubmitVoluntaryExit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testSubmitVoluntaryExitStatusOK(t, c, mockCli) })
+	t.Run("Status400", func(t *testing.T) { testSubmitVoluntaryExitStatus400(t, c, mockCli) })
+}
+
+func testSubmitVoluntaryExitStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/voluntary_exits").
+		MatchType("json").
+		JSON([]byte(`{"message":{"epoch":"1","validator_index":"2"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`)).
+		Reply(200)
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitVoluntaryExit(context.Background(), &beaconphase0.SignedVoluntaryExit{
+		Message: beaconphase0.VoluntaryExit{Epoch: 1, ValidatorIndex: 2},
+	})
+	require.NoError(t, err)
+}
+
+func testSubmitVoluntaryExitStatus400(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/eth/v1/beacon/pool/voluntary_exits").
+		Reply(400).
+		JSON([]byte(`{"code":400,"message":"Invalid voluntary exit, it will never pass validation so it's rejected"}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.SubmitVoluntaryExit(context.Background(), new(beaconphase0.SignedVoluntaryExit))
+	require.Error(t, err)
+
+	beaconErr := new(types.Error)
+	require.True(t, errors.As(err, &beaconErr))
+	assert.Equal(t, 400, beaconErr.Code)
+	assert.Equal(t, "Invalid voluntary exit, it will never pass validation so it's rejected", beaconErr.Message)
+}
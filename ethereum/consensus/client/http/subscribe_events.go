@@ -0,0 +1,206 @@
+package eth2http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// SubscribeEvents subscribes to beacon node events for the given topics
+//
+// Events are sent on the returned channel. In case the stream is interrupted, connection is automatically
+// re-established, waiting for an exponential backoff between consecutive failed attempts (see Config.EventsReconnect).
+// The channel is closed once ctx is canceled.
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan *types.Event, error) {
+	resp, err := c.subscribeEvents(ctx, topics)
+	if err != nil {
+		c.logger.
+			WithField("topics", topics).
+			WithError(err).Errorf("SubscribeEvents failed")
+		return nil, err
+	}
+
+	ch := make(chan *types.Event)
+	go c.streamEvents(ctx, topics, resp, ch)
+
+	return ch, nil
+}
+
+func (c *Client) subscribeEvents(ctx context.Context, topics []string) (*http.Response, error) {
+	req, err := newSubscribeEventsRequest(ctx, topics)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "SubscribeEvents", nil, "Failure preparing request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "SubscribeEvents", resp, "Failure sending request")
+	}
+
+	err = inspectSubscribeEventsResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "eth2http.Client", "SubscribeEvents", resp, "Invalid response")
+	}
+
+	return resp, nil
+}
+
+// streamEvents reads events from resp and re-subscribes each time the stream is interrupted until ctx is canceled
+func (c *Client) streamEvents(ctx context.Context, topics []string, resp *http.Response, ch chan<- *types.Event) {
+	defer close(ch)
+
+	for {
+		err := readEvents(resp.Body, func(topic string, data []byte) {
+			evt, err := decodeEvent(topic, data)
+			if err != nil {
+				c.logger.
+					WithField("topic", topic).
+					WithError(err).Warnf("invalid event")
+				return
+			}
+
+			select {
+			case ch <- evt:
+			case <-ctx.Done():
+			}
+		})
+		resp.Body.Close()
+
+		// backoff restarts from minimum wait once the stream has been re-established
+		for attempt := 1; ; attempt++ {
+			if ctx.Err() != nil {
+				return
+			}
+
+			wait := backoff(c.eventsReconnectMinWait, c.eventsReconnectMaxWait, attempt)
+			c.logger.
+				WithField("topics", topics).
+				WithField("attempt", attempt).
+				WithField("wait", wait).
+				WithError(err).Warnf("event stream interrupted, reconnecting...")
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+
+			resp, err = c.subscribeEvents(ctx, topics)
+			if err == nil {
+				break
+			}
+		}
+	}
+}
+
+func newSubscribeEventsRequest(ctx context.Context, topics []string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"topics": topics,
+	}
+
+	return autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithPath("eth/v1/events"),
+		autorest.WithQueryParameters(queryParameters),
+		autorest.WithHeader("Accept", "text/event-stream"),
+	).Prepare(newRequest(ctx))
+}
+
+func inspectSubscribeEventsResponse(resp *http.Response) error {
+	return autorest.Respond(
+		resp,
+		WithBeaconErrorUnlessOK(),
+	)
+}
+
+// readEvents reads a text/event-stream body and calls fn for every event dispatched
+//
+// It returns when r is exhausted or fails
+func readEvents(r io.Reader, fn func(event string, data []byte)) error {
+	var (
+		event string
+		data  bytes.Buffer
+	)
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return fmt.Errorf("event stream closed")
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		// empty line dispatches the event
+		if line == "" {
+			if data.Len() > 0 {
+				fn(event, bytes.TrimSuffix(data.Bytes(), []byte("\n")))
+			}
+			event = ""
+			data.Reset()
+			continue
+		}
+
+		// lines starting with a colon are comments
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		}
+	}
+}
+
+func decodeEvent(topic string, data []byte) (*types.Event, error) {
+	var msg interface{}
+	switch topic {
+	case types.EventTopicHead:
+		msg = new(types.HeadEvent)
+	case types.EventTopicBlock:
+		msg = new(types.BlockEvent)
+	case types.EventTopicAttestation:
+		msg = new(beaconphase0.Attestation)
+	case types.EventTopicVoluntaryExit:
+		msg = new(beaconphase0.SignedVoluntaryExit)
+	case types.EventTopicFinalizedCheckpoint:
+		msg = new(types.FinalizedCheckpointEvent)
+	case types.EventTopicChainReorg:
+		msg = new(types.ChainReorgEvent)
+	case types.EventTopicContributionAndProof:
+		msg = new(altair.SignedContributionAndProof)
+	default:
+		return nil, fmt.Errorf("unknown event topic %q", topic)
+	}
+
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+
+	return &types.Event{
+		Topic: topic,
+		Data:  msg,
+	}, nil
+}
@@ -0,0 +1,84 @@
+//go:build !integration
+// +build !integration
+
+package eth2http
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	srv := httptestutils.NewSSEServer()
+	defer srv.Close()
+
+	cfg := &Config{
+		Address: srv.URL,
+		EventsReconnect: &ReconnectConfig{
+			MinWait: &kilntypes.Duration{Duration: 10 * time.Millisecond},
+			MaxWait: &kilntypes.Duration{Duration: 50 * time.Millisecond},
+		},
+	}
+	c, err := NewClient(cfg.SetDefault())
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, c.eventsReconnectMinWait)
+	assert.Equal(t, 50*time.Millisecond, c.eventsReconnectMaxWait)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c.SubscribeEvents(ctx, []string{types.EventTopicHead, types.EventTopicChainReorg})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return srv.Streams() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"head", "chain_reorg"}, srv.Requests()[0].URL.Query()["topics"])
+
+	t.Run("head", func(t *testing.T) {
+		srv.Send("head", `{"slot":"10","block":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf","state":"0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9","epoch_transition":false,"previous_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91","current_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91","execution_optimistic":false}`)
+
+		evt := <-events
+		require.Equal(t, types.EventTopicHead, evt.Topic)
+		head, ok := evt.Data.(*types.HeadEvent)
+		require.True(t, ok)
+		assert.Equal(t, beaconcommon.Slot(10), head.Slot)
+		assert.Equal(t, "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", head.Block.String())
+	})
+
+	t.Run("chain_reorg", func(t *testing.T) {
+		srv.Send("chain_reorg", `{"slot":"200","depth":"50","old_head_block":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf","new_head_block":"0x76262e91970d375a19bfe8a867288d7b9cde43c8635f598d93d39d041706fc76","old_head_state":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf","new_head_state":"0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9","epoch":"2","execution_optimistic":false}`)
+
+		evt := <-events
+		reorg, ok := evt.Data.(*types.ChainReorgEvent)
+		require.True(t, ok)
+		assert.Equal(t, uint64(50), reorg.Depth)
+		assert.Equal(t, beaconcommon.Epoch(2), reorg.Epoch)
+	})
+
+	t.Run("reconnect", func(t *testing.T) {
+		srv.CloseClientConnections()
+		require.Eventually(t, func() bool { return len(srv.Requests()) == 2 && srv.Streams() == 1 }, time.Second, 5*time.Millisecond)
+
+		srv.Send("block", `{"slot":"11","block":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf","execution_optimistic":false}`)
+
+		evt := <-events
+		assert.Equal(t, types.EventTopicBlock, evt.Topic)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		cancel()
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok, "events channel should be closed")
+		case <-time.After(time.Second):
+			t.Fatal("events channel not closed after context cancellation")
+		}
+	})
+}
@@ -10,7 +10,6 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/kilnfi/go-utils/ethereum/consensus/types"
-	bellatrix "github.com/protolambda/zrnt/eth2/beacon/bellatrix"
 	common "github.com/protolambda/zrnt/eth2/beacon/common"
 	phase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
 )
@@ -38,6 +37,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// GetAttestationRewards mocks base method.
+func (m *MockClient) GetAttestationRewards(ctx context.Context, epoch common.Epoch, validatorIDs []types.ValidatorID) (*types.AttestationRewards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttestationRewards", ctx, epoch, validatorIDs)
+	ret0, _ := ret[0].(*types.AttestationRewards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttestationRewards indicates an expected call of GetAttestationRewards.
+func (mr *MockClientMockRecorder) GetAttestationRewards(ctx, epoch, validatorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttestationRewards", reflect.TypeOf((*MockClient)(nil).GetAttestationRewards), ctx, epoch, validatorIDs)
+}
+
 // GetAttestations mocks base method.
 func (m *MockClient) GetAttestations(ctx context.Context) (phase0.Attestations, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +67,21 @@ func (mr *MockClientMockRecorder) GetAttestations(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttestations", reflect.TypeOf((*MockClient)(nil).GetAttestations), ctx)
 }
 
+// GetAttesterDuties mocks base method.
+func (m *MockClient) GetAttesterDuties(ctx context.Context, epoch common.Epoch, indices []common.ValidatorIndex) (*types.AttesterDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttesterDuties", ctx, epoch, indices)
+	ret0, _ := ret[0].(*types.AttesterDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttesterDuties indicates an expected call of GetAttesterDuties.
+func (mr *MockClientMockRecorder) GetAttesterDuties(ctx, epoch, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttesterDuties", reflect.TypeOf((*MockClient)(nil).GetAttesterDuties), ctx, epoch, indices)
+}
+
 // GetAttesterSlashings mocks base method.
 func (m *MockClient) GetAttesterSlashings(ctx context.Context) (phase0.AttesterSlashings, error) {
 	m.ctrl.T.Helper()
@@ -68,11 +97,26 @@ func (mr *MockClientMockRecorder) GetAttesterSlashings(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttesterSlashings", reflect.TypeOf((*MockClient)(nil).GetAttesterSlashings), ctx)
 }
 
+// GetBlobSidecars mocks base method.
+func (m *MockClient) GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobSidecars", ctx, blockID, indices)
+	ret0, _ := ret[0].([]*types.BlobSidecar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlobSidecars indicates an expected call of GetBlobSidecars.
+func (mr *MockClientMockRecorder) GetBlobSidecars(ctx, blockID, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobSidecars", reflect.TypeOf((*MockClient)(nil).GetBlobSidecars), ctx, blockID, indices)
+}
+
 // GetBlock mocks base method.
-func (m *MockClient) GetBlock(ctx context.Context, blockID string) (*bellatrix.SignedBeaconBlock, error) {
+func (m *MockClient) GetBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlock", ctx, blockID)
-	ret0, _ := ret[0].(*bellatrix.SignedBeaconBlock)
+	ret0, _ := ret[0].(*types.SignedBeaconBlock)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -84,7 +128,7 @@ func (mr *MockClientMockRecorder) GetBlock(ctx, blockID interface{}) *gomock.Cal
 }
 
 // GetBlockAttestations mocks base method.
-func (m *MockClient) GetBlockAttestations(ctx context.Context, blockID string) (phase0.Attestations, error) {
+func (m *MockClient) GetBlockAttestations(ctx context.Context, blockID types.BlockID) (phase0.Attestations, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockAttestations", ctx, blockID)
 	ret0, _ := ret[0].(phase0.Attestations)
@@ -99,7 +143,7 @@ func (mr *MockClientMockRecorder) GetBlockAttestations(ctx, blockID interface{})
 }
 
 // GetBlockHeader mocks base method.
-func (m *MockClient) GetBlockHeader(ctx context.Context, blockID string) (*types.BeaconBlockHeader, error) {
+func (m *MockClient) GetBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockHeader", ctx, blockID)
 	ret0, _ := ret[0].(*types.BeaconBlockHeader)
@@ -128,8 +172,23 @@ func (mr *MockClientMockRecorder) GetBlockHeaders(ctx, slot, parentRoot interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeaders", reflect.TypeOf((*MockClient)(nil).GetBlockHeaders), ctx, slot, parentRoot)
 }
 
+// GetBlockRewards mocks base method.
+func (m *MockClient) GetBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockRewards", ctx, blockID)
+	ret0, _ := ret[0].(*types.BlockRewards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockRewards indicates an expected call of GetBlockRewards.
+func (mr *MockClientMockRecorder) GetBlockRewards(ctx, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRewards", reflect.TypeOf((*MockClient)(nil).GetBlockRewards), ctx, blockID)
+}
+
 // GetBlockRoot mocks base method.
-func (m *MockClient) GetBlockRoot(ctx context.Context, blockID string) (*common.Root, error) {
+func (m *MockClient) GetBlockRoot(ctx context.Context, blockID types.BlockID) (*common.Root, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockRoot", ctx, blockID)
 	ret0, _ := ret[0].(*common.Root)
@@ -144,7 +203,7 @@ func (mr *MockClientMockRecorder) GetBlockRoot(ctx, blockID interface{}) *gomock
 }
 
 // GetCommittees mocks base method.
-func (m *MockClient) GetCommittees(ctx context.Context, stateID string, epoch *common.Epoch, index *common.CommitteeIndex, slot *common.Slot) ([]*types.Committee, error) {
+func (m *MockClient) GetCommittees(ctx context.Context, stateID types.StateID, epoch *common.Epoch, index *common.CommitteeIndex, slot *common.Slot) ([]*types.Committee, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCommittees", ctx, stateID, epoch, index, slot)
 	ret0, _ := ret[0].([]*types.Committee)
@@ -158,6 +217,36 @@ func (mr *MockClientMockRecorder) GetCommittees(ctx, stateID, epoch, index, slot
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommittees", reflect.TypeOf((*MockClient)(nil).GetCommittees), ctx, stateID, epoch, index, slot)
 }
 
+// GetDepositContract mocks base method.
+func (m *MockClient) GetDepositContract(ctx context.Context) (*types.DepositContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositContract", ctx)
+	ret0, _ := ret[0].(*types.DepositContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositContract indicates an expected call of GetDepositContract.
+func (mr *MockClientMockRecorder) GetDepositContract(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositContract", reflect.TypeOf((*MockClient)(nil).GetDepositContract), ctx)
+}
+
+// GetForkSchedule mocks base method.
+func (m *MockClient) GetForkSchedule(ctx context.Context) ([]*common.Fork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForkSchedule", ctx)
+	ret0, _ := ret[0].([]*common.Fork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForkSchedule indicates an expected call of GetForkSchedule.
+func (mr *MockClientMockRecorder) GetForkSchedule(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkSchedule", reflect.TypeOf((*MockClient)(nil).GetForkSchedule), ctx)
+}
+
 // GetGenesis mocks base method.
 func (m *MockClient) GetGenesis(ctx context.Context) (*types.Genesis, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +262,36 @@ func (mr *MockClientMockRecorder) GetGenesis(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesis", reflect.TypeOf((*MockClient)(nil).GetGenesis), ctx)
 }
 
+// GetHealth mocks base method.
+func (m *MockClient) GetHealth(ctx context.Context) (types.Health, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealth", ctx)
+	ret0, _ := ret[0].(types.Health)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealth indicates an expected call of GetHealth.
+func (mr *MockClientMockRecorder) GetHealth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealth", reflect.TypeOf((*MockClient)(nil).GetHealth), ctx)
+}
+
+// GetIdentity mocks base method.
+func (m *MockClient) GetIdentity(ctx context.Context) (*types.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", ctx)
+	ret0, _ := ret[0].(*types.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockClientMockRecorder) GetIdentity(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockClient)(nil).GetIdentity), ctx)
+}
+
 // GetNodeVersion mocks base method.
 func (m *MockClient) GetNodeVersion(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +307,51 @@ func (mr *MockClientMockRecorder) GetNodeVersion(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeVersion", reflect.TypeOf((*MockClient)(nil).GetNodeVersion), ctx)
 }
 
+// GetPeerCount mocks base method.
+func (m *MockClient) GetPeerCount(ctx context.Context) (*types.PeerCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeerCount", ctx)
+	ret0, _ := ret[0].(*types.PeerCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeerCount indicates an expected call of GetPeerCount.
+func (mr *MockClientMockRecorder) GetPeerCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeerCount", reflect.TypeOf((*MockClient)(nil).GetPeerCount), ctx)
+}
+
+// GetPeers mocks base method.
+func (m *MockClient) GetPeers(ctx context.Context, states, directions []string) ([]*types.Peer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeers", ctx, states, directions)
+	ret0, _ := ret[0].([]*types.Peer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeers indicates an expected call of GetPeers.
+func (mr *MockClientMockRecorder) GetPeers(ctx, states, directions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockClient)(nil).GetPeers), ctx, states, directions)
+}
+
+// GetProposerDuties mocks base method.
+func (m *MockClient) GetProposerDuties(ctx context.Context, epoch common.Epoch) (*types.ProposerDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposerDuties", ctx, epoch)
+	ret0, _ := ret[0].(*types.ProposerDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposerDuties indicates an expected call of GetProposerDuties.
+func (mr *MockClientMockRecorder) GetProposerDuties(ctx, epoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposerDuties", reflect.TypeOf((*MockClient)(nil).GetProposerDuties), ctx, epoch)
+}
+
 // GetProposerSlashings mocks base method.
 func (m *MockClient) GetProposerSlashings(ctx context.Context) (phase0.ProposerSlashings, error) {
 	m.ctrl.T.Helper()
@@ -204,10 +368,10 @@ func (mr *MockClientMockRecorder) GetProposerSlashings(ctx interface{}) *gomock.
 }
 
 // GetSpec mocks base method.
-func (m *MockClient) GetSpec(ctx context.Context) (*common.Spec, error) {
+func (m *MockClient) GetSpec(ctx context.Context) (*types.Spec, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSpec", ctx)
-	ret0, _ := ret[0].(*common.Spec)
+	ret0, _ := ret[0].(*types.Spec)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -218,8 +382,23 @@ func (mr *MockClientMockRecorder) GetSpec(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpec", reflect.TypeOf((*MockClient)(nil).GetSpec), ctx)
 }
 
+// GetState mocks base method.
+func (m *MockClient) GetState(ctx context.Context, stateID types.StateID) (*types.BeaconState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ctx, stateID)
+	ret0, _ := ret[0].(*types.BeaconState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockClientMockRecorder) GetState(ctx, stateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockClient)(nil).GetState), ctx, stateID)
+}
+
 // GetStateFinalityCheckpoints mocks base method.
-func (m *MockClient) GetStateFinalityCheckpoints(ctx context.Context, stateID string) (*types.StateFinalityCheckpoints, error) {
+func (m *MockClient) GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateFinalityCheckpoints", ctx, stateID)
 	ret0, _ := ret[0].(*types.StateFinalityCheckpoints)
@@ -234,7 +413,7 @@ func (mr *MockClientMockRecorder) GetStateFinalityCheckpoints(ctx, stateID inter
 }
 
 // GetStateFork mocks base method.
-func (m *MockClient) GetStateFork(ctx context.Context, stateID string) (*common.Fork, error) {
+func (m *MockClient) GetStateFork(ctx context.Context, stateID types.StateID) (*common.Fork, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateFork", ctx, stateID)
 	ret0, _ := ret[0].(*common.Fork)
@@ -249,7 +428,7 @@ func (mr *MockClientMockRecorder) GetStateFork(ctx, stateID interface{}) *gomock
 }
 
 // GetStateRoot mocks base method.
-func (m *MockClient) GetStateRoot(ctx context.Context, stateID string) (*common.Root, error) {
+func (m *MockClient) GetStateRoot(ctx context.Context, stateID types.StateID) (*common.Root, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateRoot", ctx, stateID)
 	ret0, _ := ret[0].(*common.Root)
@@ -263,8 +442,38 @@ func (mr *MockClientMockRecorder) GetStateRoot(ctx, stateID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockClient)(nil).GetStateRoot), ctx, stateID)
 }
 
+// GetSyncCommitteeDuties mocks base method.
+func (m *MockClient) GetSyncCommitteeDuties(ctx context.Context, epoch common.Epoch, indices []common.ValidatorIndex) (*types.SyncCommitteeDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCommitteeDuties", ctx, epoch, indices)
+	ret0, _ := ret[0].(*types.SyncCommitteeDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncCommitteeDuties indicates an expected call of GetSyncCommitteeDuties.
+func (mr *MockClientMockRecorder) GetSyncCommitteeDuties(ctx, epoch, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommitteeDuties", reflect.TypeOf((*MockClient)(nil).GetSyncCommitteeDuties), ctx, epoch, indices)
+}
+
+// GetSyncCommitteeRewards mocks base method.
+func (m *MockClient) GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCommitteeRewards", ctx, blockID, validatorIDs)
+	ret0, _ := ret[0].([]*types.SyncCommitteeReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncCommitteeRewards indicates an expected call of GetSyncCommitteeRewards.
+func (mr *MockClientMockRecorder) GetSyncCommitteeRewards(ctx, blockID, validatorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommitteeRewards", reflect.TypeOf((*MockClient)(nil).GetSyncCommitteeRewards), ctx, blockID, validatorIDs)
+}
+
 // GetSyncCommittees mocks base method.
-func (m *MockClient) GetSyncCommittees(ctx context.Context, stateID string, epoch *common.Epoch) (*types.SyncCommittees, error) {
+func (m *MockClient) GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *common.Epoch) (*types.SyncCommittees, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSyncCommittees", ctx, stateID, epoch)
 	ret0, _ := ret[0].(*types.SyncCommittees)
@@ -278,8 +487,23 @@ func (mr *MockClientMockRecorder) GetSyncCommittees(ctx, stateID, epoch interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommittees", reflect.TypeOf((*MockClient)(nil).GetSyncCommittees), ctx, stateID, epoch)
 }
 
+// GetSyncing mocks base method.
+func (m *MockClient) GetSyncing(ctx context.Context) (*types.Syncing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncing", ctx)
+	ret0, _ := ret[0].(*types.Syncing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncing indicates an expected call of GetSyncing.
+func (mr *MockClientMockRecorder) GetSyncing(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncing", reflect.TypeOf((*MockClient)(nil).GetSyncing), ctx)
+}
+
 // GetValidator mocks base method.
-func (m *MockClient) GetValidator(ctx context.Context, stateID, validatorID string) (*types.Validator, error) {
+func (m *MockClient) GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidator", ctx, stateID, validatorID)
 	ret0, _ := ret[0].(*types.Validator)
@@ -294,7 +518,7 @@ func (mr *MockClientMockRecorder) GetValidator(ctx, stateID, validatorID interfa
 }
 
 // GetValidatorBalances mocks base method.
-func (m *MockClient) GetValidatorBalances(ctx context.Context, stateID string, validatorIDs []string) ([]*types.ValidatorBalance, error) {
+func (m *MockClient) GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidatorBalances", ctx, stateID, validatorIDs)
 	ret0, _ := ret[0].([]*types.ValidatorBalance)
@@ -309,7 +533,7 @@ func (mr *MockClientMockRecorder) GetValidatorBalances(ctx, stateID, validatorID
 }
 
 // GetValidators mocks base method.
-func (m *MockClient) GetValidators(ctx context.Context, stateID string, validatorIDs, statuses []string) ([]*types.Validator, error) {
+func (m *MockClient) GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidators", ctx, stateID, validatorIDs, statuses)
 	ret0, _ := ret[0].([]*types.Validator)
@@ -338,6 +562,62 @@ func (mr *MockClientMockRecorder) GetVoluntaryExits(ctx interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoluntaryExits", reflect.TypeOf((*MockClient)(nil).GetVoluntaryExits), ctx)
 }
 
+// SubmitAttestations mocks base method.
+func (m *MockClient) SubmitAttestations(ctx context.Context, attestations phase0.Attestations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAttestations", ctx, attestations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitAttestations indicates an expected call of SubmitAttestations.
+func (mr *MockClientMockRecorder) SubmitAttestations(ctx, attestations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAttestations", reflect.TypeOf((*MockClient)(nil).SubmitAttestations), ctx, attestations)
+}
+
+// SubmitBLSToExecutionChanges mocks base method.
+func (m *MockClient) SubmitBLSToExecutionChanges(ctx context.Context, changes common.SignedBLSToExecutionChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitBLSToExecutionChanges", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitBLSToExecutionChanges indicates an expected call of SubmitBLSToExecutionChanges.
+func (mr *MockClientMockRecorder) SubmitBLSToExecutionChanges(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBLSToExecutionChanges", reflect.TypeOf((*MockClient)(nil).SubmitBLSToExecutionChanges), ctx, changes)
+}
+
+// SubmitProposerSlashing mocks base method.
+func (m *MockClient) SubmitProposerSlashing(ctx context.Context, slashing *phase0.ProposerSlashing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitProposerSlashing", ctx, slashing)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitProposerSlashing indicates an expected call of SubmitProposerSlashing.
+func (mr *MockClientMockRecorder) SubmitProposerSlashing(ctx, slashing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposerSlashing", reflect.TypeOf((*MockClient)(nil).SubmitProposerSlashing), ctx, slashing)
+}
+
+// SubmitVoluntaryExit mocks base method.
+func (m *MockClient) SubmitVoluntaryExit(ctx context.Context, exit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", ctx, exit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockClientMockRecorder) SubmitVoluntaryExit(ctx, exit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockClient)(nil).SubmitVoluntaryExit), ctx, exit)
+}
+
 // MockBeaconClient is a mock of BeaconClient interface.
 type MockBeaconClient struct {
 	ctrl     *gomock.Controller
@@ -361,6 +641,21 @@ func (m *MockBeaconClient) EXPECT() *MockBeaconClientMockRecorder {
 	return m.recorder
 }
 
+// GetAttestationRewards mocks base method.
+func (m *MockBeaconClient) GetAttestationRewards(ctx context.Context, epoch common.Epoch, validatorIDs []types.ValidatorID) (*types.AttestationRewards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttestationRewards", ctx, epoch, validatorIDs)
+	ret0, _ := ret[0].(*types.AttestationRewards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttestationRewards indicates an expected call of GetAttestationRewards.
+func (mr *MockBeaconClientMockRecorder) GetAttestationRewards(ctx, epoch, validatorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttestationRewards", reflect.TypeOf((*MockBeaconClient)(nil).GetAttestationRewards), ctx, epoch, validatorIDs)
+}
+
 // GetAttestations mocks base method.
 func (m *MockBeaconClient) GetAttestations(ctx context.Context) (phase0.Attestations, error) {
 	m.ctrl.T.Helper()
@@ -391,11 +686,26 @@ func (mr *MockBeaconClientMockRecorder) GetAttesterSlashings(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttesterSlashings", reflect.TypeOf((*MockBeaconClient)(nil).GetAttesterSlashings), ctx)
 }
 
+// GetBlobSidecars mocks base method.
+func (m *MockBeaconClient) GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) ([]*types.BlobSidecar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobSidecars", ctx, blockID, indices)
+	ret0, _ := ret[0].([]*types.BlobSidecar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlobSidecars indicates an expected call of GetBlobSidecars.
+func (mr *MockBeaconClientMockRecorder) GetBlobSidecars(ctx, blockID, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobSidecars", reflect.TypeOf((*MockBeaconClient)(nil).GetBlobSidecars), ctx, blockID, indices)
+}
+
 // GetBlock mocks base method.
-func (m *MockBeaconClient) GetBlock(ctx context.Context, blockID string) (*bellatrix.SignedBeaconBlock, error) {
+func (m *MockBeaconClient) GetBlock(ctx context.Context, blockID types.BlockID) (*types.SignedBeaconBlock, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlock", ctx, blockID)
-	ret0, _ := ret[0].(*bellatrix.SignedBeaconBlock)
+	ret0, _ := ret[0].(*types.SignedBeaconBlock)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -407,7 +717,7 @@ func (mr *MockBeaconClientMockRecorder) GetBlock(ctx, blockID interface{}) *gomo
 }
 
 // GetBlockAttestations mocks base method.
-func (m *MockBeaconClient) GetBlockAttestations(ctx context.Context, blockID string) (phase0.Attestations, error) {
+func (m *MockBeaconClient) GetBlockAttestations(ctx context.Context, blockID types.BlockID) (phase0.Attestations, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockAttestations", ctx, blockID)
 	ret0, _ := ret[0].(phase0.Attestations)
@@ -422,7 +732,7 @@ func (mr *MockBeaconClientMockRecorder) GetBlockAttestations(ctx, blockID interf
 }
 
 // GetBlockHeader mocks base method.
-func (m *MockBeaconClient) GetBlockHeader(ctx context.Context, blockID string) (*types.BeaconBlockHeader, error) {
+func (m *MockBeaconClient) GetBlockHeader(ctx context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockHeader", ctx, blockID)
 	ret0, _ := ret[0].(*types.BeaconBlockHeader)
@@ -451,8 +761,23 @@ func (mr *MockBeaconClientMockRecorder) GetBlockHeaders(ctx, slot, parentRoot in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeaders", reflect.TypeOf((*MockBeaconClient)(nil).GetBlockHeaders), ctx, slot, parentRoot)
 }
 
+// GetBlockRewards mocks base method.
+func (m *MockBeaconClient) GetBlockRewards(ctx context.Context, blockID types.BlockID) (*types.BlockRewards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockRewards", ctx, blockID)
+	ret0, _ := ret[0].(*types.BlockRewards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockRewards indicates an expected call of GetBlockRewards.
+func (mr *MockBeaconClientMockRecorder) GetBlockRewards(ctx, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRewards", reflect.TypeOf((*MockBeaconClient)(nil).GetBlockRewards), ctx, blockID)
+}
+
 // GetBlockRoot mocks base method.
-func (m *MockBeaconClient) GetBlockRoot(ctx context.Context, blockID string) (*common.Root, error) {
+func (m *MockBeaconClient) GetBlockRoot(ctx context.Context, blockID types.BlockID) (*common.Root, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBlockRoot", ctx, blockID)
 	ret0, _ := ret[0].(*common.Root)
@@ -467,7 +792,7 @@ func (mr *MockBeaconClientMockRecorder) GetBlockRoot(ctx, blockID interface{}) *
 }
 
 // GetCommittees mocks base method.
-func (m *MockBeaconClient) GetCommittees(ctx context.Context, stateID string, epoch *common.Epoch, index *common.CommitteeIndex, slot *common.Slot) ([]*types.Committee, error) {
+func (m *MockBeaconClient) GetCommittees(ctx context.Context, stateID types.StateID, epoch *common.Epoch, index *common.CommitteeIndex, slot *common.Slot) ([]*types.Committee, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCommittees", ctx, stateID, epoch, index, slot)
 	ret0, _ := ret[0].([]*types.Committee)
@@ -511,8 +836,23 @@ func (mr *MockBeaconClientMockRecorder) GetProposerSlashings(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposerSlashings", reflect.TypeOf((*MockBeaconClient)(nil).GetProposerSlashings), ctx)
 }
 
+// GetState mocks base method.
+func (m *MockBeaconClient) GetState(ctx context.Context, stateID types.StateID) (*types.BeaconState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ctx, stateID)
+	ret0, _ := ret[0].(*types.BeaconState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockBeaconClientMockRecorder) GetState(ctx, stateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockBeaconClient)(nil).GetState), ctx, stateID)
+}
+
 // GetStateFinalityCheckpoints mocks base method.
-func (m *MockBeaconClient) GetStateFinalityCheckpoints(ctx context.Context, stateID string) (*types.StateFinalityCheckpoints, error) {
+func (m *MockBeaconClient) GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (*types.StateFinalityCheckpoints, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateFinalityCheckpoints", ctx, stateID)
 	ret0, _ := ret[0].(*types.StateFinalityCheckpoints)
@@ -527,7 +867,7 @@ func (mr *MockBeaconClientMockRecorder) GetStateFinalityCheckpoints(ctx, stateID
 }
 
 // GetStateFork mocks base method.
-func (m *MockBeaconClient) GetStateFork(ctx context.Context, stateID string) (*common.Fork, error) {
+func (m *MockBeaconClient) GetStateFork(ctx context.Context, stateID types.StateID) (*common.Fork, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateFork", ctx, stateID)
 	ret0, _ := ret[0].(*common.Fork)
@@ -542,7 +882,7 @@ func (mr *MockBeaconClientMockRecorder) GetStateFork(ctx, stateID interface{}) *
 }
 
 // GetStateRoot mocks base method.
-func (m *MockBeaconClient) GetStateRoot(ctx context.Context, stateID string) (*common.Root, error) {
+func (m *MockBeaconClient) GetStateRoot(ctx context.Context, stateID types.StateID) (*common.Root, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStateRoot", ctx, stateID)
 	ret0, _ := ret[0].(*common.Root)
@@ -556,8 +896,23 @@ func (mr *MockBeaconClientMockRecorder) GetStateRoot(ctx, stateID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockBeaconClient)(nil).GetStateRoot), ctx, stateID)
 }
 
+// GetSyncCommitteeRewards mocks base method.
+func (m *MockBeaconClient) GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) ([]*types.SyncCommitteeReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCommitteeRewards", ctx, blockID, validatorIDs)
+	ret0, _ := ret[0].([]*types.SyncCommitteeReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncCommitteeRewards indicates an expected call of GetSyncCommitteeRewards.
+func (mr *MockBeaconClientMockRecorder) GetSyncCommitteeRewards(ctx, blockID, validatorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommitteeRewards", reflect.TypeOf((*MockBeaconClient)(nil).GetSyncCommitteeRewards), ctx, blockID, validatorIDs)
+}
+
 // GetSyncCommittees mocks base method.
-func (m *MockBeaconClient) GetSyncCommittees(ctx context.Context, stateID string, epoch *common.Epoch) (*types.SyncCommittees, error) {
+func (m *MockBeaconClient) GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *common.Epoch) (*types.SyncCommittees, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSyncCommittees", ctx, stateID, epoch)
 	ret0, _ := ret[0].(*types.SyncCommittees)
@@ -572,7 +927,7 @@ func (mr *MockBeaconClientMockRecorder) GetSyncCommittees(ctx, stateID, epoch in
 }
 
 // GetValidator mocks base method.
-func (m *MockBeaconClient) GetValidator(ctx context.Context, stateID, validatorID string) (*types.Validator, error) {
+func (m *MockBeaconClient) GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (*types.Validator, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidator", ctx, stateID, validatorID)
 	ret0, _ := ret[0].(*types.Validator)
@@ -587,7 +942,7 @@ func (mr *MockBeaconClientMockRecorder) GetValidator(ctx, stateID, validatorID i
 }
 
 // GetValidatorBalances mocks base method.
-func (m *MockBeaconClient) GetValidatorBalances(ctx context.Context, stateID string, validatorIDs []string) ([]*types.ValidatorBalance, error) {
+func (m *MockBeaconClient) GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) ([]*types.ValidatorBalance, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidatorBalances", ctx, stateID, validatorIDs)
 	ret0, _ := ret[0].([]*types.ValidatorBalance)
@@ -602,7 +957,7 @@ func (mr *MockBeaconClientMockRecorder) GetValidatorBalances(ctx, stateID, valid
 }
 
 // GetValidators mocks base method.
-func (m *MockBeaconClient) GetValidators(ctx context.Context, stateID string, validatorIDs, statuses []string) ([]*types.Validator, error) {
+func (m *MockBeaconClient) GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) ([]*types.Validator, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetValidators", ctx, stateID, validatorIDs, statuses)
 	ret0, _ := ret[0].([]*types.Validator)
@@ -631,6 +986,130 @@ func (mr *MockBeaconClientMockRecorder) GetVoluntaryExits(ctx interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoluntaryExits", reflect.TypeOf((*MockBeaconClient)(nil).GetVoluntaryExits), ctx)
 }
 
+// SubmitAttestations mocks base method.
+func (m *MockBeaconClient) SubmitAttestations(ctx context.Context, attestations phase0.Attestations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAttestations", ctx, attestations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitAttestations indicates an expected call of SubmitAttestations.
+func (mr *MockBeaconClientMockRecorder) SubmitAttestations(ctx, attestations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAttestations", reflect.TypeOf((*MockBeaconClient)(nil).SubmitAttestations), ctx, attestations)
+}
+
+// SubmitBLSToExecutionChanges mocks base method.
+func (m *MockBeaconClient) SubmitBLSToExecutionChanges(ctx context.Context, changes common.SignedBLSToExecutionChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitBLSToExecutionChanges", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitBLSToExecutionChanges indicates an expected call of SubmitBLSToExecutionChanges.
+func (mr *MockBeaconClientMockRecorder) SubmitBLSToExecutionChanges(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBLSToExecutionChanges", reflect.TypeOf((*MockBeaconClient)(nil).SubmitBLSToExecutionChanges), ctx, changes)
+}
+
+// SubmitProposerSlashing mocks base method.
+func (m *MockBeaconClient) SubmitProposerSlashing(ctx context.Context, slashing *phase0.ProposerSlashing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitProposerSlashing", ctx, slashing)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitProposerSlashing indicates an expected call of SubmitProposerSlashing.
+func (mr *MockBeaconClientMockRecorder) SubmitProposerSlashing(ctx, slashing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposerSlashing", reflect.TypeOf((*MockBeaconClient)(nil).SubmitProposerSlashing), ctx, slashing)
+}
+
+// SubmitVoluntaryExit mocks base method.
+func (m *MockBeaconClient) SubmitVoluntaryExit(ctx context.Context, exit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", ctx, exit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockBeaconClientMockRecorder) SubmitVoluntaryExit(ctx, exit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockBeaconClient)(nil).SubmitVoluntaryExit), ctx, exit)
+}
+
+// MockValidatorClient is a mock of ValidatorClient interface.
+type MockValidatorClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorClientMockRecorder
+}
+
+// MockValidatorClientMockRecorder is the mock recorder for MockValidatorClient.
+type MockValidatorClientMockRecorder struct {
+	mock *MockValidatorClient
+}
+
+// NewMockValidatorClient creates a new mock instance.
+func NewMockValidatorClient(ctrl *gomock.Controller) *MockValidatorClient {
+	mock := &MockValidatorClient{ctrl: ctrl}
+	mock.recorder = &MockValidatorClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidatorClient) EXPECT() *MockValidatorClientMockRecorder {
+	return m.recorder
+}
+
+// GetAttesterDuties mocks base method.
+func (m *MockValidatorClient) GetAttesterDuties(ctx context.Context, epoch common.Epoch, indices []common.ValidatorIndex) (*types.AttesterDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttesterDuties", ctx, epoch, indices)
+	ret0, _ := ret[0].(*types.AttesterDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttesterDuties indicates an expected call of GetAttesterDuties.
+func (mr *MockValidatorClientMockRecorder) GetAttesterDuties(ctx, epoch, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttesterDuties", reflect.TypeOf((*MockValidatorClient)(nil).GetAttesterDuties), ctx, epoch, indices)
+}
+
+// GetProposerDuties mocks base method.
+func (m *MockValidatorClient) GetProposerDuties(ctx context.Context, epoch common.Epoch) (*types.ProposerDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposerDuties", ctx, epoch)
+	ret0, _ := ret[0].(*types.ProposerDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposerDuties indicates an expected call of GetProposerDuties.
+func (mr *MockValidatorClientMockRecorder) GetProposerDuties(ctx, epoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposerDuties", reflect.TypeOf((*MockValidatorClient)(nil).GetProposerDuties), ctx, epoch)
+}
+
+// GetSyncCommitteeDuties mocks base method.
+func (m *MockValidatorClient) GetSyncCommitteeDuties(ctx context.Context, epoch common.Epoch, indices []common.ValidatorIndex) (*types.SyncCommitteeDuties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCommitteeDuties", ctx, epoch, indices)
+	ret0, _ := ret[0].(*types.SyncCommitteeDuties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncCommitteeDuties indicates an expected call of GetSyncCommitteeDuties.
+func (mr *MockValidatorClientMockRecorder) GetSyncCommitteeDuties(ctx, epoch, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommitteeDuties", reflect.TypeOf((*MockValidatorClient)(nil).GetSyncCommitteeDuties), ctx, epoch, indices)
+}
+
 // MockNodeClient is a mock of NodeClient interface.
 type MockNodeClient struct {
 	ctrl     *gomock.Controller
@@ -654,6 +1133,36 @@ func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
 	return m.recorder
 }
 
+// GetHealth mocks base method.
+func (m *MockNodeClient) GetHealth(ctx context.Context) (types.Health, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealth", ctx)
+	ret0, _ := ret[0].(types.Health)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealth indicates an expected call of GetHealth.
+func (mr *MockNodeClientMockRecorder) GetHealth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealth", reflect.TypeOf((*MockNodeClient)(nil).GetHealth), ctx)
+}
+
+// GetIdentity mocks base method.
+func (m *MockNodeClient) GetIdentity(ctx context.Context) (*types.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", ctx)
+	ret0, _ := ret[0].(*types.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockNodeClientMockRecorder) GetIdentity(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockNodeClient)(nil).GetIdentity), ctx)
+}
+
 // GetNodeVersion mocks base method.
 func (m *MockNodeClient) GetNodeVersion(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -669,6 +1178,51 @@ func (mr *MockNodeClientMockRecorder) GetNodeVersion(ctx interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeVersion", reflect.TypeOf((*MockNodeClient)(nil).GetNodeVersion), ctx)
 }
 
+// GetPeerCount mocks base method.
+func (m *MockNodeClient) GetPeerCount(ctx context.Context) (*types.PeerCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeerCount", ctx)
+	ret0, _ := ret[0].(*types.PeerCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeerCount indicates an expected call of GetPeerCount.
+func (mr *MockNodeClientMockRecorder) GetPeerCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeerCount", reflect.TypeOf((*MockNodeClient)(nil).GetPeerCount), ctx)
+}
+
+// GetPeers mocks base method.
+func (m *MockNodeClient) GetPeers(ctx context.Context, states, directions []string) ([]*types.Peer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeers", ctx, states, directions)
+	ret0, _ := ret[0].([]*types.Peer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeers indicates an expected call of GetPeers.
+func (mr *MockNodeClientMockRecorder) GetPeers(ctx, states, directions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockNodeClient)(nil).GetPeers), ctx, states, directions)
+}
+
+// GetSyncing mocks base method.
+func (m *MockNodeClient) GetSyncing(ctx context.Context) (*types.Syncing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncing", ctx)
+	ret0, _ := ret[0].(*types.Syncing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncing indicates an expected call of GetSyncing.
+func (mr *MockNodeClientMockRecorder) GetSyncing(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncing", reflect.TypeOf((*MockNodeClient)(nil).GetSyncing), ctx)
+}
+
 // MockConfigClient is a mock of ConfigClient interface.
 type MockConfigClient struct {
 	ctrl     *gomock.Controller
@@ -692,11 +1246,41 @@ func (m *MockConfigClient) EXPECT() *MockConfigClientMockRecorder {
 	return m.recorder
 }
 
+// GetDepositContract mocks base method.
+func (m *MockConfigClient) GetDepositContract(ctx context.Context) (*types.DepositContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositContract", ctx)
+	ret0, _ := ret[0].(*types.DepositContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositContract indicates an expected call of GetDepositContract.
+func (mr *MockConfigClientMockRecorder) GetDepositContract(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositContract", reflect.TypeOf((*MockConfigClient)(nil).GetDepositContract), ctx)
+}
+
+// GetForkSchedule mocks base method.
+func (m *MockConfigClient) GetForkSchedule(ctx context.Context) ([]*common.Fork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForkSchedule", ctx)
+	ret0, _ := ret[0].([]*common.Fork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForkSchedule indicates an expected call of GetForkSchedule.
+func (mr *MockConfigClientMockRecorder) GetForkSchedule(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkSchedule", reflect.TypeOf((*MockConfigClient)(nil).GetForkSchedule), ctx)
+}
+
 // GetSpec mocks base method.
-func (m *MockConfigClient) GetSpec(ctx context.Context) (*common.Spec, error) {
+func (m *MockConfigClient) GetSpec(ctx context.Context) (*types.Spec, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSpec", ctx)
-	ret0, _ := ret[0].(*common.Spec)
+	ret0, _ := ret[0].(*types.Spec)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -706,3 +1290,78 @@ func (mr *MockConfigClientMockRecorder) GetSpec(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpec", reflect.TypeOf((*MockConfigClient)(nil).GetSpec), ctx)
 }
+
+// MockEventsClient is a mock of EventsClient interface.
+type MockEventsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsClientMockRecorder
+}
+
+// MockEventsClientMockRecorder is the mock recorder for MockEventsClient.
+type MockEventsClientMockRecorder struct {
+	mock *MockEventsClient
+}
+
+// NewMockEventsClient creates a new mock instance.
+func NewMockEventsClient(ctrl *gomock.Controller) *MockEventsClient {
+	mock := &MockEventsClient{ctrl: ctrl}
+	mock.recorder = &MockEventsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsClient) EXPECT() *MockEventsClientMockRecorder {
+	return m.recorder
+}
+
+// SubscribeEvents mocks base method.
+func (m *MockEventsClient) SubscribeEvents(ctx context.Context, topics []string) (<-chan *types.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeEvents", ctx, topics)
+	ret0, _ := ret[0].(<-chan *types.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeEvents indicates an expected call of SubscribeEvents.
+func (mr *MockEventsClientMockRecorder) SubscribeEvents(ctx, topics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeEvents", reflect.TypeOf((*MockEventsClient)(nil).SubscribeEvents), ctx, topics)
+}
+
+// MockValidatorsStreamClient is a mock of ValidatorsStreamClient interface.
+type MockValidatorsStreamClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorsStreamClientMockRecorder
+}
+
+// MockValidatorsStreamClientMockRecorder is the mock recorder for MockValidatorsStreamClient.
+type MockValidatorsStreamClientMockRecorder struct {
+	mock *MockValidatorsStreamClient
+}
+
+// NewMockValidatorsStreamClient creates a new mock instance.
+func NewMockValidatorsStreamClient(ctrl *gomock.Controller) *MockValidatorsStreamClient {
+	mock := &MockValidatorsStreamClient{ctrl: ctrl}
+	mock.recorder = &MockValidatorsStreamClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidatorsStreamClient) EXPECT() *MockValidatorsStreamClientMockRecorder {
+	return m.recorder
+}
+
+// StreamValidators mocks base method.
+func (m *MockValidatorsStreamClient) StreamValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string, fn func(*types.Validator) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamValidators", ctx, stateID, validatorIDs, statuses, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamValidators indicates an expected call of StreamValidators.
+func (mr *MockValidatorsStreamClientMockRecorder) StreamValidators(ctx, stateID, validatorIDs, statuses, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamValidators", reflect.TypeOf((*MockValidatorsStreamClient)(nil).StreamValidators), ctx, stateID, validatorIDs, statuses, fn)
+}
@@ -0,0 +1,439 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/sirupsen/logrus"
+
+	types "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	consensustypes "github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+var _ client.Client = (*Client)(nil)
+
+type Config struct {
+	// HealthCheckPeriod is the period at which nodes sync status is polled
+	HealthCheckPeriod *types.Duration
+
+	// MaxSyncDistance is the maximum sync distance for a node to be considered healthy
+	MaxSyncDistance uint64
+}
+
+const (
+	defaultHealthCheckPeriod = 12 * time.Second
+	defaultMaxSyncDistance   = 2
+)
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.HealthCheckPeriod == nil {
+		cfg.HealthCheckPeriod = &types.Duration{Duration: defaultHealthCheckPeriod}
+	}
+
+	if cfg.MaxSyncDistance == 0 {
+		cfg.MaxSyncDistance = defaultMaxSyncDistance
+	}
+
+	return cfg
+}
+
+// Node is a beacon node the multi client can route calls to
+type Node struct {
+	// Name identifies the node in logs and metrics
+	Name string
+
+	Client client.Client
+}
+
+// node holds the last known state of a Node
+type node struct {
+	*Node
+
+	// position of the node in the configuration, used to break ties
+	pos int
+
+	mux          sync.RWMutex
+	checked      bool
+	healthy      bool
+	headSlot     beaconcommon.Slot
+	syncDistance beaconcommon.Slot
+}
+
+type nodeState struct {
+	*node
+
+	checked      bool
+	healthy      bool
+	headSlot     beaconcommon.Slot
+	syncDistance beaconcommon.Slot
+}
+
+func (n *node) state() *nodeState {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	return &nodeState{
+		node:         n,
+		checked:      n.checked,
+		healthy:      n.healthy,
+		headSlot:     n.headSlot,
+		syncDistance: n.syncDistance,
+	}
+}
+
+// Client is a consensus client that routes calls over multiple beacon nodes
+//
+// Nodes sync status is regularly polled once the client is started. Each call is sent
+// to the healthiest node and, on transport errors or 5xx responses, fails over to the next one.
+// Calls on a slot pinned stateID or blockID are never sent to nodes which head is below this slot.
+type Client struct {
+	cfg *Config
+
+	nodes []*node
+
+	logger logrus.FieldLogger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	healthyGauge      *prometheus.GaugeVec
+	headSlotGauge     *prometheus.GaugeVec
+	syncDistanceGauge *prometheus.GaugeVec
+	requestsCounter   *prometheus.CounterVec
+	failuresCounter   *prometheus.CounterVec
+}
+
+// NewClient creates a client routing calls over nodes
+//
+// Nodes are given by order of preference, which is used to choose between nodes in the same state
+func NewClient(cfg *Config, nodes ...*Node) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("at least one node is required")
+	}
+
+	c := &Client{
+		cfg:    cfg,
+		logger: logrus.StandardLogger().WithField("component", "eth.consensus.multi"),
+		healthyGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: "consensus_client",
+				Name:      "node_healthy",
+				Help:      "Whether the beacon node is healthy (1) or not (0)",
+			},
+			[]string{"node"},
+		),
+		headSlotGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: "consensus_client",
+				Name:      "node_head_slot",
+				Help:      "Head slot of the beacon node",
+			},
+			[]string{"node"},
+		),
+		syncDistanceGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: "consensus_client",
+				Name:      "node_sync_distance",
+				Help:      "Sync distance of the beacon node",
+			},
+			[]string{"node"},
+		),
+		requestsCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "consensus_client",
+				Name:      "node_requests_total",
+				Help:      "Number of calls sent to the beacon node",
+			},
+			[]string{"node", "method"},
+		),
+		failuresCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: "consensus_client",
+				Name:      "node_failures_total",
+				Help:      "Number of calls to the beacon node that failed over to another node",
+			},
+			[]string{"node", "method"},
+		),
+	}
+
+	names := make(map[string]bool)
+	for i, n := range nodes {
+		if n.Client == nil {
+			return nil, fmt.Errorf("node %q has no client", n.Name)
+		}
+		if n.Name == "" {
+			return nil, fmt.Errorf("node #%v has no name", i)
+		}
+		if names[n.Name] {
+			return nil, fmt.Errorf("duplicate node name %q", n.Name)
+		}
+		names[n.Name] = true
+		c.nodes = append(c.nodes, &node{Node: n, pos: i})
+	}
+
+	return c, nil
+}
+
+func (c *Client) Logger() logrus.FieldLogger {
+	return c.logger
+}
+
+func (c *Client) SetLogger(logger logrus.FieldLogger) {
+	c.logger = logger.WithField("component", "eth.consensus.multi")
+}
+
+// RegisterMetrics registers per node metrics
+func (c *Client) RegisterMetrics(reg prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{
+		c.healthyGauge,
+		c.headSlotGauge,
+		c.syncDistanceGauge,
+		c.requestsCounter,
+		c.failuresCounter,
+	} {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start checks all nodes then starts polling them in the background
+func (c *Client) Start(ctx context.Context) error {
+	if c.cfg.HealthCheckPeriod == nil {
+		return fmt.Errorf("missing health check period, config defaults must be set")
+	}
+
+	c.checkNodes(ctx)
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.cfg.HealthCheckPeriod.Duration)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				c.checkNodes(runCtx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops polling nodes
+func (c *Client) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+
+	c.cancel()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkNodes concurrently refreshes the sync status of all nodes
+func (c *Client) checkNodes(ctx context.Context) {
+	wg := new(sync.WaitGroup)
+	for _, n := range c.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			c.checkNode(ctx, n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (c *Client) checkNode(ctx context.Context, n *node) {
+	syncing, err := n.Client.GetSyncing(ctx)
+	if err != nil && ctx.Err() != nil {
+		// check was interrupted, we keep the last known state
+		return
+	}
+
+	n.mux.Lock()
+	wasHealthy, wasChecked := n.healthy, n.checked
+	n.checked = true
+	if err != nil {
+		n.healthy = false
+	} else {
+		n.headSlot = syncing.HeadSlot
+		n.syncDistance = syncing.SyncDistance
+		n.healthy = uint64(syncing.SyncDistance) <= c.cfg.MaxSyncDistance
+	}
+	healthy, headSlot, syncDistance := n.healthy, n.headSlot, n.syncDistance
+	n.mux.Unlock()
+
+	c.headSlotGauge.WithLabelValues(n.Name).Set(float64(headSlot))
+	c.syncDistanceGauge.WithLabelValues(n.Name).Set(float64(syncDistance))
+	if healthy {
+		c.healthyGauge.WithLabelValues(n.Name).Set(1)
+	} else {
+		c.healthyGauge.WithLabelValues(n.Name).Set(0)
+	}
+
+	if wasChecked && healthy == wasHealthy {
+		return
+	}
+
+	logger := c.logger.WithField("node", n.Name).WithField("head_slot", headSlot).WithField("sync_distance", syncDistance)
+	switch {
+	case healthy:
+		logger.Infof("beacon node is healthy")
+	case err != nil:
+		logger.WithError(err).Warnf("beacon node is unhealthy")
+	default:
+		logger.Warnf("beacon node is unhealthy")
+	}
+}
+
+// candidates returns nodes that can serve a call, in order of preference
+//
+// Healthy nodes come first, then nodes are ordered by sync distance, head slot and position in configuration.
+// If minSlot is set, only nodes known to have reached minSlot are returned.
+func (c *Client) candidates(minSlot *beaconcommon.Slot) []*nodeState {
+	states := make([]*nodeState, 0, len(c.nodes))
+	for _, n := range c.nodes {
+		s := n.state()
+		if minSlot != nil && (!s.checked || s.headSlot < *minSlot) {
+			continue
+		}
+		states = append(states, s)
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		si, sj := states[i], states[j]
+		switch {
+		case si.healthy != sj.healthy:
+			return si.healthy
+		case si.checked != sj.checked:
+			return si.checked
+		case si.syncDistance != sj.syncDistance:
+			return si.syncDistance < sj.syncDistance
+		case si.headSlot != sj.headSlot:
+			return si.headSlot > sj.headSlot
+		default:
+			return si.pos < sj.pos
+		}
+	})
+
+	return states
+}
+
+// do calls fn on candidate nodes until one succeeds or fails with an error that does not justify failing over
+func (c *Client) do(ctx context.Context, method string, minSlot *beaconcommon.Slot, fn func(client.Client) error) error {
+	nodes := c.candidates(minSlot)
+	if len(nodes) == 0 {
+		// head may have moved since last check, or nodes may not have been checked yet,
+		// so we refresh nodes state before giving up
+		c.checkNodes(ctx)
+		nodes = c.candidates(minSlot)
+		if len(nodes) == 0 {
+			return fmt.Errorf("%v: no beacon node has reached slot %v", method, *minSlot)
+		}
+	}
+
+	var err error
+	for i, n := range nodes {
+		c.requestsCounter.WithLabelValues(n.Name, method).Inc()
+
+		err = fn(n.Client)
+		if err == nil || ctx.Err() != nil || !isFailoverError(err) {
+			return err
+		}
+
+		c.failuresCounter.WithLabelValues(n.Name, method).Inc()
+		if i < len(nodes)-1 {
+			c.logger.
+				WithField("node", n.Name).
+				WithField("method", method).
+				WithError(err).Warnf("call failed, failing over to next beacon node")
+		}
+	}
+
+	return err
+}
+
+// SubscribeEvents subscribes to events on the best node supporting events
+//
+// The subscription is bound to the node and does not fail over
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan *consensustypes.Event, error) {
+	for _, n := range c.candidates(nil) {
+		if cli, ok := n.Client.(client.EventsClient); ok {
+			return cli.SubscribeEvents(ctx, topics)
+		}
+	}
+	return nil, fmt.Errorf("no beacon node supports events")
+}
+
+// StreamValidators streams validators from the best node supporting streaming
+//
+// As fn may already have been called when a node fails, the call does not fail over
+func (c *Client) StreamValidators(ctx context.Context, stateID consensustypes.StateID, validatorIDs []consensustypes.ValidatorID, statuses []string, fn func(*consensustypes.Validator) error) error {
+	for _, n := range c.candidates(pinnedSlot(stateID)) {
+		if cli, ok := n.Client.(client.ValidatorsStreamClient); ok {
+			return cli.StreamValidators(ctx, stateID, validatorIDs, statuses, fn)
+		}
+	}
+	return fmt.Errorf("no beacon node supports validators streaming")
+}
+
+// isFailoverError indicates whether a call that failed with err should be retried on another node
+//
+// It is the case for transport errors and 5xx responses while other responses
+// (e.g. 404 on an unknown block) would be the same on any node. Canceled calls and
+// errors raised before sending the request (e.g. invalid parameters) do not fail over either.
+func isFailoverError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if status, ok := detailedErr.StatusCode.(int); ok && status > 0 {
+			return status >= 500
+		}
+	}
+
+	// connection failed (*url.Error implements net.Error) or dropped while reading the response
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// slotID is implemented by types.StateID and types.BlockID
+type slotID interface {
+	Slot() (beaconcommon.Slot, bool)
+}
+
+// pinnedSlot returns the slot a stateID or blockID refers to if it is a slot number, nil otherwise
+func pinnedSlot(id slotID) *beaconcommon.Slot {
+	slot, ok := id.Slot()
+	if !ok {
+		return nil
+	}
+	return &slot
+}
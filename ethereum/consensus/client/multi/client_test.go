@@ -0,0 +1,258 @@
+//go:build !integration
+// +build !integration
+
+package multi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+func newTestClient(t *testing.T, ctrl *gomock.Controller, n int) (*Client, []*mock.MockClient) {
+	var (
+		nodes []*Node
+		mocks []*mock.MockClient
+	)
+	for i := 0; i < n; i++ {
+		mockCli := mock.NewMockClient(ctrl)
+		mocks = append(mocks, mockCli)
+		nodes = append(nodes, &Node{Name: fmt.Sprintf("node-%v", i), Client: mockCli})
+	}
+
+	c, err := NewClient((&Config{}).SetDefault(), nodes...)
+	require.NoError(t, err)
+
+	return c, mocks
+}
+
+func expectSyncing(mockCli *mock.MockClient, headSlot, syncDistance beaconcommon.Slot, err error) {
+	if err != nil {
+		mockCli.EXPECT().GetSyncing(gomock.Any()).Return(nil, err)
+		return
+	}
+	mockCli.EXPECT().GetSyncing(gomock.Any()).Return(&types.Syncing{HeadSlot: headSlot, SyncDistance: syncDistance}, nil)
+}
+
+func statusError(status int) error {
+	return autorest.NewErrorWithError(fmt.Errorf("status %v", status), "eth2http.Client", "GetGenesis", &http.Response{StatusCode: status}, "Invalid response")
+}
+
+func transportError() error {
+	urlErr := &url.Error{Op: "Get", URL: "http://node/eth/v1/beacon/genesis", Err: syscall.ECONNRESET}
+	return autorest.NewErrorWithError(urlErr, "eth2http.Client", "GetGenesis", nil, "Failure sending request")
+}
+
+func TestClient(t *testing.T) {
+	t.Run("HealthRanking", testClientHealthRanking)
+	t.Run("FailoverOn5xx", testClientFailoverOn5xx)
+	t.Run("FailoverOnTransportError", testClientFailoverOnTransportError)
+	t.Run("NoFailoverOn4xx", testClientNoFailoverOn4xx)
+	t.Run("SlotPinned", testClientSlotPinned)
+	t.Run("SlotPinnedNoNode", testClientSlotPinnedNoNode)
+	t.Run("SlotPinnedUncheckedNode", testClientSlotPinnedUncheckedNode)
+	t.Run("StartWithoutDefaults", testClientStartWithoutDefaults)
+}
+
+func testClientHealthRanking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 3)
+
+	// node-0 is failing, node-1 is lagging, node-2 is synced
+	expectSyncing(mocks[0], 0, 0, fmt.Errorf("connection refused"))
+	expectSyncing(mocks[1], 90, 10, nil)
+	expectSyncing(mocks[2], 100, 0, nil)
+	c.checkNodes(context.Background())
+
+	genesis := &types.Genesis{}
+	mocks[2].EXPECT().GetGenesis(gomock.Any()).Return(genesis, nil)
+
+	rv, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, genesis, rv)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.healthyGauge.WithLabelValues("node-0")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.healthyGauge.WithLabelValues("node-1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.healthyGauge.WithLabelValues("node-2")))
+	assert.Equal(t, float64(100), testutil.ToFloat64(c.headSlotGauge.WithLabelValues("node-2")))
+	assert.Equal(t, float64(10), testutil.ToFloat64(c.syncDistanceGauge.WithLabelValues("node-1")))
+}
+
+func testClientFailoverOn5xx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+	expectSyncing(mocks[0], 100, 0, nil)
+	expectSyncing(mocks[1], 100, 0, nil)
+	c.checkNodes(context.Background())
+
+	genesis := &types.Genesis{}
+	gomock.InOrder(
+		mocks[0].EXPECT().GetGenesis(gomock.Any()).Return(nil, statusError(503)),
+		mocks[1].EXPECT().GetGenesis(gomock.Any()).Return(genesis, nil),
+	)
+
+	rv, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, genesis, rv)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.failuresCounter.WithLabelValues("node-0", "GetGenesis")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.requestsCounter.WithLabelValues("node-1", "GetGenesis")))
+}
+
+func testClientFailoverOnTransportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+	expectSyncing(mocks[0], 100, 0, nil)
+	expectSyncing(mocks[1], 100, 0, nil)
+	c.checkNodes(context.Background())
+
+	sendErr := transportError()
+	gomock.InOrder(
+		mocks[0].EXPECT().GetValidator(gomock.Any(), types.StateID("head"), types.ValidatorID("1")).Return(nil, sendErr),
+		mocks[1].EXPECT().GetValidator(gomock.Any(), types.StateID("head"), types.ValidatorID("1")).Return(nil, sendErr),
+	)
+
+	_, err := c.GetValidator(context.Background(), "head", "1")
+	require.Error(t, err)
+}
+
+func testClientNoFailoverOn4xx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+	expectSyncing(mocks[0], 100, 0, nil)
+	expectSyncing(mocks[1], 100, 0, nil)
+	c.checkNodes(context.Background())
+
+	mocks[0].EXPECT().GetGenesis(gomock.Any()).Return(nil, statusError(404))
+
+	_, err := c.GetGenesis(context.Background())
+	require.Error(t, err)
+}
+
+func testClientSlotPinned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+
+	// node-0 is preferred but has not reached slot 100 yet
+	expectSyncing(mocks[0], 99, 0, nil)
+	expectSyncing(mocks[1], 100, 1, nil)
+	c.checkNodes(context.Background())
+
+	block := &types.SignedBeaconBlock{}
+	mocks[1].EXPECT().GetBlock(gomock.Any(), types.BlockID("100")).Return(block, nil)
+	rv, err := c.GetBlock(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, block, rv)
+
+	// non slot IDs are not pinned
+	mocks[0].EXPECT().GetBlock(gomock.Any(), types.BlockID("head")).Return(block, nil)
+	_, err = c.GetBlock(context.Background(), "head")
+	require.NoError(t, err)
+
+	// node-1 fails with a 5xx but node-0 must not be tried
+	mocks[1].EXPECT().GetStateFork(gomock.Any(), types.StateID("100")).Return(nil, statusError(500))
+	_, err = c.GetStateFork(context.Background(), "100")
+	require.Error(t, err)
+}
+
+func testClientSlotPinnedNoNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+
+	expectSyncing(mocks[0], 99, 0, nil)
+	expectSyncing(mocks[1], 98, 0, nil)
+	c.checkNodes(context.Background())
+
+	// nodes are refreshed once before giving up
+	expectSyncing(mocks[0], 99, 0, nil)
+	expectSyncing(mocks[1], 99, 0, nil)
+
+	_, err := c.GetBlock(context.Background(), "100")
+	require.Error(t, err)
+}
+
+func testClientSlotPinnedUncheckedNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, mocks := newTestClient(t, ctrl, 2)
+
+	// node-0 is preferred but has not been checked yet
+	expectSyncing(mocks[1], 100, 0, nil)
+	c.checkNode(context.Background(), c.nodes[1])
+
+	// node-1 fails with a 5xx but node-0 must not be tried
+	mocks[1].EXPECT().GetBlock(gomock.Any(), types.BlockID("100")).Return(nil, statusError(500))
+	_, err := c.GetBlock(context.Background(), "100")
+	require.Error(t, err)
+
+	// unchecked nodes are checked before giving up
+	c, mocks = newTestClient(t, ctrl, 1)
+	expectSyncing(mocks[0], 100, 0, nil)
+
+	block := &types.SignedBeaconBlock{}
+	mocks[0].EXPECT().GetBlock(gomock.Any(), types.BlockID("100")).Return(block, nil)
+	rv, err := c.GetBlock(context.Background(), "100")
+	require.NoError(t, err)
+	assert.Equal(t, block, rv)
+}
+
+func testClientStartWithoutDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, err := NewClient(&Config{}, &Node{Name: "node-0", Client: mock.NewMockClient(ctrl)})
+	require.NoError(t, err)
+
+	err = c.Start(context.Background())
+	require.Error(t, err)
+}
+
+func TestPinnedSlot(t *testing.T) {
+	assert.Equal(t, beaconcommon.Slot(12), *pinnedSlot(types.StateID("12")))
+	assert.Nil(t, pinnedSlot(types.StateID("head")))
+	assert.Nil(t, pinnedSlot(types.StateID("finalized")))
+	assert.Nil(t, pinnedSlot(types.StateID("0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360")))
+}
+
+func TestIsFailoverError(t *testing.T) {
+	assert.True(t, isFailoverError(transportError()))
+	assert.True(t, isFailoverError(autorest.NewErrorWithError(io.ErrUnexpectedEOF, "eth2http.Client", "GetGenesis", nil, "Invalid response")))
+	assert.True(t, isFailoverError(statusError(500)))
+	assert.True(t, isFailoverError(statusError(503)))
+	assert.False(t, isFailoverError(statusError(400)))
+	assert.False(t, isFailoverError(statusError(404)))
+
+	urlErr := &url.Error{Op: "Get", URL: "http://node/eth/v1/beacon/genesis", Err: context.Canceled}
+	assert.False(t, isFailoverError(autorest.NewErrorWithError(urlErr, "eth2http.Client", "GetGenesis", nil, "Failure sending request")))
+	urlErr = &url.Error{Op: "Get", URL: "http://node/eth/v1/beacon/genesis", Err: context.DeadlineExceeded}
+	assert.False(t, isFailoverError(autorest.NewErrorWithError(urlErr, "eth2http.Client", "GetGenesis", nil, "Failure sending request")))
+	assert.False(t, isFailoverError(autorest.NewErrorWithError(fmt.Errorf("invalid validator ID"), "eth2http.Client", "GetValidator", nil, "Failure preparing request")))
+	assert.False(t, isFailoverError(fmt.Errorf("unexpected error")))
+}
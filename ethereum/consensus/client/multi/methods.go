@@ -0,0 +1,318 @@
+package multi
+
+import (
+	"context"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// Methods below implement client.Client by routing each call to the best available node (see Client.do)
+// Calls on a slot pinned stateID or blockID are only routed to nodes which head has reached this slot
+
+func (c *Client) GetGenesis(ctx context.Context) (rv *types.Genesis, err error) {
+	err = c.do(ctx, "GetGenesis", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetGenesis(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetStateRoot(ctx context.Context, stateID types.StateID) (rv *beaconcommon.Root, err error) {
+	err = c.do(ctx, "GetStateRoot", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetStateRoot(ctx, stateID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetStateFork(ctx context.Context, stateID types.StateID) (rv *beaconcommon.Fork, err error) {
+	err = c.do(ctx, "GetStateFork", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetStateFork(ctx, stateID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetStateFinalityCheckpoints(ctx context.Context, stateID types.StateID) (rv *types.StateFinalityCheckpoints, err error) {
+	err = c.do(ctx, "GetStateFinalityCheckpoints", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetStateFinalityCheckpoints(ctx, stateID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetValidators(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID, statuses []string) (rv []*types.Validator, err error) {
+	err = c.do(ctx, "GetValidators", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetValidators(ctx, stateID, validatorIDs, statuses)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetValidator(ctx context.Context, stateID types.StateID, validatorID types.ValidatorID) (rv *types.Validator, err error) {
+	err = c.do(ctx, "GetValidator", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetValidator(ctx, stateID, validatorID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetValidatorBalances(ctx context.Context, stateID types.StateID, validatorIDs []types.ValidatorID) (rv []*types.ValidatorBalance, err error) {
+	err = c.do(ctx, "GetValidatorBalances", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetValidatorBalances(ctx, stateID, validatorIDs)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch, index *beaconcommon.CommitteeIndex, slot *beaconcommon.Slot) (rv []*types.Committee, err error) {
+	err = c.do(ctx, "GetCommittees", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetCommittees(ctx, stateID, epoch, index, slot)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetSyncCommittees(ctx context.Context, stateID types.StateID, epoch *beaconcommon.Epoch) (rv *types.SyncCommittees, err error) {
+	err = c.do(ctx, "GetSyncCommittees", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetSyncCommittees(ctx, stateID, epoch)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetState(ctx context.Context, stateID types.StateID) (rv *types.BeaconState, err error) {
+	err = c.do(ctx, "GetState", pinnedSlot(stateID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetState(ctx, stateID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlockHeaders(ctx context.Context, slot *beaconcommon.Slot, parentRoot *beaconcommon.Root) (rv []*types.BeaconBlockHeader, err error) {
+	err = c.do(ctx, "GetBlockHeaders", slot, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlockHeaders(ctx, slot, parentRoot)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlockHeader(ctx context.Context, blockID types.BlockID) (rv *types.BeaconBlockHeader, err error) {
+	err = c.do(ctx, "GetBlockHeader", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlockHeader(ctx, blockID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlock(ctx context.Context, blockID types.BlockID) (rv *types.SignedBeaconBlock, err error) {
+	err = c.do(ctx, "GetBlock", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlock(ctx, blockID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlobSidecars(ctx context.Context, blockID types.BlockID, indices []uint64) (rv []*types.BlobSidecar, err error) {
+	err = c.do(ctx, "GetBlobSidecars", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlobSidecars(ctx, blockID, indices)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlockRoot(ctx context.Context, blockID types.BlockID) (rv *beaconcommon.Root, err error) {
+	err = c.do(ctx, "GetBlockRoot", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlockRoot(ctx, blockID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlockAttestations(ctx context.Context, blockID types.BlockID) (rv beaconphase0.Attestations, err error) {
+	err = c.do(ctx, "GetBlockAttestations", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlockAttestations(ctx, blockID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetAttestations(ctx context.Context) (rv beaconphase0.Attestations, err error) {
+	err = c.do(ctx, "GetAttestations", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetAttestations(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetAttesterSlashings(ctx context.Context) (rv beaconphase0.AttesterSlashings, err error) {
+	err = c.do(ctx, "GetAttesterSlashings", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetAttesterSlashings(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetProposerSlashings(ctx context.Context) (rv beaconphase0.ProposerSlashings, err error) {
+	err = c.do(ctx, "GetProposerSlashings", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetProposerSlashings(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetVoluntaryExits(ctx context.Context) (rv beaconphase0.VoluntaryExits, err error) {
+	err = c.do(ctx, "GetVoluntaryExits", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetVoluntaryExits(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetBlockRewards(ctx context.Context, blockID types.BlockID) (rv *types.BlockRewards, err error) {
+	err = c.do(ctx, "GetBlockRewards", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetBlockRewards(ctx, blockID)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetAttestationRewards(ctx context.Context, epoch beaconcommon.Epoch, validatorIDs []types.ValidatorID) (rv *types.AttestationRewards, err error) {
+	err = c.do(ctx, "GetAttestationRewards", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetAttestationRewards(ctx, epoch, validatorIDs)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockID types.BlockID, validatorIDs []types.ValidatorID) (rv []*types.SyncCommitteeReward, err error) {
+	err = c.do(ctx, "GetSyncCommitteeRewards", pinnedSlot(blockID), func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetSyncCommitteeRewards(ctx, blockID, validatorIDs)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) SubmitVoluntaryExit(ctx context.Context, exit *beaconphase0.SignedVoluntaryExit) error {
+	return c.do(ctx, "SubmitVoluntaryExit", nil, func(cli client.Client) error {
+		return cli.SubmitVoluntaryExit(ctx, exit)
+	})
+}
+
+func (c *Client) SubmitBLSToExecutionChanges(ctx context.Context, changes beaconcommon.SignedBLSToExecutionChanges) error {
+	return c.do(ctx, "SubmitBLSToExecutionChanges", nil, func(cli client.Client) error {
+		return cli.SubmitBLSToExecutionChanges(ctx, changes)
+	})
+}
+
+func (c *Client) SubmitAttestations(ctx context.Context, attestations beaconphase0.Attestations) error {
+	return c.do(ctx, "SubmitAttestations", nil, func(cli client.Client) error {
+		return cli.SubmitAttestations(ctx, attestations)
+	})
+}
+
+func (c *Client) SubmitProposerSlashing(ctx context.Context, slashing *beaconphase0.ProposerSlashing) error {
+	return c.do(ctx, "SubmitProposerSlashing", nil, func(cli client.Client) error {
+		return cli.SubmitProposerSlashing(ctx, slashing)
+	})
+}
+
+func (c *Client) GetProposerDuties(ctx context.Context, epoch beaconcommon.Epoch) (rv *types.ProposerDuties, err error) {
+	err = c.do(ctx, "GetProposerDuties", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetProposerDuties(ctx, epoch)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetAttesterDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (rv *types.AttesterDuties, err error) {
+	err = c.do(ctx, "GetAttesterDuties", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetAttesterDuties(ctx, epoch, indices)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetSyncCommitteeDuties(ctx context.Context, epoch beaconcommon.Epoch, indices []beaconcommon.ValidatorIndex) (rv *types.SyncCommitteeDuties, err error) {
+	err = c.do(ctx, "GetSyncCommitteeDuties", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetSyncCommitteeDuties(ctx, epoch, indices)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetNodeVersion(ctx context.Context) (rv string, err error) {
+	err = c.do(ctx, "GetNodeVersion", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetNodeVersion(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetSyncing(ctx context.Context) (rv *types.Syncing, err error) {
+	err = c.do(ctx, "GetSyncing", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetSyncing(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetHealth(ctx context.Context) (rv types.Health, err error) {
+	err = c.do(ctx, "GetHealth", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetHealth(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetPeers(ctx context.Context, states, directions []string) (rv []*types.Peer, err error) {
+	err = c.do(ctx, "GetPeers", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetPeers(ctx, states, directions)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetPeerCount(ctx context.Context) (rv *types.PeerCount, err error) {
+	err = c.do(ctx, "GetPeerCount", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetPeerCount(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetIdentity(ctx context.Context) (rv *types.Identity, err error) {
+	err = c.do(ctx, "GetIdentity", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetIdentity(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetSpec(ctx context.Context) (rv *types.Spec, err error) {
+	err = c.do(ctx, "GetSpec", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetSpec(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetForkSchedule(ctx context.Context) (rv []*beaconcommon.Fork, err error) {
+	err = c.do(ctx, "GetForkSchedule", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetForkSchedule(ctx)
+		return
+	})
+	return rv, err
+}
+
+func (c *Client) GetDepositContract(ctx context.Context) (rv *types.DepositContract, err error) {
+	err = c.do(ctx, "GetDepositContract", nil, func(cli client.Client) (callErr error) {
+		rv, callErr = cli.GetDepositContract(ctx)
+		return
+	})
+	return rv, err
+}
@@ -0,0 +1,631 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/tree"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// BeaconNode is a fake beacon node serving the Beacon API from a programmable in-memory chain
+//
+// It runs on an httptest.Server so a consensus client can be pointed at BeaconNode.URL.
+// The chain is programmed using SetGenesis, AddBlock, SetValidators and SetFinality.
+//
+// Served endpoints are
+//   - /eth/v1/beacon/genesis
+//   - /eth/v1/beacon/states/{state_id}/{root,finality_checkpoints,validators,validator_balances}
+//   - /eth/v1/beacon/headers and /eth/v1/beacon/headers/{block_id}
+//   - /eth/v2/beacon/blocks/{block_id} and /eth/v1/beacon/blocks/{block_id}/root
+//   - /eth/v1/node/version and /eth/v1/node/syncing
+//
+// Blocks are served JSON encoded only
+type BeaconNode struct {
+	*httptest.Server
+
+	mux sync.RWMutex
+
+	spec *beaconcommon.Spec
+
+	genesis *types.Genesis
+
+	// blocks of the canonical chain ordered by slot
+	blocks []*fakeBlock
+
+	// validators sets ordered by the slot from which they apply
+	validators []*fakeValidators
+
+	finality *types.StateFinalityCheckpoints
+}
+
+type fakeBlock struct {
+	root   beaconcommon.Root
+	header *beaconcommon.SignedBeaconBlockHeader
+	block  *types.SignedBeaconBlock
+}
+
+type fakeValidators struct {
+	slot       beaconcommon.Slot
+	validators []*types.Validator
+}
+
+// NewBeaconNode creates and starts a fake beacon node with an empty chain
+//
+// Block roots are computed using mainnet spec. The caller must call Close once done.
+func NewBeaconNode() *BeaconNode {
+	n := &BeaconNode{
+		spec:     configs.Mainnet,
+		genesis:  new(types.Genesis),
+		finality: new(types.StateFinalityCheckpoints),
+	}
+	n.Server = httptest.NewServer(n.router())
+	return n
+}
+
+// SetSpec sets the spec used to compute block roots and served on config endpoints
+func (n *BeaconNode) SetSpec(spec *beaconcommon.Spec) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.spec = spec
+}
+
+// SetGenesis sets the genesis served by the node
+func (n *BeaconNode) SetGenesis(genesis *types.Genesis) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.genesis = genesis
+}
+
+// AddBlock adds a block to the canonical chain and returns its root
+//
+// Blocks can be added in any order, the block with the highest slot being the head.
+// A block added at the slot of an existing block replaces it.
+func (n *BeaconNode) AddBlock(block *types.SignedBeaconBlock) (beaconcommon.Root, error) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	header, err := signedHeader(n.spec, block)
+	if err != nil {
+		return beaconcommon.Root{}, err
+	}
+
+	b := &fakeBlock{
+		root:   header.Message.HashTreeRoot(tree.GetHashFn()),
+		header: header,
+		block:  block,
+	}
+
+	i := sort.Search(len(n.blocks), func(i int) bool { return n.blocks[i].header.Message.Slot >= header.Message.Slot })
+	switch {
+	case i < len(n.blocks) && n.blocks[i].header.Message.Slot == header.Message.Slot:
+		n.blocks[i] = b
+	default:
+		n.blocks = append(n.blocks, nil)
+		copy(n.blocks[i+1:], n.blocks[i:])
+		n.blocks[i] = b
+	}
+
+	return b.root, nil
+}
+
+// SetValidators sets validators of states from slot onwards (until a following call for a later slot)
+func (n *BeaconNode) SetValidators(slot beaconcommon.Slot, validators []*types.Validator) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	i := sort.Search(len(n.validators), func(i int) bool { return n.validators[i].slot >= slot })
+	v := &fakeValidators{slot: slot, validators: validators}
+	switch {
+	case i < len(n.validators) && n.validators[i].slot == slot:
+		n.validators[i] = v
+	default:
+		n.validators = append(n.validators, nil)
+		copy(n.validators[i+1:], n.validators[i:])
+		n.validators[i] = v
+	}
+}
+
+// SetFinality sets finality checkpoints served for every state
+//
+// It also defines "justified" and "finalized" state and block IDs
+func (n *BeaconNode) SetFinality(finality *types.StateFinalityCheckpoints) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.finality = finality
+}
+
+func (n *BeaconNode) router() http.Handler {
+	router := httprouter.New()
+
+	router.GET("/eth/v1/beacon/genesis", n.handleGetGenesis)
+	router.GET("/eth/v1/beacon/states/:state_id/root", n.handleGetStateRoot)
+	router.GET("/eth/v1/beacon/states/:state_id/finality_checkpoints", n.handleGetFinalityCheckpoints)
+	router.GET("/eth/v1/beacon/states/:state_id/validators", n.handleGetValidators)
+	router.POST("/eth/v1/beacon/states/:state_id/validators", n.handlePostValidators)
+	router.GET("/eth/v1/beacon/states/:state_id/validators/:validator_id", n.handleGetValidator)
+	router.GET("/eth/v1/beacon/states/:state_id/validator_balances", n.handleGetValidatorBalances)
+	router.POST("/eth/v1/beacon/states/:state_id/validator_balances", n.handlePostValidatorBalances)
+	router.GET("/eth/v1/beacon/headers", n.handleGetBlockHeaders)
+	router.GET("/eth/v1/beacon/headers/:block_id", n.handleGetBlockHeader)
+	router.GET("/eth/v2/beacon/blocks/:block_id", n.handleGetBlock)
+	router.GET("/eth/v1/beacon/blocks/:block_id/root", n.handleGetBlockRoot)
+	router.GET("/eth/v1/node/version", n.handleGetNodeVersion)
+	router.GET("/eth/v1/node/syncing", n.handleGetSyncing)
+	router.GET("/eth/v1/config/spec", n.handleGetSpec)
+	router.GET("/eth/v1/config/fork_schedule", n.handleGetForkSchedule)
+	router.GET("/eth/v1/config/deposit_contract", n.handleGetDepositContract)
+
+	router.NotFound = http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		writeError(rw, http.StatusNotFound, "endpoint not found")
+	})
+
+	return router
+}
+
+func (n *BeaconNode) handleGetGenesis(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	writeData(rw, n.genesis)
+}
+
+func (n *BeaconNode) handleGetStateRoot(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	slot, ok := n.stateSlot(rw, ps.ByName("state_id"))
+	if !ok {
+		return
+	}
+
+	b := n.blockAtSlot(slot)
+	if b == nil {
+		writeError(rw, http.StatusNotFound, "state not found")
+		return
+	}
+
+	writeData(rw, map[string]interface{}{"root": b.header.Message.StateRoot})
+}
+
+func (n *BeaconNode) handleGetFinalityCheckpoints(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	if _, ok := n.stateSlot(rw, ps.ByName("state_id")); !ok {
+		return
+	}
+
+	writeData(rw, n.finality)
+}
+
+func (n *BeaconNode) handleGetValidators(rw http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	query := req.URL.Query()
+	n.getValidators(rw, ps.ByName("state_id"), splitQuery(query["id"]), splitQuery(query["status"]))
+}
+
+func (n *BeaconNode) handlePostValidators(rw http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	body := new(struct {
+		IDs      []string `json:"ids"`
+		Statuses []string `json:"statuses"`
+	})
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	n.getValidators(rw, ps.ByName("state_id"), body.IDs, body.Statuses)
+}
+
+func (n *BeaconNode) getValidators(rw http.ResponseWriter, stateID string, ids, statuses []string) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	validators, ok := n.stateValidators(rw, stateID)
+	if !ok {
+		return
+	}
+
+	writeData(rw, filterValidators(validators, ids, statuses))
+}
+
+func (n *BeaconNode) handleGetValidator(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	validators, ok := n.stateValidators(rw, ps.ByName("state_id"))
+	if !ok {
+		return
+	}
+
+	matches := filterValidators(validators, []string{ps.ByName("validator_id")}, nil)
+	if len(matches) == 0 {
+		writeError(rw, http.StatusNotFound, "validator not found")
+		return
+	}
+
+	writeData(rw, matches[0])
+}
+
+func (n *BeaconNode) handleGetValidatorBalances(rw http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	n.getValidatorBalances(rw, ps.ByName("state_id"), splitQuery(req.URL.Query()["id"]))
+}
+
+func (n *BeaconNode) handlePostValidatorBalances(rw http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	var ids []string
+	if err := json.NewDecoder(req.Body).Decode(&ids); err != nil {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	n.getValidatorBalances(rw, ps.ByName("state_id"), ids)
+}
+
+func (n *BeaconNode) getValidatorBalances(rw http.ResponseWriter, stateID string, ids []string) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	validators, ok := n.stateValidators(rw, stateID)
+	if !ok {
+		return
+	}
+
+	balances := []*types.ValidatorBalance{}
+	for _, val := range filterValidators(validators, ids, nil) {
+		balances = append(balances, &types.ValidatorBalance{Index: val.Index, Balance: val.Balance})
+	}
+
+	writeData(rw, balances)
+}
+
+func (n *BeaconNode) handleGetBlockHeaders(rw http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	query := req.URL.Query()
+
+	candidates := n.blocks
+	if slotParam := query.Get("slot"); slotParam != "" {
+		slot, err := strconv.ParseUint(slotParam, 10, 64)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid slot %q", slotParam))
+			return
+		}
+		candidates = nil
+		if b := n.blockAtSlot(beaconcommon.Slot(slot)); b != nil {
+			candidates = []*fakeBlock{b}
+		}
+	} else if query.Get("parent_root") == "" && len(n.blocks) > 0 {
+		candidates = n.blocks[len(n.blocks)-1:]
+	}
+
+	headers := []*types.BeaconBlockHeader{}
+	for _, b := range candidates {
+		if parentRoot := query.Get("parent_root"); parentRoot != "" && b.header.Message.ParentRoot.String() != parentRoot {
+			continue
+		}
+		headers = append(headers, newBlockHeader(b))
+	}
+
+	writeData(rw, headers)
+}
+
+func (n *BeaconNode) handleGetBlockHeader(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	b, ok := n.block(rw, ps.ByName("block_id"))
+	if !ok {
+		return
+	}
+
+	writeData(rw, newBlockHeader(b))
+}
+
+func (n *BeaconNode) handleGetBlock(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	b, ok := n.block(rw, ps.ByName("block_id"))
+	if !ok {
+		return
+	}
+
+	// SignedBeaconBlock already encodes into a {"version": ..., "data": ...} object
+	rw.Header().Set("Eth-Consensus-Version", b.block.Version)
+	writeJSON(rw, http.StatusOK, b.block)
+}
+
+func (n *BeaconNode) handleGetBlockRoot(rw http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	b, ok := n.block(rw, ps.ByName("block_id"))
+	if !ok {
+		return
+	}
+
+	writeData(rw, map[string]interface{}{"root": b.root})
+}
+
+func (n *BeaconNode) handleGetNodeVersion(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	writeData(rw, map[string]interface{}{"version": "fake/v0.0.0"})
+}
+
+func (n *BeaconNode) handleGetSyncing(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	writeData(rw, &types.Syncing{HeadSlot: n.headSlot()})
+}
+
+func (n *BeaconNode) handleGetSpec(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	writeData(rw, n.spec)
+}
+
+func (n *BeaconNode) handleGetForkSchedule(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	versions := []beaconcommon.Version{
+		n.spec.GENESIS_FORK_VERSION,
+		n.spec.ALTAIR_FORK_VERSION,
+		n.spec.BELLATRIX_FORK_VERSION,
+		n.spec.CAPELLA_FORK_VERSION,
+		n.spec.DENEB_FORK_VERSION,
+		n.spec.ELECTRA_FORK_VERSION,
+	}
+	epochs := []beaconcommon.Epoch{
+		0,
+		n.spec.ALTAIR_FORK_EPOCH,
+		n.spec.BELLATRIX_FORK_EPOCH,
+		n.spec.CAPELLA_FORK_EPOCH,
+		n.spec.DENEB_FORK_EPOCH,
+		n.spec.ELECTRA_FORK_EPOCH,
+	}
+
+	forks := make([]*beaconcommon.Fork, 0, len(versions))
+	for i := range versions {
+		fork := &beaconcommon.Fork{CurrentVersion: versions[i], PreviousVersion: versions[i], Epoch: epochs[i]}
+		if i > 0 {
+			fork.PreviousVersion = versions[i-1]
+		}
+		forks = append(forks, fork)
+	}
+
+	writeData(rw, forks)
+}
+
+func (n *BeaconNode) handleGetDepositContract(rw http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	n.mux.RLock()
+	defer n.mux.RUnlock()
+
+	writeData(rw, &types.DepositContract{
+		ChainID: uint64(n.spec.DEPOSIT_CHAIN_ID),
+		Address: n.spec.DEPOSIT_CONTRACT_ADDRESS,
+	})
+}
+
+func (n *BeaconNode) headSlot() beaconcommon.Slot {
+	if len(n.blocks) == 0 {
+		return 0
+	}
+	return n.blocks[len(n.blocks)-1].header.Message.Slot
+}
+
+func (n *BeaconNode) blockAtSlot(slot beaconcommon.Slot) *fakeBlock {
+	i := sort.Search(len(n.blocks), func(i int) bool { return n.blocks[i].header.Message.Slot >= slot })
+	if i < len(n.blocks) && n.blocks[i].header.Message.Slot == slot {
+		return n.blocks[i]
+	}
+	return nil
+}
+
+// checkpointSlot returns the slot of a checkpoint, which is the slot of its block if known
+func (n *BeaconNode) checkpointSlot(checkpoint beaconcommon.Checkpoint) beaconcommon.Slot {
+	for _, b := range n.blocks {
+		if b.root == checkpoint.Root {
+			return b.header.Message.Slot
+		}
+	}
+	slot, _ := n.spec.EpochStartSlot(checkpoint.Epoch)
+	return slot
+}
+
+// stateSlot resolves a stateID into a slot, writing an error response if it can not be resolved
+func (n *BeaconNode) stateSlot(rw http.ResponseWriter, stateID string) (beaconcommon.Slot, bool) {
+	switch stateID {
+	case "head":
+		return n.headSlot(), true
+	case "genesis":
+		return 0, true
+	case "finalized":
+		return n.checkpointSlot(n.finality.FinalizedCheckpoint), true
+	case "justified":
+		return n.checkpointSlot(n.finality.CurrentJustifiedCheckpoint), true
+	}
+
+	if strings.HasPrefix(stateID, "0x") {
+		for _, b := range n.blocks {
+			if b.header.Message.StateRoot.String() == stateID {
+				return b.header.Message.Slot, true
+			}
+		}
+		writeError(rw, http.StatusNotFound, "state not found")
+		return 0, false
+	}
+
+	slot, err := strconv.ParseUint(stateID, 10, 64)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid state ID %q", stateID))
+		return 0, false
+	}
+
+	if beaconcommon.Slot(slot) > n.headSlot() {
+		writeError(rw, http.StatusNotFound, "state not found")
+		return 0, false
+	}
+
+	return beaconcommon.Slot(slot), true
+}
+
+// stateValidators returns the validators of a state, writing an error response if the state can not be resolved
+func (n *BeaconNode) stateValidators(rw http.ResponseWriter, stateID string) ([]*types.Validator, bool) {
+	slot, ok := n.stateSlot(rw, stateID)
+	if !ok {
+		return nil, false
+	}
+
+	var validators []*types.Validator
+	for _, v := range n.validators {
+		if v.slot > slot {
+			break
+		}
+		validators = v.validators
+	}
+
+	return validators, true
+}
+
+// block resolves a blockID into a block, writing an error response if it can not be resolved
+func (n *BeaconNode) block(rw http.ResponseWriter, blockID string) (*fakeBlock, bool) {
+	var b *fakeBlock
+	switch {
+	case blockID == "head":
+		if len(n.blocks) > 0 {
+			b = n.blocks[len(n.blocks)-1]
+		}
+	case blockID == "genesis":
+		b = n.blockAtSlot(0)
+	case blockID == "finalized":
+		b = n.blockAtSlot(n.checkpointSlot(n.finality.FinalizedCheckpoint))
+	case blockID == "justified":
+		b = n.blockAtSlot(n.checkpointSlot(n.finality.CurrentJustifiedCheckpoint))
+	case strings.HasPrefix(blockID, "0x"):
+		for _, candidate := range n.blocks {
+			if candidate.root.String() == blockID {
+				b = candidate
+			}
+		}
+	default:
+		slot, err := strconv.ParseUint(blockID, 10, 64)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid block ID %q", blockID))
+			return nil, false
+		}
+		b = n.blockAtSlot(beaconcommon.Slot(slot))
+	}
+
+	if b == nil {
+		writeError(rw, http.StatusNotFound, "block not found")
+		return nil, false
+	}
+
+	return b, true
+}
+
+// filterValidators returns validators matching one of ids (index or pubkey) and one of statuses
+//
+// Statuses can be either specific (e.g. "active_ongoing") or general (e.g. "active")
+func filterValidators(validators []*types.Validator, ids, statuses []string) []*types.Validator {
+	rv := []*types.Validator{}
+	for _, val := range validators {
+		if len(ids) > 0 && !matchValidatorID(val, ids) {
+			continue
+		}
+		if len(statuses) > 0 && !matchValidatorStatus(val, statuses) {
+			continue
+		}
+		rv = append(rv, val)
+	}
+	return rv
+}
+
+func matchValidatorID(val *types.Validator, ids []string) bool {
+	for _, id := range ids {
+		if id == strconv.FormatUint(uint64(val.Index), 10) {
+			return true
+		}
+		if val.Validator != nil && id == val.Validator.Pubkey.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func matchValidatorStatus(val *types.Validator, statuses []string) bool {
+	for _, status := range statuses {
+		if val.Status.Matches(types.ValidatorStatus(status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitQuery splits query values which can be either repeated or comma separated
+func splitQuery(values []string) []string {
+	var rv []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s != "" {
+				rv = append(rv, s)
+			}
+		}
+	}
+	return rv
+}
+
+func newBlockHeader(b *fakeBlock) *types.BeaconBlockHeader {
+	return &types.BeaconBlockHeader{
+		Root:      b.root,
+		Canonical: true,
+		Header:    *b.header,
+	}
+}
+
+func signedHeader(spec *beaconcommon.Spec, block *types.SignedBeaconBlock) (*beaconcommon.SignedBeaconBlockHeader, error) {
+	switch {
+	case block.Phase0 != nil:
+		return block.Phase0.SignedHeader(spec), nil
+	case block.Altair != nil:
+		return block.Altair.SignedHeader(spec), nil
+	case block.Bellatrix != nil:
+		return block.Bellatrix.SignedHeader(spec), nil
+	case block.Capella != nil:
+		return block.Capella.SignedHeader(spec), nil
+	case block.Deneb != nil:
+		return block.Deneb.SignedHeader(spec), nil
+	default:
+		return nil, fmt.Errorf("empty block")
+	}
+}
+
+func writeData(rw http.ResponseWriter, data interface{}) {
+	writeJSON(rw, http.StatusOK, map[string]interface{}{
+		"execution_optimistic": false,
+		"data":                 data,
+	})
+}
+
+func writeError(rw http.ResponseWriter, status int, msg string) {
+	writeJSON(rw, status, &types.Error{Code: status, Message: msg})
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(v)
+}
@@ -0,0 +1,169 @@
+//go:build !integration
+// +build !integration
+
+package testutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eth2http "github.com/kilnfi/go-utils/ethereum/consensus/client/http"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+func newTestBlock(slot beaconcommon.Slot, parentRoot beaconcommon.Root) *types.SignedBeaconBlock {
+	block := &types.SignedBeaconBlock{Version: types.ForkCapella, Capella: new(capella.SignedBeaconBlock)}
+	block.Capella.Message.Slot = slot
+	block.Capella.Message.ParentRoot = parentRoot
+	block.Capella.Message.StateRoot = beaconcommon.Root{byte(slot)}
+	return block
+}
+
+func newTestValidators(count int, balance beaconcommon.Gwei) []*types.Validator {
+	var validators []*types.Validator
+	for i := 0; i < count; i++ {
+		validators = append(validators, &types.Validator{
+			Index:     beaconcommon.ValidatorIndex(i),
+			Status:    "active_ongoing",
+			Balance:   balance,
+			Validator: &beaconphase0.Validator{Pubkey: beaconcommon.BLSPubkey{byte(i), 0x1}},
+		})
+	}
+	validators[0].Status = "pending_queued"
+	return validators
+}
+
+func TestBeaconNode(t *testing.T) {
+	node := NewBeaconNode()
+	defer node.Close()
+
+	node.SetGenesis(&types.Genesis{GenesisTime: 1606824023})
+
+	var (
+		roots      []beaconcommon.Root
+		parentRoot beaconcommon.Root
+	)
+	for _, slot := range []beaconcommon.Slot{0, 1, 2, 4} {
+		root, err := node.AddBlock(newTestBlock(slot, parentRoot))
+		require.NoError(t, err)
+		roots = append(roots, root)
+		parentRoot = root
+	}
+
+	node.SetValidators(0, newTestValidators(150, 32000000000))
+	node.SetValidators(2, newTestValidators(150, 32000000001))
+	node.SetFinality(&types.StateFinalityCheckpoints{
+		FinalizedCheckpoint: beaconcommon.Checkpoint{Epoch: 0, Root: roots[1]},
+	})
+
+	c, err := eth2http.NewClient((&eth2http.Config{Address: node.URL}).SetDefault())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("GetGenesis", func(t *testing.T) {
+		genesis, err := c.GetGenesis(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, beaconcommon.Timestamp(1606824023), genesis.GenesisTime)
+	})
+
+	t.Run("GetBlock", func(t *testing.T) {
+		block, err := c.GetBlock(ctx, "head")
+		require.NoError(t, err)
+		assert.Equal(t, beaconcommon.Slot(4), block.Slot())
+		assert.Equal(t, roots[2], block.ParentRoot())
+
+		block, err = c.GetBlock(ctx, types.BlockIDFromRoot(roots[1]))
+		require.NoError(t, err)
+		assert.Equal(t, beaconcommon.Slot(1), block.Slot())
+
+		// slot 3 is missed
+		_, err = c.GetBlock(ctx, "3")
+		require.Error(t, err)
+	})
+
+	t.Run("GetBlockRoot", func(t *testing.T) {
+		root, err := c.GetBlockRoot(ctx, "finalized")
+		require.NoError(t, err)
+		assert.Equal(t, roots[1], *root)
+	})
+
+	t.Run("GetBlockHeaders", func(t *testing.T) {
+		header, err := c.GetBlockHeader(ctx, "2")
+		require.NoError(t, err)
+		assert.Equal(t, roots[2], header.Root)
+		assert.Equal(t, roots[1], header.Header.Message.ParentRoot)
+
+		headers, err := c.GetBlockHeaders(ctx, nil, &roots[2])
+		require.NoError(t, err)
+		require.Len(t, headers, 1)
+		assert.Equal(t, roots[3], headers[0].Root)
+	})
+
+	t.Run("GetStateFinalityCheckpoints", func(t *testing.T) {
+		checkpoints, err := c.GetStateFinalityCheckpoints(ctx, "head")
+		require.NoError(t, err)
+		assert.Equal(t, roots[1], checkpoints.FinalizedCheckpoint.Root)
+	})
+
+	t.Run("GetValidators", func(t *testing.T) {
+		validators, err := c.GetValidators(ctx, "1", []types.ValidatorID{"0", "1"}, nil)
+		require.NoError(t, err)
+		require.Len(t, validators, 2)
+		assert.Equal(t, beaconcommon.Gwei(32000000000), validators[1].Balance)
+
+		validators, err = c.GetValidators(ctx, "head", nil, []string{"active"})
+		require.NoError(t, err)
+		assert.Len(t, validators, 149)
+
+		// above chunk size IDs are sent using POST
+		var ids []types.ValidatorID
+		for i := 0; i < 120; i++ {
+			ids = append(ids, types.ValidatorIDFromIndex(beaconcommon.ValidatorIndex(i)))
+		}
+		validators, err = c.GetValidators(ctx, "head", ids, nil)
+		require.NoError(t, err)
+		assert.Len(t, validators, 120)
+
+		validator, err := c.GetValidator(ctx, "head", types.ValidatorIDFromPubkey(beaconcommon.BLSPubkey{0x3, 0x1}))
+		require.NoError(t, err)
+		assert.Equal(t, beaconcommon.ValidatorIndex(3), validator.Index)
+	})
+
+	t.Run("GetValidatorBalances", func(t *testing.T) {
+		balances, err := c.GetValidatorBalances(ctx, "head", []types.ValidatorID{"5"})
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, beaconcommon.Gwei(32000000001), balances[0].Balance)
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		spec, err := c.GetSpec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, *configs.Mainnet, spec.Spec)
+
+		forks, err := c.GetForkSchedule(ctx)
+		require.NoError(t, err)
+		require.Len(t, forks, 6)
+		assert.Equal(t, configs.Mainnet.CAPELLA_FORK_VERSION, forks[3].CurrentVersion)
+		assert.Equal(t, configs.Mainnet.BELLATRIX_FORK_VERSION, forks[3].PreviousVersion)
+
+		depositContract, err := c.GetDepositContract(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), depositContract.ChainID)
+		assert.Equal(t, configs.Mainnet.DEPOSIT_CONTRACT_ADDRESS, depositContract.Address)
+	})
+
+	t.Run("GetSyncing", func(t *testing.T) {
+		syncing, err := c.GetSyncing(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, beaconcommon.Slot(4), syncing.HeadSlot)
+	})
+}
@@ -0,0 +1,327 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+)
+
+// Clock computes beacon chain slots and epochs from genesis time and chain specification
+//
+// Genesis and specification are loaded from the beacon node when the clock starts,
+// so the clock must be started before being used: methods computing slots and epochs
+// return ErrNotStarted until the clock is started. Use WaitStarted to wait for it.
+type Clock struct {
+	client client.Client
+
+	time TimeSource
+
+	genesisTime   time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch beaconcommon.Slot
+
+	logger logrus.FieldLogger
+
+	ready   chan struct{}
+	start   sync.Once
+	stopped chan struct{}
+	stop    sync.Once
+	tickers sync.WaitGroup
+}
+
+// ErrNotStarted is returned by methods computing slots and epochs before the clock started
+var ErrNotStarted = errors.New("clock: not started")
+
+// New creates a clock loading genesis and specification from cli
+func New(cli client.Client) *Clock {
+	return &Clock{
+		client:  cli,
+		time:    SystemTime,
+		logger:  logrus.StandardLogger().WithField("component", "eth.consensus.clock"),
+		ready:   make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (c *Clock) Logger() logrus.FieldLogger {
+	return c.logger
+}
+
+func (c *Clock) SetLogger(logger logrus.FieldLogger) {
+	c.logger = logger.WithField("component", "eth.consensus.clock")
+}
+
+// SetTimeSource sets the time source of the clock (defaults to SystemTime)
+//
+// It must be called before the clock starts
+func (c *Clock) SetTimeSource(ts TimeSource) {
+	c.time = ts
+}
+
+// Start loads genesis time and chain specification from the beacon node
+//
+// It can be called again if it failed, but fails once the clock has started
+func (c *Clock) Start(ctx context.Context) error {
+	genesis, err := c.client.GetGenesis(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load genesis: %w", err)
+	}
+
+	spec, err := c.client.GetSpec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	if spec.SECONDS_PER_SLOT == 0 || spec.SLOTS_PER_EPOCH == 0 {
+		return fmt.Errorf("invalid spec: SECONDS_PER_SLOT=%v SLOTS_PER_EPOCH=%v", spec.SECONDS_PER_SLOT, spec.SLOTS_PER_EPOCH)
+	}
+
+	started := false
+	c.start.Do(func() {
+		c.genesisTime = time.Unix(int64(genesis.GenesisTime), 0)
+		c.slotDuration = time.Duration(spec.SECONDS_PER_SLOT) * time.Second
+		c.slotsPerEpoch = spec.SLOTS_PER_EPOCH
+		close(c.ready)
+		started = true
+	})
+	if !started {
+		return fmt.Errorf("clock already started")
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"genesis_time":    c.genesisTime,
+		"slot_duration":   c.slotDuration,
+		"slots_per_epoch": c.slotsPerEpoch,
+	}).Infof("clock started")
+
+	return nil
+}
+
+// Stop stops all tickers and waits for them to exit
+func (c *Clock) Stop(ctx context.Context) error {
+	c.stop.Do(func() { close(c.stopped) })
+
+	done := make(chan struct{})
+	go func() {
+		c.tickers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitStarted blocks until the clock is started or ctx is done
+func (c *Clock) WaitStarted(ctx context.Context) error {
+	select {
+	case <-c.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// started indicates whether genesis and specification are loaded
+func (c *Clock) started() bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// GenesisTime returns the time of the chain genesis
+func (c *Clock) GenesisTime() (time.Time, error) {
+	if !c.started() {
+		return time.Time{}, ErrNotStarted
+	}
+	return c.genesisTime, nil
+}
+
+// CurrentSlot returns the current slot, or 0 before genesis
+func (c *Clock) CurrentSlot() (beaconcommon.Slot, error) {
+	return c.SlotAt(c.time.Now())
+}
+
+// CurrentEpoch returns the current epoch, or 0 before genesis
+func (c *Clock) CurrentEpoch() (beaconcommon.Epoch, error) {
+	slot, err := c.CurrentSlot()
+	if err != nil {
+		return 0, err
+	}
+	return c.epochOf(slot), nil
+}
+
+// SlotAt returns the slot at time t, or 0 if t is before genesis
+func (c *Clock) SlotAt(t time.Time) (beaconcommon.Slot, error) {
+	if !c.started() {
+		return 0, ErrNotStarted
+	}
+	if !t.After(c.genesisTime) {
+		return 0, nil
+	}
+	return beaconcommon.Slot(t.Sub(c.genesisTime) / c.slotDuration), nil
+}
+
+// SlotStartTime returns the time at which slot starts
+func (c *Clock) SlotStartTime(slot beaconcommon.Slot) (time.Time, error) {
+	if !c.started() {
+		return time.Time{}, ErrNotStarted
+	}
+	return c.slotStartTime(slot), nil
+}
+
+// EpochOf returns the epoch slot belongs to
+func (c *Clock) EpochOf(slot beaconcommon.Slot) (beaconcommon.Epoch, error) {
+	if !c.started() {
+		return 0, ErrNotStarted
+	}
+	return c.epochOf(slot), nil
+}
+
+// EpochStartSlot returns the first slot of epoch
+func (c *Clock) EpochStartSlot(epoch beaconcommon.Epoch) (beaconcommon.Slot, error) {
+	if !c.started() {
+		return 0, ErrNotStarted
+	}
+	return beaconcommon.Slot(epoch) * c.slotsPerEpoch, nil
+}
+
+func (c *Clock) slotStartTime(slot beaconcommon.Slot) time.Time {
+	return c.genesisTime.Add(time.Duration(slot) * c.slotDuration)
+}
+
+func (c *Clock) epochOf(slot beaconcommon.Slot) beaconcommon.Epoch {
+	return beaconcommon.Epoch(slot / c.slotsPerEpoch)
+}
+
+// Tick is emitted by a Ticker
+type Tick struct {
+	Slot  beaconcommon.Slot
+	Epoch beaconcommon.Epoch
+
+	// Time is the time the tick has been scheduled at (i.e. slot start time plus ticker offset)
+	Time time.Time
+}
+
+// Ticker emits a Tick at every slot or epoch boundary
+type Ticker struct {
+	// C receives ticks, it is closed once the ticker or the clock is stopped
+	C <-chan *Tick
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// Stop stops the ticker
+func (t *Ticker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+// NewSlotTicker returns a ticker emitting at the start of every slot plus offset
+//
+// Offset can be negative to tick before slots start. Only the latest tick is kept, a tick not received
+// before the next slot tick is due is dropped so a slow receiver never gets stale ticks.
+func (c *Clock) NewSlotTicker(offset time.Duration) *Ticker {
+	return c.newTicker(offset, 1)
+}
+
+// NewEpochTicker returns a ticker emitting at the start of every epoch plus offset
+//
+// Offset can be negative to tick before epochs start. Only the latest tick is kept, a tick not received
+// before the next epoch tick is due is dropped so a slow receiver never gets stale ticks.
+func (c *Clock) NewEpochTicker(offset time.Duration) *Ticker {
+	return c.newTicker(offset, 0)
+}
+
+// newTicker creates a ticker emitting every period slots, 0 meaning every epoch
+func (c *Clock) newTicker(offset time.Duration, period beaconcommon.Slot) *Ticker {
+	// only this goroutine sends so a send never blocks once the buffer is drained
+	ch := make(chan *Tick, 1)
+	t := &Ticker{
+		C:    ch,
+		stop: make(chan struct{}),
+	}
+
+	c.tickers.Add(1)
+	go func() {
+		defer c.tickers.Done()
+		defer func() {
+			drain(ch)
+			close(ch)
+		}()
+
+		// wait for genesis and spec to be loaded
+		select {
+		case <-c.ready:
+		case <-t.stop:
+			return
+		case <-c.stopped:
+			return
+		}
+
+		if period == 0 {
+			period = c.slotsPerEpoch
+		}
+
+		for {
+			now := c.time.Now()
+			slot := c.nextSlot(now, offset, period)
+			at := c.slotStartTime(slot).Add(offset)
+
+			select {
+			case <-c.time.After(at.Sub(now)):
+			case <-t.stop:
+				return
+			case <-c.stopped:
+				return
+			}
+
+			// woke up too late, the next tick is already due
+			if !c.time.Now().Before(c.slotStartTime(slot + period).Add(offset)) {
+				continue
+			}
+
+			// replace previous tick if it has not been received
+			drain(ch)
+			ch <- &Tick{Slot: slot, Epoch: c.epochOf(slot), Time: at}
+		}
+	}()
+
+	return t
+}
+
+// nextSlot returns the first slot multiple of period which start time plus offset is strictly after now
+func (c *Clock) nextSlot(now time.Time, offset time.Duration, period beaconcommon.Slot) beaconcommon.Slot {
+	elapsed := now.Add(-offset).Sub(c.genesisTime)
+	if elapsed < 0 {
+		return 0
+	}
+
+	slot := beaconcommon.Slot(elapsed/c.slotDuration) + 1
+	if rem := slot % period; rem != 0 {
+		slot += period - rem
+	}
+
+	return slot
+}
+
+// drain drops the tick pending in ch if any
+func drain(ch chan *Tick) {
+	select {
+	case <-ch:
+	default:
+	}
+}
@@ -0,0 +1,249 @@
+//go:build !integration
+// +build !integration
+
+package clock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// fakeTime is a TimeSource which time only moves forward on Advance
+type fakeTime struct {
+	mux     sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.now
+}
+
+func (f *fakeTime) After(d time.Duration) <-chan time.Time {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	w := &waiter{at: f.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- f.now
+		return w.ch
+	}
+	f.waiters = append(f.waiters, w)
+	return w.ch
+}
+
+func (f *fakeTime) Advance(d time.Duration) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.now = f.now.Add(d)
+	var pending []*waiter
+	for _, w := range f.waiters {
+		if w.at.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
+
+func (f *fakeTime) Waiters() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.waiters)
+}
+
+var genesisTime = time.Unix(1606824023, 0)
+
+func newTestClock(t *testing.T, ctrl *gomock.Controller, now time.Time) (*Clock, *fakeTime) {
+	mockCli := mock.NewMockClient(ctrl)
+	mockCli.EXPECT().GetGenesis(gomock.Any()).Return(&types.Genesis{GenesisTime: beaconcommon.Timestamp(genesisTime.Unix())}, nil)
+	mockCli.EXPECT().GetSpec(gomock.Any()).Return(&types.Spec{Spec: *configs.Mainnet}, nil)
+
+	ts := &fakeTime{now: now}
+	c := New(mockCli)
+	c.SetTimeSource(ts)
+	require.NoError(t, c.Start(context.Background()))
+
+	return c, ts
+}
+
+func TestClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, ts := newTestClock(t, ctrl, genesisTime.Add(-time.Minute))
+	defer c.Stop(context.Background()) //nolint:errcheck // test
+
+	slot, err := c.CurrentSlot()
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Slot(0), slot)
+	epoch, err := c.CurrentEpoch()
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Epoch(0), epoch)
+
+	ts.Advance(time.Minute + 12*33*time.Second + time.Second)
+	slot, err = c.CurrentSlot()
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Slot(33), slot)
+	epoch, err = c.CurrentEpoch()
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Epoch(1), epoch)
+
+	genesis, err := c.GenesisTime()
+	require.NoError(t, err)
+	assert.Equal(t, genesisTime, genesis)
+
+	for _, tt := range []struct {
+		slot     beaconcommon.Slot
+		expected time.Time
+	}{
+		{slot: 0, expected: genesisTime},
+		{slot: 33, expected: genesisTime.Add(12 * 33 * time.Second)},
+	} {
+		start, err := c.SlotStartTime(tt.slot)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, start)
+	}
+
+	for _, tt := range []struct {
+		slot     beaconcommon.Slot
+		expected beaconcommon.Epoch
+	}{
+		{slot: 31, expected: 0},
+		{slot: 32, expected: 1},
+	} {
+		epoch, err := c.EpochOf(tt.slot)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, epoch)
+	}
+
+	startSlot, err := c.EpochStartSlot(2)
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Slot(64), startSlot)
+}
+
+func TestTicker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 1s after slot 8 started
+	c, ts := newTestClock(t, ctrl, genesisTime.Add(8*12*time.Second+time.Second))
+
+	slotTicker := c.NewSlotTicker(4 * time.Second)
+	epochTicker := c.NewEpochTicker(-2 * time.Second)
+
+	waitTicker := func() {
+		require.Eventually(t, func() bool { return ts.Waiters() == 2 }, time.Second, time.Millisecond)
+	}
+
+	// slot 8 + 4s
+	waitTicker()
+	ts.Advance(3 * time.Second)
+	tick := <-slotTicker.C
+	assert.Equal(t, &Tick{Slot: 8, Epoch: 0, Time: genesisTime.Add(8*12*time.Second + 4*time.Second)}, tick)
+
+	// slot 9 + 4s
+	waitTicker()
+	ts.Advance(12 * time.Second)
+	tick = <-slotTicker.C
+	assert.Equal(t, beaconcommon.Slot(9), tick.Slot)
+
+	// slot 32 - 2s, slot ticker drops slot 10 which was due long ago
+	waitTicker()
+	ts.Advance(22*12*time.Second + 6*time.Second)
+	tick = <-epochTicker.C
+	assert.Equal(t, &Tick{Slot: 32, Epoch: 1, Time: genesisTime.Add(32*12*time.Second - 2*time.Second)}, tick)
+
+	waitTicker()
+	ts.Advance(12 * time.Second)
+	tick = <-slotTicker.C
+	assert.Equal(t, beaconcommon.Slot(32), tick.Slot)
+
+	// slow receiver, slot 33 tick is replaced by slot 34 tick
+	waitTicker()
+	ts.Advance(12 * time.Second)
+	waitTicker()
+	ts.Advance(12 * time.Second)
+	waitTicker()
+	tick = <-slotTicker.C
+	assert.Equal(t, beaconcommon.Slot(34), tick.Slot)
+
+	slotTicker.Stop()
+	_, ok := <-slotTicker.C
+	assert.False(t, ok)
+
+	require.NoError(t, c.Stop(context.Background()))
+	_, ok = <-epochTicker.C
+	assert.False(t, ok)
+}
+
+func TestClockStartTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c, _ := newTestClock(t, ctrl, genesisTime)
+	defer c.Stop(context.Background()) //nolint:errcheck // test
+
+	mockCli := c.client.(*mock.MockClient)
+	mockCli.EXPECT().GetGenesis(gomock.Any()).Return(&types.Genesis{GenesisTime: beaconcommon.Timestamp(genesisTime.Unix())}, nil)
+	mockCli.EXPECT().GetSpec(gomock.Any()).Return(&types.Spec{Spec: *configs.Mainnet}, nil)
+
+	assert.Error(t, c.Start(context.Background()))
+}
+
+func TestClockBeforeStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	mockCli.EXPECT().GetGenesis(gomock.Any()).Return(&types.Genesis{GenesisTime: beaconcommon.Timestamp(genesisTime.Unix())}, nil)
+	mockCli.EXPECT().GetSpec(gomock.Any()).Return(&types.Spec{Spec: *configs.Mainnet}, nil)
+
+	c := New(mockCli)
+	c.SetTimeSource(&fakeTime{now: genesisTime.Add(12*33*time.Second + time.Second)})
+
+	_, err := c.CurrentSlot()
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = c.GenesisTime()
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = c.SlotStartTime(1)
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = c.EpochOf(1)
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = c.EpochStartSlot(1)
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.WaitStarted(ctx), context.DeadlineExceeded)
+
+	started := make(chan error, 1)
+	go func() { started <- c.WaitStarted(context.Background()) }()
+
+	require.NoError(t, c.Start(context.Background()))
+	require.NoError(t, <-started)
+
+	slot, err := c.CurrentSlot()
+	require.NoError(t, err)
+	assert.Equal(t, beaconcommon.Slot(33), slot)
+}
@@ -0,0 +1,22 @@
+package clock
+
+import (
+	"time"
+)
+
+// TimeSource provides the current time and timers to a Clock
+//
+// It allows to control time in tests
+type TimeSource interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemTime is a TimeSource relying on system time
+var SystemTime TimeSource = systemTime{}
+
+type systemTime struct{}
+
+func (systemTime) Now() time.Time { return time.Now() }
+
+func (systemTime) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,337 @@
+package effectiveness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+type Config struct {
+	// Parallelism is the maximum number of concurrent calls to the beacon node
+	Parallelism int
+}
+
+const defaultParallelism = 8
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.Parallelism == 0 {
+		cfg.Parallelism = defaultParallelism
+	}
+
+	return cfg
+}
+
+// Analyzer computes validators attestation effectiveness from committees and blocks
+//
+// For an epoch, attestations are searched in blocks up to the end of the next epoch,
+// so epochs should be analyzed once the next epoch is over for results to be complete.
+// Since beacon blocks only include attestations which source matches the justified checkpoint,
+// the source of an included attestation is always correct.
+type Analyzer struct {
+	cfg *Config
+
+	client client.Client
+
+	specMux       sync.Mutex
+	slotsPerEpoch beaconcommon.Slot
+
+	logger logrus.FieldLogger
+}
+
+// NewAnalyzer creates an analyzer loading data from cli
+func NewAnalyzer(cfg *Config, cli client.Client) *Analyzer {
+	return &Analyzer{
+		cfg:    cfg,
+		client: cli,
+		logger: logrus.StandardLogger().WithField("component", "eth.consensus.effectiveness"),
+	}
+}
+
+func (a *Analyzer) Logger() logrus.FieldLogger {
+	return a.logger
+}
+
+func (a *Analyzer) SetLogger(logger logrus.FieldLogger) {
+	a.logger = logger.WithField("component", "eth.consensus.effectiveness")
+}
+
+// Analyze computes attestation effectiveness of validators for epochs in [fromEpoch, toEpoch]
+//
+// Set validators to restrict result to given validators (if empty all validators are returned).
+// Results are sorted by epoch and validator index.
+func (a *Analyzer) Analyze(ctx context.Context, fromEpoch, toEpoch beaconcommon.Epoch, validators []beaconcommon.ValidatorIndex) ([]*ValidatorEffectiveness, error) {
+	if toEpoch < fromEpoch {
+		return nil, fmt.Errorf("invalid epoch range [%v, %v]", fromEpoch, toEpoch)
+	}
+
+	slotsPerEpoch, err := a.loadSlotsPerEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// attestations of the last epoch can be included until the end of the following epoch
+	fromSlot := beaconcommon.Slot(fromEpoch) * slotsPerEpoch
+	toSlot := beaconcommon.Slot(toEpoch+2)*slotsPerEpoch - 1
+
+	chain, err := a.loadChain(ctx, slotsPerEpoch, fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter map[beaconcommon.ValidatorIndex]bool
+	if len(validators) > 0 {
+		filter = make(map[beaconcommon.ValidatorIndex]bool, len(validators))
+		for _, index := range validators {
+			filter[index] = true
+		}
+	}
+
+	var rv []*ValidatorEffectiveness
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		committees, err := a.client.GetCommittees(ctx, types.StateIDFromSlot(chain.epochStartSlot(epoch)), &epoch, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load committees of epoch %v: %w", epoch, err)
+		}
+
+		rv = append(rv, chain.analyzeEpoch(epoch, committees, filter, a.logger)...)
+	}
+
+	return rv, nil
+}
+
+func (a *Analyzer) loadSlotsPerEpoch(ctx context.Context) (beaconcommon.Slot, error) {
+	a.specMux.Lock()
+	defer a.specMux.Unlock()
+
+	if a.slotsPerEpoch == 0 {
+		spec, err := a.client.GetSpec(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load spec: %w", err)
+		}
+		if spec.SLOTS_PER_EPOCH == 0 {
+			return 0, fmt.Errorf("invalid spec: SLOTS_PER_EPOCH=0")
+		}
+		a.slotsPerEpoch = spec.SLOTS_PER_EPOCH
+	}
+
+	return a.slotsPerEpoch, nil
+}
+
+// block is the canonical block at a slot, it is nil if the slot has been missed
+type block struct {
+	root         beaconcommon.Root
+	attestations beaconphase0.Attestations
+}
+
+// chain holds canonical blocks over a range of slots
+type chain struct {
+	slotsPerEpoch beaconcommon.Slot
+
+	fromSlot, toSlot beaconcommon.Slot
+	blocks           []*block
+
+	// root of the last canonical block before fromSlot
+	parentRoot beaconcommon.Root
+}
+
+// loadChain loads canonical blocks in [fromSlot, toSlot] with bounded concurrency
+func (a *Analyzer) loadChain(ctx context.Context, slotsPerEpoch, fromSlot, toSlot beaconcommon.Slot) (*chain, error) {
+	c := &chain{
+		slotsPerEpoch: slotsPerEpoch,
+		fromSlot:      fromSlot,
+		toSlot:        toSlot,
+		blocks:        make([]*block, toSlot-fromSlot+1),
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(a.cfg.Parallelism)
+	for i := range c.blocks {
+		i := i
+		g.Go(func() (err error) {
+			c.blocks[i], err = a.loadBlock(gctx, fromSlot+beaconcommon.Slot(i))
+			return
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// if first slots are missed, head and target votes refer to a previous block
+	if c.blocks[0] == nil {
+		for slot := fromSlot; slot > 0; {
+			slot--
+			root, err := a.client.GetBlockRoot(ctx, types.BlockIDFromSlot(slot))
+			if client.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to load block root at slot %v: %w", slot, err)
+			}
+			c.parentRoot = *root
+			break
+		}
+	}
+
+	return c, nil
+}
+
+// loadBlock loads the canonical block at slot, it returns nil if slot has been missed
+func (a *Analyzer) loadBlock(ctx context.Context, slot beaconcommon.Slot) (*block, error) {
+	header, err := a.client.GetBlockHeader(ctx, types.BlockIDFromSlot(slot))
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block header at slot %v: %w", slot, err)
+	}
+
+	// some nodes return the last block before a missed slot
+	if header.Header.Message.Slot != slot {
+		return nil, nil
+	}
+
+	// attestations are loaded by root so they are consistent with the header in case of reorg
+	attestations, err := a.client.GetBlockAttestations(ctx, types.BlockIDFromRoot(header.Root))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attestations of block at slot %v: %w", slot, err)
+	}
+
+	return &block{root: header.Root, attestations: attestations}, nil
+}
+
+func (c *chain) epochStartSlot(epoch beaconcommon.Epoch) beaconcommon.Slot {
+	return beaconcommon.Slot(epoch) * c.slotsPerEpoch
+}
+
+func (c *chain) epochOf(slot beaconcommon.Slot) beaconcommon.Epoch {
+	return beaconcommon.Epoch(slot / c.slotsPerEpoch)
+}
+
+func (c *chain) block(slot beaconcommon.Slot) *block {
+	if slot < c.fromSlot || slot > c.toSlot {
+		return nil
+	}
+	return c.blocks[slot-c.fromSlot]
+}
+
+// headRoot returns the root of the canonical head at slot (i.e. the root of the last block proposed at or before slot)
+func (c *chain) headRoot(slot beaconcommon.Slot) beaconcommon.Root {
+	for ; slot >= c.fromSlot; slot-- {
+		if b := c.block(slot); b != nil {
+			return b.root
+		}
+		if slot == 0 {
+			break
+		}
+	}
+	return c.parentRoot
+}
+
+// nextBlockSlot returns the slot of the first block proposed after slot
+func (c *chain) nextBlockSlot(slot beaconcommon.Slot) (beaconcommon.Slot, bool) {
+	for next := slot + 1; next <= c.toSlot; next++ {
+		if c.block(next) != nil {
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+type committeeKey struct {
+	slot  beaconcommon.Slot
+	index beaconcommon.CommitteeIndex
+}
+
+func (c *chain) analyzeEpoch(
+	epoch beaconcommon.Epoch,
+	committees []*types.Committee,
+	filter map[beaconcommon.ValidatorIndex]bool,
+	logger logrus.FieldLogger,
+) []*ValidatorEffectiveness {
+	targetRoot := c.headRoot(c.epochStartSlot(epoch))
+
+	duties := make(map[beaconcommon.ValidatorIndex]*ValidatorEffectiveness)
+	committeesByKey := make(map[committeeKey]*types.Committee, len(committees))
+	for _, committee := range committees {
+		committeesByKey[committeeKey{committee.Slot, committee.Index}] = committee
+		for _, index := range committee.Validators {
+			if filter != nil && !filter[index] {
+				continue
+			}
+			duties[index] = &ValidatorEffectiveness{
+				Epoch:          epoch,
+				ValidatorIndex: index,
+				Slot:           committee.Slot,
+				CommitteeIndex: committee.Index,
+			}
+		}
+	}
+
+	// blocks are processed by increasing slot so first inclusion is the earliest
+	lastSlot := c.epochStartSlot(epoch+2) - 1
+	for slot := c.epochStartSlot(epoch) + 1; slot <= lastSlot; slot++ {
+		b := c.block(slot)
+		if b == nil {
+			continue
+		}
+
+		for _, att := range b.attestations {
+			if c.epochOf(att.Data.Slot) != epoch {
+				continue
+			}
+
+			committee, ok := committeesByKey[committeeKey{att.Data.Slot, att.Data.Index}]
+			if !ok {
+				continue
+			}
+
+			if att.AggregationBits.BitLen() != uint64(len(committee.Validators)) {
+				logger.WithFields(logrus.Fields{
+					"slot":            slot,
+					"committee.slot":  att.Data.Slot,
+					"committee.index": att.Data.Index,
+				}).Warnf("Attestation aggregation bits do not match committee size")
+				continue
+			}
+
+			for i, index := range committee.Validators {
+				e, ok := duties[index]
+				if !ok || e.Included || !att.AggregationBits.GetBit(uint64(i)) {
+					continue
+				}
+
+				e.Included = true
+				e.InclusionSlot = slot
+				e.InclusionDelay = uint64(slot - e.Slot)
+				e.CorrectSource = true
+				e.CorrectTarget = att.Data.Target.Epoch == epoch && att.Data.Target.Root == targetRoot
+				e.CorrectHead = att.Data.BeaconBlockRoot == c.headRoot(e.Slot)
+			}
+		}
+	}
+
+	rv := make([]*ValidatorEffectiveness, 0, len(duties))
+	for _, e := range duties {
+		if nextSlot, ok := c.nextBlockSlot(e.Slot); ok {
+			e.MinInclusionDelay = uint64(nextSlot - e.Slot)
+		}
+		if e.Included && e.InclusionDelay > 0 {
+			e.Effectiveness = float64(e.MinInclusionDelay) / float64(e.InclusionDelay)
+		}
+		rv = append(rv, e)
+	}
+
+	sort.Slice(rv, func(i, j int) bool { return rv[i].ValidatorIndex < rv[j].ValidatorIndex })
+
+	return rv
+}
@@ -0,0 +1,164 @@
+//go:build !integration
+// +build !integration
+
+package effectiveness
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/csv"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+func newAttestation(slot beaconcommon.Slot, bits byte, head, target beaconcommon.Root) beaconphase0.Attestation {
+	return beaconphase0.Attestation{
+		AggregationBits: beaconphase0.AttestationBits{bits},
+		Data: beaconphase0.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: head,
+			Target:          beaconcommon.Checkpoint{Epoch: beaconcommon.Epoch(slot / 4), Root: target},
+		},
+	}
+}
+
+func newHeader(slot beaconcommon.Slot, root beaconcommon.Root) *types.BeaconBlockHeader {
+	header := &types.BeaconBlockHeader{Root: root}
+	header.Header.Message.Slot = slot
+	return header
+}
+
+func TestAnalyzer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	spec := new(types.Spec)
+	spec.SLOTS_PER_EPOCH = 4
+	mockCli.EXPECT().GetSpec(gomock.Any()).Return(spec, nil)
+
+	epoch := beaconcommon.Epoch(1)
+	mockCli.EXPECT().GetCommittees(gomock.Any(), types.StateID("4"), &epoch, nil, nil).Return([]*types.Committee{
+		{Slot: 4, Index: 0, Validators: beaconcommon.CommitteeIndices{10, 11}},
+		{Slot: 5, Index: 0, Validators: beaconcommon.CommitteeIndices{12, 13}},
+		{Slot: 6, Index: 0, Validators: beaconcommon.CommitteeIndices{14}},
+		{Slot: 7, Index: 0, Validators: beaconcommon.CommitteeIndices{15}},
+	}, nil)
+
+	roots := map[beaconcommon.Slot]beaconcommon.Root{4: {0x4}, 6: {0x6}, 7: {0x7}, 8: {0x8}}
+	attestations := map[beaconcommon.Slot]beaconphase0.Attestations{
+		4: {},
+		6: {
+			// validator 10, on time given slot 5 is missed
+			newAttestation(4, 0b101, roots[4], roots[4]),
+			// validator 13, head at missed slot 5 is the block at slot 4
+			newAttestation(5, 0b110, roots[4], roots[4]),
+		},
+		7: {
+			// validator 10 is already included, validator 11 votes for a wrong head
+			newAttestation(4, 0b111, roots[6], roots[4]),
+			// attestation of previous epoch
+			newAttestation(3, 0b11, roots[4], roots[4]),
+		},
+		8: {
+			// validator 14 votes for a wrong target
+			newAttestation(6, 0b11, roots[6], roots[6]),
+			// aggregation bits do not match committee size
+			newAttestation(7, 0b111, roots[7], roots[4]),
+		},
+	}
+
+	for slot := beaconcommon.Slot(4); slot <= 11; slot++ {
+		root, ok := roots[slot]
+		switch {
+		case slot == 5:
+			// some nodes return the last block before a missed slot
+			mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockIDFromSlot(slot)).Return(newHeader(4, roots[4]), nil)
+		case !ok:
+			mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockIDFromSlot(slot)).Return(nil, &types.Error{Code: 404, Message: "not found"})
+		default:
+			mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockIDFromSlot(slot)).Return(newHeader(slot, root), nil)
+			mockCli.EXPECT().GetBlockAttestations(gomock.Any(), types.BlockIDFromRoot(root)).Return(attestations[slot], nil)
+		}
+	}
+
+	a := NewAnalyzer((&Config{}).SetDefault(), mockCli)
+	rv, err := a.Analyze(context.Background(), 1, 1, nil)
+	require.NoError(t, err)
+
+	expected := []*ValidatorEffectiveness{
+		{Epoch: 1, ValidatorIndex: 10, Slot: 4, Included: true, InclusionSlot: 6, InclusionDelay: 2, MinInclusionDelay: 2, CorrectSource: true, CorrectTarget: true, CorrectHead: true, Effectiveness: 1},
+		{Epoch: 1, ValidatorIndex: 11, Slot: 4, Included: true, InclusionSlot: 7, InclusionDelay: 3, MinInclusionDelay: 2, CorrectSource: true, CorrectTarget: true, Effectiveness: 2. / 3},
+		{Epoch: 1, ValidatorIndex: 12, Slot: 5, MinInclusionDelay: 1},
+		{Epoch: 1, ValidatorIndex: 13, Slot: 5, Included: true, InclusionSlot: 6, InclusionDelay: 1, MinInclusionDelay: 1, CorrectSource: true, CorrectTarget: true, CorrectHead: true, Effectiveness: 1},
+		{Epoch: 1, ValidatorIndex: 14, Slot: 6, Included: true, InclusionSlot: 8, InclusionDelay: 2, MinInclusionDelay: 1, CorrectSource: true, CorrectHead: true, Effectiveness: 0.5},
+		{Epoch: 1, ValidatorIndex: 15, Slot: 7, MinInclusionDelay: 1},
+	}
+	assert.Equal(t, expected, rv)
+
+	t.Run("CSV", func(t *testing.T) {
+		store := csv.NewStore(filepath.Join(t.TempDir(), "effectiveness.csv"))
+
+		var values []interface{}
+		for _, e := range rv {
+			values = append(values, e)
+		}
+		require.NoError(t, store.WriteAllStructs(values))
+
+		var read []*ValidatorEffectiveness
+		require.NoError(t, store.ReadAllStructs(&read))
+		assert.Equal(t, rv, read)
+	})
+}
+
+func TestAnalyzerFilterAndMissedFirstSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+
+	spec := new(types.Spec)
+	spec.SLOTS_PER_EPOCH = 4
+	mockCli.EXPECT().GetSpec(gomock.Any()).Return(spec, nil)
+
+	epoch := beaconcommon.Epoch(1)
+	mockCli.EXPECT().GetCommittees(gomock.Any(), types.StateID("4"), &epoch, nil, nil).Return([]*types.Committee{
+		{Slot: 4, Index: 0, Validators: beaconcommon.CommitteeIndices{10, 11}},
+	}, nil)
+
+	// slot 4 is missed, so target and head at slot 4 are the block at slot 2
+	parentRoot := beaconcommon.Root{0x2}
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID("3")).Return(nil, &types.Error{Code: 404})
+	mockCli.EXPECT().GetBlockRoot(gomock.Any(), types.BlockID("2")).Return(&parentRoot, nil)
+
+	root := beaconcommon.Root{0x5}
+	for slot := beaconcommon.Slot(4); slot <= 11; slot++ {
+		if slot != 5 {
+			mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockIDFromSlot(slot)).Return(nil, &types.Error{Code: 404})
+		}
+	}
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), types.BlockID("5")).Return(newHeader(5, root), nil)
+	mockCli.EXPECT().GetBlockAttestations(gomock.Any(), types.BlockIDFromRoot(root)).Return(beaconphase0.Attestations{
+		newAttestation(4, 0b111, parentRoot, parentRoot),
+	}, nil)
+
+	a := NewAnalyzer((&Config{}).SetDefault(), mockCli)
+	rv, err := a.Analyze(context.Background(), 1, 1, []beaconcommon.ValidatorIndex{11})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*ValidatorEffectiveness{
+			{Epoch: 1, ValidatorIndex: 11, Slot: 4, Included: true, InclusionSlot: 5, InclusionDelay: 1, MinInclusionDelay: 1, CorrectSource: true, CorrectTarget: true, CorrectHead: true, Effectiveness: 1},
+		},
+		rv,
+	)
+}
@@ -0,0 +1,104 @@
+package effectiveness
+
+import (
+	"fmt"
+	"strconv"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// ValidatorEffectiveness is the attestation performance of a validator for an epoch
+type ValidatorEffectiveness struct {
+	Epoch          beaconcommon.Epoch
+	ValidatorIndex beaconcommon.ValidatorIndex
+
+	// Slot and CommitteeIndex of the validator attestation duty
+	Slot           beaconcommon.Slot
+	CommitteeIndex beaconcommon.CommitteeIndex
+
+	// Included indicates whether an attestation of the validator has been included on chain
+	Included bool
+
+	// InclusionSlot is the slot of the block that first included an attestation of the validator
+	InclusionSlot beaconcommon.Slot
+
+	// InclusionDelay is the number of slots between the duty slot and the inclusion slot
+	InclusionDelay uint64
+
+	// MinInclusionDelay is the number of slots between the duty slot and the first block proposed after it
+	//
+	// It is the best inclusion delay the validator could get given missed slots
+	MinInclusionDelay uint64
+
+	// CorrectSource, CorrectTarget and CorrectHead indicate whether the included attestation voted for
+	// canonical source checkpoint, target checkpoint and head block
+	CorrectSource bool
+	CorrectTarget bool
+	CorrectHead   bool
+
+	// Effectiveness is the ratio MinInclusionDelay / InclusionDelay, 0 if no attestation has been included
+	Effectiveness float64
+}
+
+func (e *ValidatorEffectiveness) MarshalCSV() ([]string, error) {
+	return []string{
+		e.Epoch.String(),
+		e.ValidatorIndex.String(),
+		e.Slot.String(),
+		strconv.FormatUint(uint64(e.CommitteeIndex), 10),
+		strconv.FormatBool(e.Included),
+		e.InclusionSlot.String(),
+		strconv.FormatUint(e.InclusionDelay, 10),
+		strconv.FormatUint(e.MinInclusionDelay, 10),
+		strconv.FormatBool(e.CorrectSource),
+		strconv.FormatBool(e.CorrectTarget),
+		strconv.FormatBool(e.CorrectHead),
+		strconv.FormatFloat(e.Effectiveness, 'f', -1, 64),
+	}, nil
+}
+
+func (e *ValidatorEffectiveness) UnmarshalCSV(record []string) error {
+	if len(record) != 12 {
+		return fmt.Errorf("invalid csv record with %d fields (%d fields expected)", len(record), 12)
+	}
+
+	uints := make([]uint64, 0, 7)
+	for _, i := range []int{0, 1, 2, 3, 5, 6, 7} {
+		v, err := strconv.ParseUint(record[i], 10, 64)
+		if err != nil {
+			return err
+		}
+		uints = append(uints, v)
+	}
+
+	bools := make([]bool, 0, 4)
+	for _, i := range []int{4, 8, 9, 10} {
+		v, err := strconv.ParseBool(record[i])
+		if err != nil {
+			return err
+		}
+		bools = append(bools, v)
+	}
+
+	effectiveness, err := strconv.ParseFloat(record[11], 64)
+	if err != nil {
+		return err
+	}
+
+	*e = ValidatorEffectiveness{
+		Epoch:             beaconcommon.Epoch(uints[0]),
+		ValidatorIndex:    beaconcommon.ValidatorIndex(uints[1]),
+		Slot:              beaconcommon.Slot(uints[2]),
+		CommitteeIndex:    beaconcommon.CommitteeIndex(uints[3]),
+		Included:          bools[0],
+		InclusionSlot:     beaconcommon.Slot(uints[4]),
+		InclusionDelay:    uints[5],
+		MinInclusionDelay: uints[6],
+		CorrectSource:     bools[1],
+		CorrectTarget:     bools[2],
+		CorrectHead:       bools[3],
+		Effectiveness:     effectiveness,
+	}
+
+	return nil
+}
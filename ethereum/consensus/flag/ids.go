@@ -0,0 +1,82 @@
+package flag
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// stateIDValue is a type implementing pflag.Value interface for types.StateID type
+type stateIDValue struct {
+	id *types.StateID
+}
+
+func (v *stateIDValue) Set(s string) error { return v.id.UnmarshalText([]byte(s)) }
+func (v *stateIDValue) Type() string       { return "state-id" }
+func (v *stateIDValue) String() string     { return v.id.String() }
+
+// StateIDVar registers a types.StateID custom flag with specified name, default value, and usage string.
+// The argument p points to a types.StateID variable in which to store the value of the flag
+func StateIDVar(f *pflag.FlagSet, p *types.StateID, name string, value types.StateID, usage string) {
+	v := &stateIDValue{p}
+	*v.id = value
+	f.Var(v, name, usage)
+}
+
+// StateIDVarP registers a types.StateID custom flag with specified name and shorthand, default value, and usage string.
+// The argument p points to a types.StateID variable in which to store the value of the flag
+func StateIDVarP(f *pflag.FlagSet, p *types.StateID, name, shorthand string, value types.StateID, usage string) {
+	v := &stateIDValue{p}
+	*v.id = value
+	f.VarP(v, name, shorthand, usage)
+}
+
+// blockIDValue is a type implementing pflag.Value interface for types.BlockID type
+type blockIDValue struct {
+	id *types.BlockID
+}
+
+func (v *blockIDValue) Set(s string) error { return v.id.UnmarshalText([]byte(s)) }
+func (v *blockIDValue) Type() string       { return "block-id" }
+func (v *blockIDValue) String() string     { return v.id.String() }
+
+// BlockIDVar registers a types.BlockID custom flag with specified name, default value, and usage string.
+// The argument p points to a types.BlockID variable in which to store the value of the flag
+func BlockIDVar(f *pflag.FlagSet, p *types.BlockID, name string, value types.BlockID, usage string) {
+	v := &blockIDValue{p}
+	*v.id = value
+	f.Var(v, name, usage)
+}
+
+// BlockIDVarP registers a types.BlockID custom flag with specified name and shorthand, default value, and usage string.
+// The argument p points to a types.BlockID variable in which to store the value of the flag
+func BlockIDVarP(f *pflag.FlagSet, p *types.BlockID, name, shorthand string, value types.BlockID, usage string) {
+	v := &blockIDValue{p}
+	*v.id = value
+	f.VarP(v, name, shorthand, usage)
+}
+
+// validatorIDValue is a type implementing pflag.Value interface for types.ValidatorID type
+type validatorIDValue struct {
+	id *types.ValidatorID
+}
+
+func (v *validatorIDValue) Set(s string) error { return v.id.UnmarshalText([]byte(s)) }
+func (v *validatorIDValue) Type() string       { return "validator-id" }
+func (v *validatorIDValue) String() string     { return v.id.String() }
+
+// ValidatorIDVar registers a types.ValidatorID custom flag with specified name, default value, and usage string.
+// The argument p points to a types.ValidatorID variable in which to store the value of the flag
+func ValidatorIDVar(f *pflag.FlagSet, p *types.ValidatorID, name string, value types.ValidatorID, usage string) {
+	v := &validatorIDValue{p}
+	*v.id = value
+	f.Var(v, name, usage)
+}
+
+// ValidatorIDVarP registers a types.ValidatorID custom flag with specified name and shorthand, default value, and usage string.
+// The argument p points to a types.ValidatorID variable in which to store the value of the flag
+func ValidatorIDVarP(f *pflag.FlagSet, p *types.ValidatorID, name, shorthand string, value types.ValidatorID, usage string) {
+	v := &validatorIDValue{p}
+	*v.id = value
+	f.VarP(v, name, shorthand, usage)
+}
@@ -0,0 +1,38 @@
+//go:build !integration
+// +build !integration
+
+package flag
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+func TestIDs(t *testing.T) {
+	var (
+		stateID     types.StateID
+		blockID     types.BlockID
+		validatorID types.ValidatorID
+	)
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	StateIDVarP(f, &stateID, "state", "s", types.StateIDHead, "test")
+	BlockIDVar(f, &blockID, "block", types.BlockIDHead, "test")
+	ValidatorIDVar(f, &validatorID, "validator", "", "test")
+
+	assert.Equal(t, types.StateIDHead, stateID)
+
+	err := f.Parse([]string{"--state=finalized", "--block=12", "--validator=42"})
+	require.NoError(t, err)
+	assert.Equal(t, types.StateIDFinalized, stateID)
+	assert.Equal(t, types.BlockIDFromSlot(12), blockID)
+	assert.Equal(t, types.ValidatorIDFromIndex(42), validatorID)
+
+	require.Error(t, f.Parse([]string{"--state=latest"}))
+	require.Error(t, f.Parse([]string{"--block=justified"}))
+	require.Error(t, f.Parse([]string{"--validator=head"}))
+}
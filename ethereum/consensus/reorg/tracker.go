@@ -0,0 +1,409 @@
+package reorg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/sirupsen/logrus"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+type Config struct {
+	// WindowSize is the number of recent canonical headers kept to detect reorgs
+	WindowSize int
+
+	// PollInterval is the interval at which head is polled if the node does not support events
+	PollInterval *kilntypes.Duration
+}
+
+const (
+	defaultWindowSize   = 64
+	defaultPollInterval = 4 * time.Second
+)
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.WindowSize == 0 {
+		cfg.WindowSize = defaultWindowSize
+	}
+
+	if cfg.PollInterval == nil {
+		cfg.PollInterval = &kilntypes.Duration{Duration: defaultPollInterval}
+	}
+
+	return cfg
+}
+
+// Reorg is a change of canonical head that orphaned previously canonical blocks
+type Reorg struct {
+	// Depth is the number of orphaned blocks
+	Depth uint64
+
+	OldHead *types.BeaconBlockHeader
+	NewHead *types.BeaconBlockHeader
+
+	// CommonAncestor is the root of the last block common to the old and new chains
+	//
+	// It is zero if the reorg is deeper than the window, in which case all blocks
+	// of the window are reported as orphaned
+	CommonAncestor beaconcommon.Root
+
+	// Orphaned are headers of orphaned blocks by increasing slot
+	Orphaned []*types.BeaconBlockHeader
+}
+
+// Tracker follows the canonical head of a beacon node and detects reorgs
+//
+// It keeps a sliding window of recent canonical headers. Each time the head changes, the new head is
+// linked to the window by following parent roots, blocks of the window that are not ancestors of the new head
+// being orphaned. Head changes are received from the node event stream if the client supports it, otherwise
+// head is polled.
+type Tracker struct {
+	cfg *Config
+
+	client client.Client
+
+	onReorg func(*Reorg)
+
+	mux           sync.RWMutex
+	window        []*types.BeaconBlockHeader
+	safeRoot      beaconcommon.Root
+	finalizedRoot beaconcommon.Root
+
+	logger logrus.FieldLogger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewTracker creates a tracker following head of cli
+func NewTracker(cfg *Config, cli client.Client) *Tracker {
+	return &Tracker{
+		cfg:    cfg,
+		client: cli,
+		logger: logrus.StandardLogger().WithField("component", "eth.consensus.reorg"),
+		done:   make(chan struct{}),
+	}
+}
+
+func (t *Tracker) Logger() logrus.FieldLogger {
+	return t.logger
+}
+
+func (t *Tracker) SetLogger(logger logrus.FieldLogger) {
+	t.logger = logger.WithField("component", "eth.consensus.reorg")
+}
+
+// OnReorg sets a callback called on every reorg
+//
+// It must be set before the tracker starts. It is called from the tracker goroutine,
+// so head is not updated until it returns.
+func (t *Tracker) OnReorg(fn func(*Reorg)) {
+	t.onReorg = fn
+}
+
+// Start loads current head with its recent ancestors and starts following head
+func (t *Tracker) Start(ctx context.Context) error {
+	head, err := t.loadHead(ctx)
+	if err != nil {
+		return err
+	}
+
+	window := []*types.BeaconBlockHeader{head}
+	for len(window) < t.cfg.WindowSize && slotOf(window[0]) > 0 {
+		parent, err := t.loadHeader(ctx, parentOf(window[0]))
+		if err != nil {
+			return err
+		}
+		window = append([]*types.BeaconBlockHeader{parent}, window...)
+	}
+
+	t.mux.Lock()
+	t.window = window
+	t.mux.Unlock()
+
+	if err := t.loadFinality(ctx); err != nil {
+		return err
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"head.slot": slotOf(head),
+		"head.root": head.Root.String(),
+	}).Infof("reorg tracker started")
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	go func() {
+		defer close(t.done)
+		if cli, ok := t.client.(client.EventsClient); ok {
+			t.follow(runCtx, cli)
+		} else {
+			t.poll(runCtx)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops following head
+func (t *Tracker) Stop(ctx context.Context) error {
+	if t.cancel == nil {
+		return nil
+	}
+	t.cancel()
+
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Head returns the current canonical head
+func (t *Tracker) Head() *types.BeaconBlockHeader {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	if len(t.window) == 0 {
+		return nil
+	}
+	return t.window[len(t.window)-1]
+}
+
+// SafeRoot returns the root of the current justified checkpoint, which is not expected to be reorged
+func (t *Tracker) SafeRoot() beaconcommon.Root {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.safeRoot
+}
+
+// FinalizedRoot returns the root of the current finalized checkpoint, which can not be reorged
+func (t *Tracker) FinalizedRoot() beaconcommon.Root {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.finalizedRoot
+}
+
+// follow updates head on every head event
+func (t *Tracker) follow(ctx context.Context, cli client.EventsClient) {
+	events, err := cli.SubscribeEvents(ctx, []string{types.EventTopicHead})
+	if err != nil {
+		t.logger.WithError(err).Warnf("failed to subscribe to head events, falling back to polling")
+		t.poll(ctx)
+		return
+	}
+
+	for event := range events {
+		headEvent, ok := event.Data.(*types.HeadEvent)
+		if !ok {
+			continue
+		}
+
+		head, err := t.loadHeader(ctx, headEvent.Block)
+		if err != nil {
+			t.logger.WithError(err).Errorf("failed to load head")
+			continue
+		}
+
+		t.update(ctx, head)
+	}
+}
+
+// poll updates head at every poll interval
+func (t *Tracker) poll(ctx context.Context) {
+	ticker := time.NewTicker(t.cfg.PollInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		head, err := t.loadHead(ctx)
+		if err != nil {
+			t.logger.WithError(err).Errorf("failed to load head")
+			continue
+		}
+
+		t.update(ctx, head)
+	}
+}
+
+func (t *Tracker) update(ctx context.Context, head *types.BeaconBlockHeader) {
+	changed, err := t.processHead(ctx, head)
+	if err != nil {
+		t.logger.WithError(err).Errorf("failed to process head")
+		return
+	}
+
+	if changed {
+		if err := t.loadFinality(ctx); err != nil {
+			t.logger.WithError(err).Errorf("failed to load finality checkpoints")
+		}
+	}
+}
+
+// processHead links head to the window, emitting a reorg if blocks of the window are orphaned
+//
+// It returns whether head changed
+func (t *Tracker) processHead(ctx context.Context, head *types.BeaconBlockHeader) (bool, error) {
+	t.mux.RLock()
+	window := t.window
+	t.mux.RUnlock()
+
+	oldHead := window[len(window)-1]
+	if head.Root == oldHead.Root {
+		return false, nil
+	}
+
+	index := make(map[beaconcommon.Root]int, len(window))
+	for i, h := range window {
+		index[h.Root] = i
+	}
+
+	// head moved back to a block of the window
+	if i, ok := index[head.Root]; ok {
+		t.setWindow(window, i, nil)
+		return true, nil
+	}
+
+	// walk back from head until reaching a block of the window
+	branch := []*types.BeaconBlockHeader{head}
+	ancestor := -1
+	for {
+		cur := branch[len(branch)-1]
+		if i, ok := index[parentOf(cur)]; ok {
+			ancestor = i
+			break
+		}
+
+		// ancestor is older than the window, or chain reaches genesis
+		if slotOf(cur) <= slotOf(window[0]) || slotOf(cur) == 0 || len(branch) >= t.cfg.WindowSize {
+			break
+		}
+
+		parent, err := t.loadHeader(ctx, parentOf(cur))
+		if err != nil {
+			return false, err
+		}
+		branch = append(branch, parent)
+	}
+
+	if ancestor < 0 {
+		// head could not be linked to the window, which is the case if head moved
+		// further than the window size so it is checked whether old head is still canonical
+		header, err := t.client.GetBlockHeader(ctx, types.BlockIDFromRoot(oldHead.Root))
+		if err != nil && !client.IsNotFound(err) {
+			return false, fmt.Errorf("failed to load header %v: %w", oldHead.Root, err)
+		}
+
+		if err == nil && header.Canonical {
+			t.setWindow(nil, -1, branch)
+			return true, nil
+		}
+	}
+
+	t.setWindow(window, ancestor, branch)
+
+	return true, nil
+}
+
+// setWindow replaces blocks of window after ancestor by branch (given from newest to oldest)
+// and emits a reorg if blocks are orphaned
+//
+// ancestor is the index in window of the common ancestor, -1 if it is older than the window
+func (t *Tracker) setWindow(window []*types.BeaconBlockHeader, ancestor int, branch []*types.BeaconBlockHeader) {
+	newWindow := append([]*types.BeaconBlockHeader{}, window[:ancestor+1]...)
+	for i := len(branch) - 1; i >= 0; i-- {
+		newWindow = append(newWindow, branch[i])
+	}
+	if len(newWindow) > t.cfg.WindowSize {
+		newWindow = newWindow[len(newWindow)-t.cfg.WindowSize:]
+	}
+
+	t.mux.Lock()
+	t.window = newWindow
+	t.mux.Unlock()
+
+	orphaned := window[ancestor+1:]
+	if len(orphaned) == 0 {
+		return
+	}
+
+	oldHead, newHead := window[len(window)-1], newWindow[len(newWindow)-1]
+	reorg := &Reorg{
+		Depth:    uint64(len(orphaned)),
+		OldHead:  oldHead,
+		NewHead:  newHead,
+		Orphaned: orphaned,
+	}
+	if ancestor >= 0 {
+		reorg.CommonAncestor = window[ancestor].Root
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"depth":         reorg.Depth,
+		"old_head.slot": slotOf(oldHead),
+		"old_head.root": oldHead.Root.String(),
+		"new_head.slot": slotOf(newHead),
+		"new_head.root": newHead.Root.String(),
+	}).Warnf("chain reorg detected")
+
+	if t.onReorg != nil {
+		t.onReorg(reorg)
+	}
+}
+
+// loadHead loads the canonical head header
+func (t *Tracker) loadHead(ctx context.Context) (*types.BeaconBlockHeader, error) {
+	headers, err := t.client.GetBlockHeaders(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load head header: %w", err)
+	}
+
+	for _, header := range headers {
+		if header.Canonical {
+			return header, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no canonical head header")
+}
+
+func (t *Tracker) loadHeader(ctx context.Context, root beaconcommon.Root) (*types.BeaconBlockHeader, error) {
+	header, err := t.client.GetBlockHeader(ctx, types.BlockIDFromRoot(root))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load header %v: %w", root, err)
+	}
+	return header, nil
+}
+
+func (t *Tracker) loadFinality(ctx context.Context) error {
+	checkpoints, err := t.client.GetStateFinalityCheckpoints(ctx, types.StateIDHead)
+	if err != nil {
+		return fmt.Errorf("failed to load finality checkpoints: %w", err)
+	}
+
+	t.mux.Lock()
+	t.safeRoot = checkpoints.CurrentJustifiedCheckpoint.Root
+	t.finalizedRoot = checkpoints.FinalizedCheckpoint.Root
+	t.mux.Unlock()
+
+	return nil
+}
+
+func slotOf(h *types.BeaconBlockHeader) beaconcommon.Slot {
+	return h.Header.Message.Slot
+}
+
+func parentOf(h *types.BeaconBlockHeader) beaconcommon.Root {
+	return h.Header.Message.ParentRoot
+}
@@ -0,0 +1,334 @@
+//go:build !integration
+// +build !integration
+
+package reorg
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/ethereum/consensus/client/mock"
+	"github.com/kilnfi/go-utils/ethereum/consensus/types"
+)
+
+// fakeChain serves headers of a block tree to a mock client
+type fakeChain struct {
+	mux     sync.Mutex
+	headers map[beaconcommon.Root]*types.BeaconBlockHeader
+	head    *types.BeaconBlockHeader
+}
+
+func newFakeChain(mockCli *mock.MockClient) *fakeChain {
+	c := &fakeChain{headers: make(map[beaconcommon.Root]*types.BeaconBlockHeader)}
+
+	mockCli.EXPECT().GetBlockHeader(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, blockID types.BlockID) (*types.BeaconBlockHeader, error) {
+			c.mux.Lock()
+			defer c.mux.Unlock()
+			for root, header := range c.headers {
+				if types.BlockIDFromRoot(root) == blockID {
+					return header, nil
+				}
+			}
+			return nil, &types.Error{Code: 404, Message: "not found"}
+		},
+	).AnyTimes()
+
+	mockCli.EXPECT().GetBlockHeaders(gomock.Any(), nil, nil).DoAndReturn(
+		func(context.Context, *beaconcommon.Slot, *beaconcommon.Root) ([]*types.BeaconBlockHeader, error) {
+			c.mux.Lock()
+			defer c.mux.Unlock()
+			return []*types.BeaconBlockHeader{c.head}, nil
+		},
+	).AnyTimes()
+
+	mockCli.EXPECT().GetStateFinalityCheckpoints(gomock.Any(), types.StateID("head")).Return(&types.StateFinalityCheckpoints{
+		CurrentJustifiedCheckpoint: beaconcommon.Checkpoint{Epoch: 2, Root: beaconcommon.Root{0xaa}},
+		FinalizedCheckpoint:        beaconcommon.Checkpoint{Epoch: 1, Root: beaconcommon.Root{0xbb}},
+	}, nil).AnyTimes()
+
+	return c
+}
+
+// add adds a canonical block, parent being nil for genesis
+func (c *fakeChain) add(name byte, slot beaconcommon.Slot, parent *types.BeaconBlockHeader) *types.BeaconBlockHeader {
+	header := &types.BeaconBlockHeader{Root: beaconcommon.Root{name}, Canonical: true}
+	header.Header.Message.Slot = slot
+	if parent != nil {
+		header.Header.Message.ParentRoot = parent.Root
+	}
+
+	c.mux.Lock()
+	c.headers[header.Root] = header
+	c.mux.Unlock()
+
+	return header
+}
+
+func (c *fakeChain) setHead(head *types.BeaconBlockHeader) {
+	c.mux.Lock()
+	c.head = head
+	c.mux.Unlock()
+}
+
+func (c *fakeChain) orphan(headers ...*types.BeaconBlockHeader) {
+	c.mux.Lock()
+	for _, header := range headers {
+		header.Canonical = false
+	}
+	c.mux.Unlock()
+}
+
+func newTestConfig(windowSize int) *Config {
+	return (&Config{
+		WindowSize:   windowSize,
+		PollInterval: &kilntypes.Duration{Duration: time.Hour},
+	}).SetDefault()
+}
+
+func roots(headers []*types.BeaconBlockHeader) []beaconcommon.Root {
+	var rv []beaconcommon.Root
+	for _, h := range headers {
+		rv = append(rv, h.Root)
+	}
+	return rv
+}
+
+func TestTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	chain := newFakeChain(mockCli)
+
+	g := chain.add('g', 0, nil)
+	a := chain.add('a', 1, g)
+	b := chain.add('b', 2, a)
+	c := chain.add('c', 3, b)
+	chain.setHead(c)
+
+	tracker := NewTracker(newTestConfig(8), mockCli)
+
+	var reorgs []*Reorg
+	tracker.OnReorg(func(r *Reorg) { reorgs = append(reorgs, r) })
+
+	require.NoError(t, tracker.Start(context.Background()))
+	defer func() { require.NoError(t, tracker.Stop(context.Background())) }()
+
+	assert.Equal(t, []beaconcommon.Root{g.Root, a.Root, b.Root, c.Root}, roots(tracker.window))
+	assert.Equal(t, c, tracker.Head())
+	assert.Equal(t, beaconcommon.Root{0xaa}, tracker.SafeRoot())
+	assert.Equal(t, beaconcommon.Root{0xbb}, tracker.FinalizedRoot())
+
+	ctx := context.Background()
+
+	t.Run("SameHead", func(t *testing.T) {
+		changed, err := tracker.processHead(ctx, c)
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	d := chain.add('d', 4, c)
+	t.Run("NextBlock", func(t *testing.T) {
+		changed, err := tracker.processHead(ctx, d)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, d, tracker.Head())
+		assert.Empty(t, reorgs)
+	})
+
+	e := chain.add('e', 4, b)
+	chain.orphan(c, d)
+	t.Run("Reorg", func(t *testing.T) {
+		_, err := tracker.processHead(ctx, e)
+		require.NoError(t, err)
+		require.Len(t, reorgs, 1)
+		assert.Equal(
+			t,
+			&Reorg{Depth: 2, OldHead: d, NewHead: e, CommonAncestor: b.Root, Orphaned: []*types.BeaconBlockHeader{c, d}},
+			reorgs[0],
+		)
+		assert.Equal(t, []beaconcommon.Root{g.Root, a.Root, b.Root, e.Root}, roots(tracker.window))
+	})
+
+	x := chain.add('x', 5, e)
+	f := chain.add('f', 6, x)
+	t.Run("MissedHeads", func(t *testing.T) {
+		_, err := tracker.processHead(ctx, f)
+		require.NoError(t, err)
+		assert.Len(t, reorgs, 1)
+		assert.Equal(t, []beaconcommon.Root{g.Root, a.Root, b.Root, e.Root, x.Root, f.Root}, roots(tracker.window))
+	})
+
+	t.Run("HeadMovedBack", func(t *testing.T) {
+		_, err := tracker.processHead(ctx, e)
+		require.NoError(t, err)
+		require.Len(t, reorgs, 2)
+		assert.Equal(
+			t,
+			&Reorg{Depth: 2, OldHead: f, NewHead: e, CommonAncestor: e.Root, Orphaned: []*types.BeaconBlockHeader{x, f}},
+			reorgs[1],
+		)
+	})
+
+	t.Run("WindowSlides", func(t *testing.T) {
+		parent := e
+		for slot := beaconcommon.Slot(5); slot < 12; slot++ {
+			parent = chain.add(byte(slot), slot, parent)
+			_, err := tracker.processHead(ctx, parent)
+			require.NoError(t, err)
+		}
+		assert.Len(t, tracker.window, 8)
+		assert.Equal(t, beaconcommon.Slot(4), slotOf(tracker.window[0]))
+		assert.Len(t, reorgs, 2)
+	})
+}
+
+func TestTrackerBeyondWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	chain := newFakeChain(mockCli)
+
+	g := chain.add('g', 0, nil)
+	a := chain.add('a', 1, g)
+	b := chain.add('b', 2, a)
+	c := chain.add('c', 3, b)
+	chain.setHead(c)
+
+	tracker := NewTracker(newTestConfig(2), mockCli)
+
+	var reorgs []*Reorg
+	tracker.OnReorg(func(r *Reorg) { reorgs = append(reorgs, r) })
+
+	require.NoError(t, tracker.Start(context.Background()))
+	defer func() { require.NoError(t, tracker.Stop(context.Background())) }()
+
+	assert.Equal(t, []beaconcommon.Root{b.Root, c.Root}, roots(tracker.window))
+
+	ctx := context.Background()
+
+	t.Run("HeadFarAhead", func(t *testing.T) {
+		d := chain.add('d', 4, c)
+		e := chain.add('e', 5, d)
+		f := chain.add('f', 6, e)
+
+		_, err := tracker.processHead(ctx, f)
+		require.NoError(t, err)
+		assert.Empty(t, reorgs)
+		assert.Equal(t, []beaconcommon.Root{e.Root, f.Root}, roots(tracker.window))
+
+		// rewind for next test
+		tracker.window = []*types.BeaconBlockHeader{b, c}
+	})
+
+	t.Run("DeepReorg", func(t *testing.T) {
+		z := chain.add('z', 2, a)
+		y := chain.add('y', 3, z)
+		chain.orphan(b, c)
+
+		_, err := tracker.processHead(ctx, y)
+		require.NoError(t, err)
+		require.Len(t, reorgs, 1)
+		assert.Equal(
+			t,
+			&Reorg{Depth: 2, OldHead: c, NewHead: y, Orphaned: []*types.BeaconBlockHeader{b, c}},
+			reorgs[0],
+		)
+		assert.Equal(t, []beaconcommon.Root{z.Root, y.Root}, roots(tracker.window))
+	})
+}
+
+func TestTrackerPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	chain := newFakeChain(mockCli)
+
+	g := chain.add('g', 0, nil)
+	a := chain.add('a', 1, g)
+	chain.setHead(a)
+
+	cfg := newTestConfig(8)
+	cfg.PollInterval.Duration = time.Millisecond
+	tracker := NewTracker(cfg, mockCli)
+
+	reorgs := make(chan *Reorg, 1)
+	tracker.OnReorg(func(r *Reorg) { reorgs <- r })
+
+	require.NoError(t, tracker.Start(context.Background()))
+
+	b := chain.add('b', 1, g)
+	chain.orphan(a)
+	chain.setHead(b)
+
+	select {
+	case r := <-reorgs:
+		assert.Equal(t, a, r.OldHead)
+		assert.Equal(t, b, r.NewHead)
+	case <-time.After(time.Second):
+		t.Fatalf("no reorg detected")
+	}
+
+	require.NoError(t, tracker.Stop(context.Background()))
+}
+
+type eventsClient struct {
+	*mock.MockClient
+	*mock.MockEventsClient
+}
+
+func TestTrackerEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := mock.NewMockClient(ctrl)
+	mockEventsCli := mock.NewMockEventsClient(ctrl)
+	chain := newFakeChain(mockCli)
+
+	g := chain.add('g', 0, nil)
+	a := chain.add('a', 1, g)
+	chain.setHead(a)
+
+	events := make(chan *types.Event)
+	mockEventsCli.EXPECT().SubscribeEvents(gomock.Any(), []string{types.EventTopicHead}).DoAndReturn(
+		func(ctx context.Context, _ []string) (<-chan *types.Event, error) {
+			go func() {
+				<-ctx.Done()
+				close(events)
+			}()
+			return events, nil
+		},
+	)
+
+	tracker := NewTracker(newTestConfig(8), &eventsClient{mockCli, mockEventsCli})
+
+	reorgs := make(chan *Reorg, 1)
+	tracker.OnReorg(func(r *Reorg) { reorgs <- r })
+
+	require.NoError(t, tracker.Start(context.Background()))
+
+	b := chain.add('b', 1, g)
+	chain.orphan(a)
+	events <- &types.Event{Topic: types.EventTopicHead, Data: &types.HeadEvent{Slot: 1, Block: b.Root}}
+
+	select {
+	case r := <-reorgs:
+		assert.Equal(t, uint64(1), r.Depth)
+		assert.Equal(t, g.Root, r.CommonAncestor)
+	case <-time.After(time.Second):
+		t.Fatalf("no reorg detected")
+	}
+
+	require.NoError(t, tracker.Stop(context.Background()))
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"fmt"
+
+	gethhexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/tree"
+	"github.com/protolambda/ztyp/view"
+)
+
+// KZGProofSize is the size in bytes of a KZG proof
+const KZGProofSize = 48
+
+// KZGProof is a KZG proof of a blob against its commitment
+type KZGProof [KZGProofSize]byte
+
+func (p KZGProof) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p KZGProof) String() string {
+	return gethhexutil.Encode(p[:])
+}
+
+func (p *KZGProof) UnmarshalText(text []byte) error {
+	return gethhexutil.UnmarshalFixedText("KZGProof", text, p[:])
+}
+
+// BlobSidecar is a blob with its KZG commitment and proof as propagated since Deneb
+type BlobSidecar struct {
+	Index                       view.Uint64View                      `json:"index"`
+	Blob                        gethhexutil.Bytes                    `json:"blob"`
+	KZGCommitment               beaconcommon.KZGCommitment           `json:"kzg_commitment"`
+	KZGProof                    KZGProof                             `json:"kzg_proof"`
+	SignedBlockHeader           beaconcommon.SignedBeaconBlockHeader `json:"signed_block_header"`
+	KZGCommitmentInclusionProof []beaconcommon.Root                  `json:"kzg_commitment_inclusion_proof"`
+}
+
+// BlobKZGCommitments returns KZG commitments of the blobs the block commits to (nil for pre-Deneb blocks)
+func (b *SignedBeaconBlock) BlobKZGCommitments() []beaconcommon.KZGCommitment {
+	if b.Deneb != nil {
+		return b.Deneb.Message.Body.BlobKZGCommitments
+	}
+	return nil
+}
+
+// VerifyBlobSidecarCommitments checks sidecars are consistent with the block they have been retrieved for
+// using the given indices filter (nil meaning all sidecars of the block were requested)
+//
+// It returns an error if a sidecar header hash tree root differs from the block's one (spec is used to
+// compute the block body root), if a sidecar index is duplicated, out of the block's blob_kzg_commitments range
+// or not requested, or if a sidecar KZG commitment differs from the commitment at the same index in the block.
+// It also returns an error if a sidecar is missing, that is if a block commitment at a requested index
+// (every index if indices is empty) has no sidecar. It does not verify KZG proofs nor inclusion proofs.
+func VerifyBlobSidecarCommitments(spec *beaconcommon.Spec, block *SignedBeaconBlock, sidecars []*BlobSidecar, indices []uint64) error {
+	commitments := block.BlobKZGCommitments()
+
+	requested := make(map[view.Uint64View]bool, len(indices))
+	for _, index := range indices {
+		requested[view.Uint64View(index)] = true
+	}
+
+	hFn := tree.GetHashFn()
+	blockRoot := block.Header(spec).HashTreeRoot(hFn)
+	seen := make(map[view.Uint64View]bool, len(sidecars))
+	for _, sidecar := range sidecars {
+		if sidecar.SignedBlockHeader.Message.HashTreeRoot(hFn) != blockRoot {
+			return fmt.Errorf("blob sidecar %v: header does not match block %v at slot %v", sidecar.Index, blockRoot, block.Slot())
+		}
+
+		if seen[sidecar.Index] {
+			return fmt.Errorf("blob sidecar %v: duplicated index", sidecar.Index)
+		}
+		seen[sidecar.Index] = true
+
+		if len(requested) > 0 && !requested[sidecar.Index] {
+			return fmt.Errorf("blob sidecar %v: index not requested", sidecar.Index)
+		}
+
+		if uint64(sidecar.Index) >= uint64(len(commitments)) {
+			return fmt.Errorf("blob sidecar %v: index out of range (block has %v commitments)", sidecar.Index, len(commitments))
+		}
+
+		if sidecar.KZGCommitment != commitments[sidecar.Index] {
+			return fmt.Errorf(
+				"blob sidecar %v: KZG commitment %v does not match block commitment %v",
+				sidecar.Index, sidecar.KZGCommitment, commitments[sidecar.Index],
+			)
+		}
+	}
+
+	// sidecars are unique and in range so checking every expected index is present is enough
+	for index := range commitments {
+		if len(requested) > 0 && !requested[view.Uint64View(index)] {
+			continue
+		}
+
+		if !seen[view.Uint64View(index)] {
+			return fmt.Errorf("blob sidecar %v: missing (block has %v commitments)", index, len(commitments))
+		}
+	}
+
+	return nil
+}
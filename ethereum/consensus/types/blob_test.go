@@ -0,0 +1,135 @@
+//go:build !integration
+// +build !integration
+
+package types
+
+import (
+	"testing"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlobSidecarCommitments(t *testing.T) {
+	block := &SignedBeaconBlock{Version: ForkDeneb, Deneb: new(deneb.SignedBeaconBlock)}
+	block.Deneb.Message.Slot = 100
+	block.Deneb.Message.ProposerIndex = 7
+	block.Deneb.Message.ParentRoot = beaconcommon.Root{0x1}
+	block.Deneb.Message.StateRoot = beaconcommon.Root{0x2}
+	block.Deneb.Message.Body.BlobKZGCommitments = deneb.KZGCommitments{{0xa}, {0xb}}
+
+	spec := configs.Mainnet
+	newSidecar := func(index uint64, commitment beaconcommon.KZGCommitment) *BlobSidecar {
+		sidecar := &BlobSidecar{KZGCommitment: commitment}
+		sidecar.Index = view.Uint64View(index)
+		sidecar.SignedBlockHeader.Message = *block.Deneb.Message.Header(spec)
+		return sidecar
+	}
+
+	otherSlot := newSidecar(0, beaconcommon.KZGCommitment{0xa})
+	otherSlot.SignedBlockHeader.Message.Slot = 101
+
+	otherBody := newSidecar(0, beaconcommon.KZGCommitment{0xa})
+	otherBody.SignedBlockHeader.Message.BodyRoot = beaconcommon.Root{0x3}
+
+	tests := []struct {
+		desc     string
+		sidecars []*BlobSidecar
+		indices  []uint64
+		expected string
+	}{
+		{
+			desc:     "all sidecars",
+			sidecars: []*BlobSidecar{newSidecar(0, beaconcommon.KZGCommitment{0xa}), newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+		},
+		{
+			desc:     "requested subset of sidecars",
+			sidecars: []*BlobSidecar{newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+			indices:  []uint64{1},
+		},
+		{
+			desc:     "requested index beyond commitments",
+			sidecars: []*BlobSidecar{newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+			indices:  []uint64{1, 5},
+		},
+		{
+			desc:     "partial sidecars",
+			sidecars: []*BlobSidecar{newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+			expected: "blob sidecar 0: missing (block has 2 commitments)",
+		},
+		{
+			desc:     "no sidecars",
+			expected: "blob sidecar 0: missing (block has 2 commitments)",
+		},
+		{
+			desc:     "missing requested sidecar",
+			sidecars: []*BlobSidecar{newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+			indices:  []uint64{0, 1},
+			expected: "blob sidecar 0: missing (block has 2 commitments)",
+		},
+		{
+			desc:     "sidecar not requested",
+			sidecars: []*BlobSidecar{newSidecar(0, beaconcommon.KZGCommitment{0xa}), newSidecar(1, beaconcommon.KZGCommitment{0xb})},
+			indices:  []uint64{1},
+			expected: "blob sidecar 0: index not requested",
+		},
+		{
+			desc:     "mismatching commitment",
+			sidecars: []*BlobSidecar{newSidecar(0, beaconcommon.KZGCommitment{0xb})},
+			expected: "blob sidecar 0: KZG commitment",
+		},
+		{
+			desc:     "index out of range",
+			sidecars: []*BlobSidecar{newSidecar(2, beaconcommon.KZGCommitment{0xa})},
+			expected: "blob sidecar 2: index out of range (block has 2 commitments)",
+		},
+		{
+			desc:     "duplicated index",
+			sidecars: []*BlobSidecar{newSidecar(0, beaconcommon.KZGCommitment{0xa}), newSidecar(0, beaconcommon.KZGCommitment{0xa})},
+			expected: "blob sidecar 0: duplicated index",
+		},
+		{
+			desc:     "header of another block",
+			sidecars: []*BlobSidecar{otherSlot},
+			expected: "blob sidecar 0: header does not match block",
+		},
+		{
+			desc:     "header with another body",
+			sidecars: []*BlobSidecar{otherBody},
+			expected: "blob sidecar 0: header does not match block",
+		},
+	}
+
+	t.Run("block without blobs", func(t *testing.T) {
+		noBlobs := &SignedBeaconBlock{Version: ForkDeneb, Deneb: new(deneb.SignedBeaconBlock)}
+		require.NoError(t, VerifyBlobSidecarCommitments(spec, noBlobs, nil, nil))
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := VerifyBlobSidecarCommitments(spec, block, tt.sidecars, tt.indices)
+			if tt.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestKZGProofText(t *testing.T) {
+	text := "0x" + "ab" + "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000cd"
+
+	var proof KZGProof
+	require.NoError(t, proof.UnmarshalText([]byte(text)))
+	assert.Equal(t, byte(0xab), proof[0])
+	assert.Equal(t, byte(0xcd), proof[KZGProofSize-1])
+	assert.Equal(t, text, proof.String())
+
+	require.Error(t, proof.UnmarshalText([]byte("0xab")))
+}
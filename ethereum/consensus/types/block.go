@@ -0,0 +1,296 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/codec"
+)
+
+// Consensus forks as identified by beacon nodes in "version" fields and Eth-Consensus-Version header
+const (
+	ForkPhase0    = "phase0"
+	ForkAltair    = "altair"
+	ForkBellatrix = "bellatrix"
+	ForkCapella   = "capella"
+	ForkDeneb     = "deneb"
+)
+
+// SignedBeaconBlock is a signed beacon block of any supported fork
+//
+// Only the field matching Version is set
+type SignedBeaconBlock struct {
+	Version string
+
+	Phase0    *beaconphase0.SignedBeaconBlock
+	Altair    *altair.SignedBeaconBlock
+	Bellatrix *bellatrix.SignedBeaconBlock
+	Capella   *capella.SignedBeaconBlock
+	Deneb     *deneb.SignedBeaconBlock
+}
+
+type signedBeaconBlockMsg struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// UnmarshalVersionedJSON decodes data into a block of the given fork version
+func (b *SignedBeaconBlock) UnmarshalVersionedJSON(version string, data []byte) error {
+	msg, err := b.reset(version)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, msg)
+}
+
+// UnmarshalVersionedSSZ decodes SSZ encoded block of the given fork version from r
+//
+// size is the length in bytes of the encoded block
+func (b *SignedBeaconBlock) UnmarshalVersionedSSZ(spec *beaconcommon.Spec, version string, r io.Reader, size uint64) error {
+	msg, err := b.reset(version)
+	if err != nil {
+		return err
+	}
+
+	return msg.Deserialize(spec, codec.NewDecodingReader(r, size))
+}
+
+// sszObject is implemented by zrnt types that depend on spec for SSZ decoding
+type sszObject interface {
+	Deserialize(spec *beaconcommon.Spec, dr *codec.DecodingReader) error
+}
+
+// reset sets b to an empty block of the given fork version
+func (b *SignedBeaconBlock) reset(version string) (sszObject, error) {
+	*b = SignedBeaconBlock{Version: version}
+
+	switch version {
+	case ForkPhase0:
+		b.Phase0 = new(beaconphase0.SignedBeaconBlock)
+		return b.Phase0, nil
+	case ForkAltair:
+		b.Altair = new(altair.SignedBeaconBlock)
+		return b.Altair, nil
+	case ForkBellatrix:
+		b.Bellatrix = new(bellatrix.SignedBeaconBlock)
+		return b.Bellatrix, nil
+	case ForkCapella:
+		b.Capella = new(capella.SignedBeaconBlock)
+		return b.Capella, nil
+	case ForkDeneb:
+		b.Deneb = new(deneb.SignedBeaconBlock)
+		return b.Deneb, nil
+	default:
+		return nil, fmt.Errorf("unsupported block version %q", version)
+	}
+}
+
+// UnmarshalJSON decodes a block from a {"version": ..., "data": ...} object
+func (b *SignedBeaconBlock) UnmarshalJSON(data []byte) error {
+	msg := new(signedBeaconBlockMsg)
+	if err := json.Unmarshal(data, msg); err != nil {
+		return err
+	}
+
+	return b.UnmarshalVersionedJSON(msg.Version, msg.Data)
+}
+
+// MarshalJSON encodes a block into a {"version": ..., "data": ...} object
+func (b *SignedBeaconBlock) MarshalJSON() ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case b.Phase0 != nil:
+		data, err = json.Marshal(b.Phase0)
+	case b.Altair != nil:
+		data, err = json.Marshal(b.Altair)
+	case b.Bellatrix != nil:
+		data, err = json.Marshal(b.Bellatrix)
+	case b.Capella != nil:
+		data, err = json.Marshal(b.Capella)
+	case b.Deneb != nil:
+		data, err = json.Marshal(b.Deneb)
+	default:
+		return nil, fmt.Errorf("empty block")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&signedBeaconBlockMsg{
+		Version: b.Version,
+		Data:    data,
+	})
+}
+
+// Slot returns the block slot
+func (b *SignedBeaconBlock) Slot() beaconcommon.Slot {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.Slot
+	case b.Altair != nil:
+		return b.Altair.Message.Slot
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.Slot
+	case b.Capella != nil:
+		return b.Capella.Message.Slot
+	case b.Deneb != nil:
+		return b.Deneb.Message.Slot
+	}
+	return 0
+}
+
+// ProposerIndex returns the index of the validator that proposed the block
+func (b *SignedBeaconBlock) ProposerIndex() beaconcommon.ValidatorIndex {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.ProposerIndex
+	case b.Altair != nil:
+		return b.Altair.Message.ProposerIndex
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.ProposerIndex
+	case b.Capella != nil:
+		return b.Capella.Message.ProposerIndex
+	case b.Deneb != nil:
+		return b.Deneb.Message.ProposerIndex
+	}
+	return 0
+}
+
+// ParentRoot returns the root of the parent block
+func (b *SignedBeaconBlock) ParentRoot() beaconcommon.Root {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.ParentRoot
+	case b.Altair != nil:
+		return b.Altair.Message.ParentRoot
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.ParentRoot
+	case b.Capella != nil:
+		return b.Capella.Message.ParentRoot
+	case b.Deneb != nil:
+		return b.Deneb.Message.ParentRoot
+	}
+	return beaconcommon.Root{}
+}
+
+// StateRoot returns the root of the post-state of the block
+func (b *SignedBeaconBlock) StateRoot() beaconcommon.Root {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.StateRoot
+	case b.Altair != nil:
+		return b.Altair.Message.StateRoot
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.StateRoot
+	case b.Capella != nil:
+		return b.Capella.Message.StateRoot
+	case b.Deneb != nil:
+		return b.Deneb.Message.StateRoot
+	}
+	return beaconcommon.Root{}
+}
+
+// Header returns the header of the block, which body root is computed using spec
+func (b *SignedBeaconBlock) Header(spec *beaconcommon.Spec) *beaconcommon.BeaconBlockHeader {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.Header(spec)
+	case b.Altair != nil:
+		return b.Altair.Message.Header(spec)
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.Header(spec)
+	case b.Capella != nil:
+		return b.Capella.Message.Header(spec)
+	case b.Deneb != nil:
+		return b.Deneb.Message.Header(spec)
+	}
+	return &beaconcommon.BeaconBlockHeader{}
+}
+
+// Attestations returns attestations included in the block
+func (b *SignedBeaconBlock) Attestations() beaconphase0.Attestations {
+	switch {
+	case b.Phase0 != nil:
+		return b.Phase0.Message.Body.Attestations
+	case b.Altair != nil:
+		return b.Altair.Message.Body.Attestations
+	case b.Bellatrix != nil:
+		return b.Bellatrix.Message.Body.Attestations
+	case b.Capella != nil:
+		return b.Capella.Message.Body.Attestations
+	case b.Deneb != nil:
+		return b.Deneb.Message.Body.Attestations
+	}
+	return nil
+}
+
+// ExecutionPayload returns the execution payload of the block (nil for pre-Bellatrix blocks)
+//
+// Payloads of Bellatrix and Capella blocks are converted into Deneb format, leaving fields
+// introduced by later forks empty
+func (b *SignedBeaconBlock) ExecutionPayload() *deneb.ExecutionPayload {
+	switch {
+	case b.Bellatrix != nil:
+		p := &b.Bellatrix.Message.Body.ExecutionPayload
+		return &deneb.ExecutionPayload{
+			ParentHash:    p.ParentHash,
+			FeeRecipient:  p.FeeRecipient,
+			StateRoot:     p.StateRoot,
+			ReceiptsRoot:  p.ReceiptsRoot,
+			LogsBloom:     p.LogsBloom,
+			PrevRandao:    p.PrevRandao,
+			BlockNumber:   p.BlockNumber,
+			GasLimit:      p.GasLimit,
+			GasUsed:       p.GasUsed,
+			Timestamp:     p.Timestamp,
+			ExtraData:     p.ExtraData,
+			BaseFeePerGas: p.BaseFeePerGas,
+			BlockHash:     p.BlockHash,
+			Transactions:  p.Transactions,
+		}
+	case b.Capella != nil:
+		p := &b.Capella.Message.Body.ExecutionPayload
+		return &deneb.ExecutionPayload{
+			ParentHash:    p.ParentHash,
+			FeeRecipient:  p.FeeRecipient,
+			StateRoot:     p.StateRoot,
+			ReceiptsRoot:  p.ReceiptsRoot,
+			LogsBloom:     p.LogsBloom,
+			PrevRandao:    p.PrevRandao,
+			BlockNumber:   p.BlockNumber,
+			GasLimit:      p.GasLimit,
+			GasUsed:       p.GasUsed,
+			Timestamp:     p.Timestamp,
+			ExtraData:     p.ExtraData,
+			BaseFeePerGas: p.BaseFeePerGas,
+			BlockHash:     p.BlockHash,
+			Transactions:  p.Transactions,
+			Withdrawals:   p.Withdrawals,
+		}
+	case b.Deneb != nil:
+		return &b.Deneb.Message.Body.ExecutionPayload
+	}
+	return nil
+}
+
+// Withdrawals returns withdrawals processed in the block (nil for pre-Capella blocks)
+func (b *SignedBeaconBlock) Withdrawals() beaconcommon.Withdrawals {
+	switch {
+	case b.Capella != nil:
+		return b.Capella.Message.Body.ExecutionPayload.Withdrawals
+	case b.Deneb != nil:
+		return b.Deneb.Message.Body.ExecutionPayload.Withdrawals
+	}
+	return nil
+}
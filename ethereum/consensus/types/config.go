@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// Spec is the chain specification as returned by /eth/v1/config/spec
+//
+// Values that beaconcommon.Spec does not know about (e.g. domain types, constants
+// or parameters of forks not yet supported by zrnt) are kept in Extra
+// using their raw string value, so they are not lost when decoding.
+type Spec struct {
+	beaconcommon.Spec
+
+	Extra map[string]string `json:"-" yaml:"-"`
+}
+
+func (s *Spec) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &s.Spec); err != nil {
+		return err
+	}
+
+	known := specKeys()
+	s.Extra = make(map[string]string)
+	for key, value := range raw {
+		if known[key] {
+			continue
+		}
+
+		// Beacon API encodes all spec values as strings but we do not
+		// want to fail on nodes returning other types
+		var str string
+		if err := json.Unmarshal(value, &str); err != nil {
+			str = string(value)
+		}
+		s.Extra[key] = str
+	}
+
+	return nil
+}
+
+func (s *Spec) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(&s.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Extra) == 0 {
+		return b, nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	for key, value := range s.Extra {
+		if _, ok := raw[key]; !ok {
+			raw[key] = value
+		}
+	}
+
+	return json.Marshal(raw)
+}
+
+var (
+	specKeysOnce sync.Once
+	specKeysSet  map[string]bool
+)
+
+// specKeys returns the set of JSON keys beaconcommon.Spec decodes
+func specKeys() map[string]bool {
+	specKeysOnce.Do(func() {
+		specKeysSet = make(map[string]bool)
+		collectJSONKeys(reflect.TypeOf(beaconcommon.Spec{}), specKeysSet)
+	})
+	return specKeysSet
+}
+
+func collectJSONKeys(t reflect.Type, keys map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch {
+		case name == "-":
+		case field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct:
+			collectJSONKeys(field.Type, keys)
+		case !field.IsExported():
+		case name == "":
+			keys[field.Name] = true
+		default:
+			keys[name] = true
+		}
+	}
+}
+
+// DepositContract is the Eth1 deposit contract used by a beacon chain
+type DepositContract struct {
+	ChainID uint64                   `json:"chain_id,string"`
+	Address beaconcommon.Eth1Address `json:"address"`
+}
@@ -0,0 +1,28 @@
+//go:build !integration
+// +build !integration
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecJSON(t *testing.T) {
+	spec := &Spec{
+		Spec:  *configs.Mainnet,
+		Extra: map[string]string{"DOMAIN_BEACON_PROPOSER": "0x00000000"},
+	}
+
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	decoded := new(Spec)
+	err = json.Unmarshal(b, decoded)
+	require.NoError(t, err)
+	assert.Equal(t, spec, decoded)
+}
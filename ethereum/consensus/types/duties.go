@@ -0,0 +1,58 @@
+package types
+
+import (
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/view"
+)
+
+// ProposerDuties are block proposal duties for an epoch
+//
+// DependentRoot is the block root duties depend on, if it changes (e.g. after a reorg)
+// duties must be fetched again
+type ProposerDuties struct {
+	DependentRoot       beaconcommon.Root `json:"dependent_root"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Duties              []*ProposerDuty   `json:"data"`
+}
+
+type ProposerDuty struct {
+	Pubkey         beaconcommon.BLSPubkey      `json:"pubkey"`
+	ValidatorIndex beaconcommon.ValidatorIndex `json:"validator_index"`
+	Slot           beaconcommon.Slot           `json:"slot"`
+}
+
+// AttesterDuties are attestation duties for an epoch
+//
+// DependentRoot is the block root duties depend on, if it changes (e.g. after a reorg)
+// duties must be fetched again
+type AttesterDuties struct {
+	DependentRoot       beaconcommon.Root `json:"dependent_root"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Duties              []*AttesterDuty   `json:"data"`
+}
+
+type AttesterDuty struct {
+	Pubkey                  beaconcommon.BLSPubkey      `json:"pubkey"`
+	ValidatorIndex          beaconcommon.ValidatorIndex `json:"validator_index"`
+	CommitteeIndex          beaconcommon.CommitteeIndex `json:"committee_index"`
+	CommitteeLength         view.Uint64View             `json:"committee_length"`
+	CommitteesAtSlot        view.Uint64View             `json:"committees_at_slot"`
+	ValidatorCommitteeIndex view.Uint64View             `json:"validator_committee_index"`
+	Slot                    beaconcommon.Slot           `json:"slot"`
+}
+
+// SyncCommitteeDuties are sync committee duties for the sync committee period of an epoch
+//
+// Sync committees are computed a full period in advance so the Beacon API does not define
+// a dependent root for those duties, DependentRoot is set only if the node provides it
+type SyncCommitteeDuties struct {
+	DependentRoot       beaconcommon.Root    `json:"dependent_root"`
+	ExecutionOptimistic bool                 `json:"execution_optimistic"`
+	Duties              []*SyncCommitteeDuty `json:"data"`
+}
+
+type SyncCommitteeDuty struct {
+	Pubkey                        beaconcommon.BLSPubkey      `json:"pubkey"`
+	ValidatorIndex                beaconcommon.ValidatorIndex `json:"validator_index"`
+	ValidatorSyncCommitteeIndices []view.Uint64View           `json:"validator_sync_committee_indices"`
+}
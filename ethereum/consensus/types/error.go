@@ -8,10 +8,22 @@ type Error struct {
 	Code        int      `json:"code"`        // either a specific error code in case of invalid request or http status code
 	Message     string   `json:"message"`     // message describing error
 	StackTraces []string `json:"stacktraces"` // optional stacktraces, sent when node is in debug mode
+
+	Failures []*IndexedError `json:"failures,omitempty"` // per item failures, sent by endpoints submitting multiple items
+}
+
+// IndexedError describes the failure of one of the items submitted to the node
+type IndexedError struct {
+	Index   int    `json:"index"`   // index of the failing item in the submitted list
+	Message string `json:"message"` // message describing error
 }
 
 func (err Error) Error() string {
 	s := fmt.Sprintf("BeaconError: message=%q code=%d", err.Message, err.Code)
+	for _, failure := range err.Failures {
+		s = fmt.Sprintf("%v\nfailure: index=%d message=%q", s, failure.Index, failure.Message)
+	}
+
 	if len(err.StackTraces) != 0 {
 		s = fmt.Sprintf("%v\nstacktraces=%q", s, err.StackTraces)
 	}
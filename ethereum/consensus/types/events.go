@@ -0,0 +1,65 @@
+package types
+
+import (
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// Topics of events emitted by a beacon node on /eth/v1/events
+const (
+	EventTopicHead                 = "head"
+	EventTopicBlock                = "block"
+	EventTopicAttestation          = "attestation"
+	EventTopicVoluntaryExit        = "voluntary_exit"
+	EventTopicFinalizedCheckpoint  = "finalized_checkpoint"
+	EventTopicChainReorg           = "chain_reorg"
+	EventTopicContributionAndProof = "contribution_and_proof"
+)
+
+// Event is an event received from a beacon node event stream
+//
+// Data type depends on Topic
+// - "head": *HeadEvent
+// - "block": *BlockEvent
+// - "attestation": *phase0.Attestation
+// - "voluntary_exit": *phase0.SignedVoluntaryExit
+// - "finalized_checkpoint": *FinalizedCheckpointEvent
+// - "chain_reorg": *ChainReorgEvent
+// - "contribution_and_proof": *altair.SignedContributionAndProof
+type Event struct {
+	Topic string      `json:"topic"`
+	Data  interface{} `json:"data"`
+}
+
+type HeadEvent struct {
+	Slot                      beaconcommon.Slot `json:"slot"`
+	Block                     beaconcommon.Root `json:"block"`
+	State                     beaconcommon.Root `json:"state"`
+	EpochTransition           bool              `json:"epoch_transition"`
+	PreviousDutyDependentRoot beaconcommon.Root `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  beaconcommon.Root `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool              `json:"execution_optimistic"`
+}
+
+type BlockEvent struct {
+	Slot                beaconcommon.Slot `json:"slot"`
+	Block               beaconcommon.Root `json:"block"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               beaconcommon.Root  `json:"block"`
+	State               beaconcommon.Root  `json:"state"`
+	Epoch               beaconcommon.Epoch `json:"epoch"`
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+}
+
+type ChainReorgEvent struct {
+	Slot                beaconcommon.Slot  `json:"slot"`
+	Depth               uint64             `json:"depth,string"`
+	OldHeadBlock        beaconcommon.Root  `json:"old_head_block"`
+	NewHeadBlock        beaconcommon.Root  `json:"new_head_block"`
+	OldHeadState        beaconcommon.Root  `json:"old_head_state"`
+	NewHeadState        beaconcommon.Root  `json:"new_head_state"`
+	Epoch               beaconcommon.Epoch `json:"epoch"`
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+}
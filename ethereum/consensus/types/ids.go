@@ -0,0 +1,324 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// StateID identifies a beacon state in Beacon API calls
+//
+// It is either "head", "genesis", "finalized", "justified", a slot or a 0x prefixed hex encoded state root.
+// Use ParseStateID to build a StateID from user input, string constants (e.g. "head") can be used directly.
+type StateID string
+
+const (
+	StateIDHead      StateID = "head"
+	StateIDGenesis   StateID = "genesis"
+	StateIDFinalized StateID = "finalized"
+	StateIDJustified StateID = "justified"
+)
+
+// StateIDFromSlot returns the StateID of the state at slot
+func StateIDFromSlot(slot beaconcommon.Slot) StateID {
+	return StateID(formatSlot(slot))
+}
+
+// StateIDFromRoot returns the StateID of the state with the given state root
+func StateIDFromRoot(root beaconcommon.Root) StateID {
+	return StateID(root.String())
+}
+
+// ParseStateID parses and validates a state ID
+func ParseStateID(s string) (StateID, error) {
+	id := StateID(s)
+	return id, id.Validate()
+}
+
+// Validate returns an error if id is not a valid state ID
+func (id StateID) Validate() error {
+	switch id {
+	case StateIDHead, StateIDGenesis, StateIDFinalized, StateIDJustified:
+		return nil
+	}
+
+	if _, ok := parseSlot(string(id)); ok {
+		return nil
+	}
+
+	if _, ok := parseRoot(string(id)); ok {
+		return nil
+	}
+
+	return fmt.Errorf("invalid state ID %q (expected head, genesis, finalized, justified, a slot or a hex encoded root)", string(id))
+}
+
+func (id StateID) String() string { return string(id) }
+
+// Slot returns the slot id refers to if it is a slot
+func (id StateID) Slot() (beaconcommon.Slot, bool) { return parseSlot(string(id)) }
+
+// Root returns the state root id refers to if it is a root
+func (id StateID) Root() (beaconcommon.Root, bool) { return parseRoot(string(id)) }
+
+// UnmarshalText parses and validates a state ID
+func (id *StateID) UnmarshalText(text []byte) error {
+	parsed, err := ParseStateID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// BlockID identifies a beacon block in Beacon API calls
+//
+// It is either "head", "genesis", "finalized", a slot or a 0x prefixed hex encoded block root.
+// Use ParseBlockID to build a BlockID from user input, string constants (e.g. "head") can be used directly.
+type BlockID string
+
+const (
+	BlockIDHead      BlockID = "head"
+	BlockIDGenesis   BlockID = "genesis"
+	BlockIDFinalized BlockID = "finalized"
+)
+
+// BlockIDFromSlot returns the BlockID of the block at slot
+func BlockIDFromSlot(slot beaconcommon.Slot) BlockID {
+	return BlockID(formatSlot(slot))
+}
+
+// BlockIDFromRoot returns the BlockID of the block with the given root
+func BlockIDFromRoot(root beaconcommon.Root) BlockID {
+	return BlockID(root.String())
+}
+
+// ParseBlockID parses and validates a block ID
+func ParseBlockID(s string) (BlockID, error) {
+	id := BlockID(s)
+	return id, id.Validate()
+}
+
+// Validate returns an error if id is not a valid block ID
+func (id BlockID) Validate() error {
+	switch id {
+	case BlockIDHead, BlockIDGenesis, BlockIDFinalized:
+		return nil
+	}
+
+	if _, ok := parseSlot(string(id)); ok {
+		return nil
+	}
+
+	if _, ok := parseRoot(string(id)); ok {
+		return nil
+	}
+
+	return fmt.Errorf("invalid block ID %q (expected head, genesis, finalized, a slot or a hex encoded root)", string(id))
+}
+
+func (id BlockID) String() string { return string(id) }
+
+// Slot returns the slot id refers to if it is a slot
+func (id BlockID) Slot() (beaconcommon.Slot, bool) { return parseSlot(string(id)) }
+
+// Root returns the block root id refers to if it is a root
+func (id BlockID) Root() (beaconcommon.Root, bool) { return parseRoot(string(id)) }
+
+// UnmarshalText parses and validates a block ID
+func (id *BlockID) UnmarshalText(text []byte) error {
+	parsed, err := ParseBlockID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// ValidatorID identifies a validator in Beacon API calls
+//
+// It is either a validator index or a 0x prefixed hex encoded validator public key.
+// Use ParseValidatorID to build a ValidatorID from user input, string constants (e.g. "1") can be used directly.
+type ValidatorID string
+
+// ValidatorIDFromIndex returns the ValidatorID of the validator with the given index
+func ValidatorIDFromIndex(index beaconcommon.ValidatorIndex) ValidatorID {
+	return ValidatorID(strconv.FormatUint(uint64(index), 10))
+}
+
+// ValidatorIDFromPubkey returns the ValidatorID of the validator with the given public key
+func ValidatorIDFromPubkey(pubkey beaconcommon.BLSPubkey) ValidatorID {
+	return ValidatorID(pubkey.String())
+}
+
+// ParseValidatorID parses and validates a validator ID
+func ParseValidatorID(s string) (ValidatorID, error) {
+	id := ValidatorID(s)
+	return id, id.Validate()
+}
+
+// ParseValidatorIDs parses and validates validator IDs
+func ParseValidatorIDs(ss []string) ([]ValidatorID, error) {
+	ids := make([]ValidatorID, 0, len(ss))
+	for _, s := range ss {
+		id, err := ParseValidatorID(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ValidatorIDs converts strings into ValidatorIDs without validating them
+//
+// It eases migration of callers passing []string to client.Client methods, use ParseValidatorIDs on user input.
+func ValidatorIDs(ss ...string) []ValidatorID {
+	if ss == nil {
+		return nil
+	}
+
+	ids := make([]ValidatorID, 0, len(ss))
+	for _, s := range ss {
+		ids = append(ids, ValidatorID(s))
+	}
+	return ids
+}
+
+// ValidateValidatorIDs returns an error if any of ids is not a valid validator ID
+func ValidateValidatorIDs(ids []ValidatorID) error {
+	for _, id := range ids {
+		if err := id.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidatorIDStrings converts ids into strings
+func ValidatorIDStrings(ids []ValidatorID) []string {
+	if ids == nil {
+		return nil
+	}
+
+	ss := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ss = append(ss, string(id))
+	}
+	return ss
+}
+
+// Validate returns an error if id is not a valid validator ID
+func (id ValidatorID) Validate() error {
+	if _, ok := id.Index(); ok {
+		return nil
+	}
+
+	if _, ok := id.Pubkey(); ok {
+		return nil
+	}
+
+	return fmt.Errorf("invalid validator ID %q (expected a validator index or a hex encoded public key)", string(id))
+}
+
+func (id ValidatorID) String() string { return string(id) }
+
+// Index returns the validator index id refers to if it is an index
+func (id ValidatorID) Index() (beaconcommon.ValidatorIndex, bool) {
+	if !isDecimal(string(id)) {
+		return 0, false
+	}
+
+	index, err := strconv.ParseUint(string(id), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return beaconcommon.ValidatorIndex(index), true
+}
+
+// Pubkey returns the validator public key id refers to if it is a public key
+func (id ValidatorID) Pubkey() (beaconcommon.BLSPubkey, bool) {
+	var pubkey beaconcommon.BLSPubkey
+	if !isHex(string(id), len(pubkey)) {
+		return pubkey, false
+	}
+
+	if err := pubkey.UnmarshalText([]byte(id)); err != nil {
+		return pubkey, false
+	}
+
+	return pubkey, true
+}
+
+// UnmarshalText parses and validates a validator ID
+func (id *ValidatorID) UnmarshalText(text []byte) error {
+	parsed, err := ParseValidatorID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+func formatSlot(slot beaconcommon.Slot) string {
+	return strconv.FormatUint(uint64(slot), 10)
+}
+
+func parseSlot(s string) (beaconcommon.Slot, bool) {
+	if !isDecimal(s) {
+		return 0, false
+	}
+
+	slot, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return beaconcommon.Slot(slot), true
+}
+
+func parseRoot(s string) (beaconcommon.Root, bool) {
+	var root beaconcommon.Root
+	if !isHex(s, len(root)) {
+		return root, false
+	}
+
+	if err := root.UnmarshalText([]byte(s)); err != nil {
+		return root, false
+	}
+
+	return root, true
+}
+
+// isDecimal indicates whether s is a non empty string of decimal digits
+func isDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isHex indicates whether s is a 0x prefixed hex encoding of size bytes
+func isHex(s string, size int) bool {
+	if len(s) != 2+2*size || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+
+	for _, r := range s[2:] {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,129 @@
+//go:build !integration
+// +build !integration
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRoot   = "0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360"
+	testPubkey = "0x8efba2238a00d678306c6258105b058e3c8b0c1f36e821de42da7319c4221b77aa74135dab1860235e19d6515575c381"
+)
+
+func TestStateID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+		slot  bool
+		root  bool
+	}{
+		{id: "head", valid: true},
+		{id: "genesis", valid: true},
+		{id: "finalized", valid: true},
+		{id: "justified", valid: true},
+		{id: "12", valid: true, slot: true},
+		{id: testRoot, valid: true, root: true},
+		{id: ""},
+		{id: "-1"},
+		{id: "+1"},
+		{id: "1.5"},
+		{id: "18446744073709551616"},
+		{id: "Head"},
+		{id: testRoot[2:]},
+		{id: testRoot[:65]},
+		{id: testRoot[:65] + "z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			id, err := ParseStateID(tt.id)
+			if !tt.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, id.String())
+
+			_, isSlot := id.Slot()
+			assert.Equal(t, tt.slot, isSlot)
+			_, isRoot := id.Root()
+			assert.Equal(t, tt.root, isRoot)
+		})
+	}
+
+	assert.Equal(t, StateID("12"), StateIDFromSlot(12))
+	root := beaconcommon.Root{0x1}
+	rv, ok := StateIDFromRoot(root).Root()
+	require.True(t, ok)
+	assert.Equal(t, root, rv)
+}
+
+func TestBlockID(t *testing.T) {
+	for _, id := range []string{"head", "genesis", "finalized", "0", testRoot} {
+		_, err := ParseBlockID(id)
+		assert.NoError(t, err, id)
+	}
+
+	for _, id := range []string{"justified", "", "latest", testPubkey} {
+		_, err := ParseBlockID(id)
+		assert.Error(t, err, id)
+	}
+
+	slot, ok := BlockIDFromSlot(42).Slot()
+	require.True(t, ok)
+	assert.Equal(t, beaconcommon.Slot(42), slot)
+}
+
+func TestValidatorID(t *testing.T) {
+	id, err := ParseValidatorID("10")
+	require.NoError(t, err)
+	index, ok := id.Index()
+	require.True(t, ok)
+	assert.Equal(t, beaconcommon.ValidatorIndex(10), index)
+	_, ok = id.Pubkey()
+	assert.False(t, ok)
+
+	id, err = ParseValidatorID(testPubkey)
+	require.NoError(t, err)
+	pubkey, ok := id.Pubkey()
+	require.True(t, ok)
+	assert.Equal(t, testPubkey, pubkey.String())
+	assert.Equal(t, id, ValidatorIDFromPubkey(pubkey))
+
+	for _, s := range []string{"", "head", testRoot, "0x12"} {
+		_, err := ParseValidatorID(s)
+		assert.Error(t, err, s)
+	}
+
+	ids, err := ParseValidatorIDs([]string{"1", testPubkey})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", testPubkey}, ValidatorIDStrings(ids))
+	assert.Equal(t, ValidatorID("7"), ValidatorIDFromIndex(7))
+	assert.Equal(t, ids, ValidatorIDs("1", testPubkey))
+	assert.Equal(t, []string{"1", testPubkey}, ValidatorIDStrings(ValidatorIDs([]string{"1", testPubkey}...)))
+	assert.Nil(t, ValidatorIDs())
+
+	_, err = ParseValidatorIDs([]string{"1", "invalid"})
+	require.Error(t, err)
+}
+
+func TestIDUnmarshalJSON(t *testing.T) {
+	var v struct {
+		State     StateID     `json:"state"`
+		Block     BlockID     `json:"block"`
+		Validator ValidatorID `json:"validator"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"state":"finalized","block":"12","validator":"3"}`), &v))
+	assert.Equal(t, StateIDFinalized, v.State)
+	assert.Equal(t, BlockID("12"), v.Block)
+	assert.Equal(t, ValidatorID("3"), v.Validator)
+
+	require.Error(t, json.Unmarshal([]byte(`{"state":"latest"}`), &v))
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/ztyp/view"
+)
+
+type Syncing struct {
+	HeadSlot     beaconcommon.Slot `json:"head_slot"`
+	SyncDistance beaconcommon.Slot `json:"sync_distance"`
+	IsSyncing    bool              `json:"is_syncing"`
+	IsOptimistic bool              `json:"is_optimistic"`
+	ELOffline    bool              `json:"el_offline"`
+}
+
+// Health is node health as indicated by /eth/v1/node/health status code
+type Health string
+
+const (
+	HealthReady          Health = "ready"           // 200: node is ready
+	HealthSyncing        Health = "syncing"         // 206: node is syncing but can serve incomplete data
+	HealthNotInitialized Health = "not_initialized" // 503: node is not initialized or having issues
+)
+
+// Peer states and directions
+const (
+	PeerStateDisconnected  = "disconnected"
+	PeerStateConnecting    = "connecting"
+	PeerStateConnected     = "connected"
+	PeerStateDisconnecting = "disconnecting"
+
+	PeerDirectionInbound  = "inbound"
+	PeerDirectionOutbound = "outbound"
+)
+
+type Peer struct {
+	PeerID             string `json:"peer_id"`
+	ENR                string `json:"enr"`
+	LastSeenP2PAddress string `json:"last_seen_p2p_address"`
+	State              string `json:"state"`
+	Direction          string `json:"direction"`
+}
+
+type PeerCount struct {
+	Disconnected  view.Uint64View `json:"disconnected"`
+	Connecting    view.Uint64View `json:"connecting"`
+	Connected     view.Uint64View `json:"connected"`
+	Disconnecting view.Uint64View `json:"disconnecting"`
+}
+
+type Identity struct {
+	PeerID             string           `json:"peer_id"`
+	ENR                string           `json:"enr"`
+	P2PAddresses       []string         `json:"p2p_addresses"`
+	DiscoveryAddresses []string         `json:"discovery_addresses"`
+	Metadata           IdentityMetadata `json:"metadata"`
+}
+
+type IdentityMetadata struct {
+	SeqNumber view.Uint64View `json:"seq_number"`
+	Attnets   string          `json:"attnets"`
+	Syncnets  string          `json:"syncnets,omitempty"`
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// SignedGwei is an amount of Gwei that can be negative (e.g. a penalty)
+type SignedGwei int64
+
+func (g SignedGwei) String() string {
+	return strconv.FormatInt(int64(g), 10)
+}
+
+func (g SignedGwei) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(g.String())), nil
+}
+
+func (g *SignedGwei) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signed gwei %s: %w", b, err)
+	}
+	*g = SignedGwei(v)
+
+	return nil
+}
+
+// BlockRewards are rewards earned by a block proposer for a block
+type BlockRewards struct {
+	ProposerIndex     beaconcommon.ValidatorIndex `json:"proposer_index"`
+	Total             beaconcommon.Gwei           `json:"total"`
+	Attestations      beaconcommon.Gwei           `json:"attestations"`
+	SyncAggregate     beaconcommon.Gwei           `json:"sync_aggregate"`
+	ProposerSlashings beaconcommon.Gwei           `json:"proposer_slashings"`
+	AttesterSlashings beaconcommon.Gwei           `json:"attester_slashings"`
+}
+
+func (r BlockRewards) MarshalCSV() ([]string, error) {
+	return []string{
+		strconv.FormatInt(int64(r.ProposerIndex), 10),
+		r.Total.String(),
+		r.Attestations.String(),
+		r.SyncAggregate.String(),
+		r.ProposerSlashings.String(),
+		r.AttesterSlashings.String(),
+	}, nil
+}
+
+func (r *BlockRewards) UnmarshalCSV(record []string) error {
+	if len(record) != 6 {
+		return fmt.Errorf("invalid csv record with %d fields (%d fields expected)", len(record), 6)
+	}
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"proposer_index":     record[0],
+		"total":              record[1],
+		"attestations":       record[2],
+		"sync_aggregate":     record[3],
+		"proposer_slashings": record[4],
+		"attester_slashings": record[5],
+	})
+
+	return json.Unmarshal(b, r)
+}
+
+// AttestationRewards are attestation rewards for an epoch
+//
+// IdealRewards are the rewards a validator would have earned with perfect attestations,
+// for each effective balance. TotalRewards are the rewards actually earned (or lost) by each validator
+type AttestationRewards struct {
+	IdealRewards []*IdealAttestationRewards `json:"ideal_rewards"`
+	TotalRewards []*AttestationReward       `json:"total_rewards"`
+}
+
+type IdealAttestationRewards struct {
+	EffectiveBalance beaconcommon.Gwei `json:"effective_balance"`
+	Head             beaconcommon.Gwei `json:"head"`
+	Target           beaconcommon.Gwei `json:"target"`
+	Source           beaconcommon.Gwei `json:"source"`
+	InclusionDelay   beaconcommon.Gwei `json:"inclusion_delay,omitempty"` // phase0 only
+	Inactivity       beaconcommon.Gwei `json:"inactivity"`
+}
+
+// AttestationReward is the attestation reward of a validator for an epoch
+//
+// Values are negative when the validator has been penalized
+type AttestationReward struct {
+	ValidatorIndex beaconcommon.ValidatorIndex `json:"validator_index"`
+	Head           SignedGwei                  `json:"head"`
+	Target         SignedGwei                  `json:"target"`
+	Source         SignedGwei                  `json:"source"`
+	InclusionDelay beaconcommon.Gwei           `json:"inclusion_delay,omitempty"` // phase0 only
+	Inactivity     SignedGwei                  `json:"inactivity"`
+}
+
+func (r AttestationReward) MarshalCSV() ([]string, error) {
+	return []string{
+		strconv.FormatInt(int64(r.ValidatorIndex), 10),
+		r.Head.String(),
+		r.Target.String(),
+		r.Source.String(),
+		r.InclusionDelay.String(),
+		r.Inactivity.String(),
+	}, nil
+}
+
+func (r *AttestationReward) UnmarshalCSV(record []string) error {
+	if len(record) != 6 {
+		return fmt.Errorf("invalid csv record with %d fields (%d fields expected)", len(record), 6)
+	}
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"validator_index": record[0],
+		"head":            record[1],
+		"target":          record[2],
+		"source":          record[3],
+		"inclusion_delay": record[4],
+		"inactivity":      record[5],
+	})
+
+	return json.Unmarshal(b, r)
+}
+
+// SyncCommitteeReward is the reward of a sync committee member for a block
+//
+// Reward is negative when the validator missed its sync committee duty
+type SyncCommitteeReward struct {
+	ValidatorIndex beaconcommon.ValidatorIndex `json:"validator_index"`
+	Reward         SignedGwei                  `json:"reward"`
+}
+
+func (r SyncCommitteeReward) MarshalCSV() ([]string, error) {
+	return []string{
+		strconv.FormatInt(int64(r.ValidatorIndex), 10),
+		r.Reward.String(),
+	}, nil
+}
+
+func (r *SyncCommitteeReward) UnmarshalCSV(record []string) error {
+	if len(record) != 2 {
+		return fmt.Errorf("invalid csv record with %d fields (%d fields expected)", len(record), 2)
+	}
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"validator_index": record[0],
+		"reward":          record[1],
+	})
+
+	return json.Unmarshal(b, r)
+}
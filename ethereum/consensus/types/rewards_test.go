@@ -0,0 +1,65 @@
+//go:build !integration
+// +build !integration
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedGweiJSON(t *testing.T) {
+	var g SignedGwei
+	require.NoError(t, json.Unmarshal([]byte(`"-2000"`), &g))
+	assert.Equal(t, SignedGwei(-2000), g)
+
+	require.NoError(t, json.Unmarshal([]byte(`150`), &g))
+	assert.Equal(t, SignedGwei(150), g)
+
+	b, err := json.Marshal(SignedGwei(-3))
+	require.NoError(t, err)
+	assert.Equal(t, `"-3"`, string(b))
+
+	require.Error(t, json.Unmarshal([]byte(`"abc"`), &g))
+}
+
+func TestRewardsUnmarshalMarshalCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []string
+		v      interface {
+			MarshalCSV() ([]string, error)
+			UnmarshalCSV([]string) error
+		}
+	}{
+		{
+			name:   "BlockRewards",
+			record: []string{"123", "123", "123", "123", "123", "123"},
+			v:      new(BlockRewards),
+		},
+		{
+			name:   "AttestationReward",
+			record: []string{"10", "2000", "-2000", "-1500", "0", "-300"},
+			v:      new(AttestationReward),
+		},
+		{
+			name:   "SyncCommitteeReward",
+			record: []string{"10", "-8000"},
+			v:      new(SyncCommitteeReward),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.v.UnmarshalCSV(tt.record))
+			record, err := tt.v.MarshalCSV()
+			require.NoError(t, err)
+			assert.Equal(t, tt.record, record)
+
+			require.Error(t, tt.v.UnmarshalCSV(tt.record[1:]))
+		})
+	}
+}
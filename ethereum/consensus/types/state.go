@@ -0,0 +1,172 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/protolambda/zrnt/eth2/beacon/altair"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/ztyp/codec"
+)
+
+// BeaconState is a beacon state of any supported fork
+//
+// Only the field matching Version is set
+type BeaconState struct {
+	Version string
+
+	Phase0    *beaconphase0.BeaconState
+	Altair    *altair.BeaconState
+	Bellatrix *bellatrix.BeaconState
+	Capella   *capella.BeaconState
+	Deneb     *deneb.BeaconState
+}
+
+// UnmarshalVersionedJSON decodes data into a state of the given fork version
+func (s *BeaconState) UnmarshalVersionedJSON(version string, data []byte) error {
+	msg, err := s.reset(version)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, msg)
+}
+
+// DecodeVersionedJSON decodes the next JSON value from dec into a state of the given fork version
+//
+// It allows to decode a state without buffering it first
+func (s *BeaconState) DecodeVersionedJSON(version string, dec *json.Decoder) error {
+	msg, err := s.reset(version)
+	if err != nil {
+		return err
+	}
+
+	return dec.Decode(msg)
+}
+
+// UnmarshalVersionedSSZ decodes SSZ encoded state of the given fork version from r
+//
+// size is the length in bytes of the encoded state
+func (s *BeaconState) UnmarshalVersionedSSZ(spec *beaconcommon.Spec, version string, r io.Reader, size uint64) error {
+	msg, err := s.reset(version)
+	if err != nil {
+		return err
+	}
+
+	return msg.Deserialize(spec, codec.NewDecodingReader(r, size))
+}
+
+// reset sets s to an empty state of the given fork version
+func (s *BeaconState) reset(version string) (sszObject, error) {
+	*s = BeaconState{Version: version}
+
+	switch version {
+	case ForkPhase0:
+		s.Phase0 = new(beaconphase0.BeaconState)
+		return s.Phase0, nil
+	case ForkAltair:
+		s.Altair = new(altair.BeaconState)
+		return s.Altair, nil
+	case ForkBellatrix:
+		s.Bellatrix = new(bellatrix.BeaconState)
+		return s.Bellatrix, nil
+	case ForkCapella:
+		s.Capella = new(capella.BeaconState)
+		return s.Capella, nil
+	case ForkDeneb:
+		s.Deneb = new(deneb.BeaconState)
+		return s.Deneb, nil
+	default:
+		return nil, fmt.Errorf("unsupported state version %q", version)
+	}
+}
+
+// Slot returns the state slot
+func (s *BeaconState) Slot() beaconcommon.Slot {
+	switch {
+	case s.Phase0 != nil:
+		return s.Phase0.Slot
+	case s.Altair != nil:
+		return s.Altair.Slot
+	case s.Bellatrix != nil:
+		return s.Bellatrix.Slot
+	case s.Capella != nil:
+		return s.Capella.Slot
+	case s.Deneb != nil:
+		return s.Deneb.Slot
+	}
+	return 0
+}
+
+// Fork returns the state fork
+func (s *BeaconState) Fork() beaconcommon.Fork {
+	switch {
+	case s.Phase0 != nil:
+		return s.Phase0.Fork
+	case s.Altair != nil:
+		return s.Altair.Fork
+	case s.Bellatrix != nil:
+		return s.Bellatrix.Fork
+	case s.Capella != nil:
+		return s.Capella.Fork
+	case s.Deneb != nil:
+		return s.Deneb.Fork
+	}
+	return beaconcommon.Fork{}
+}
+
+// Validators returns the validator registry
+func (s *BeaconState) Validators() beaconphase0.ValidatorRegistry {
+	switch {
+	case s.Phase0 != nil:
+		return s.Phase0.Validators
+	case s.Altair != nil:
+		return s.Altair.Validators
+	case s.Bellatrix != nil:
+		return s.Bellatrix.Validators
+	case s.Capella != nil:
+		return s.Capella.Validators
+	case s.Deneb != nil:
+		return s.Deneb.Validators
+	}
+	return nil
+}
+
+// Balances returns validator balances, indexed as the validator registry
+func (s *BeaconState) Balances() beaconphase0.Balances {
+	switch {
+	case s.Phase0 != nil:
+		return s.Phase0.Balances
+	case s.Altair != nil:
+		return s.Altair.Balances
+	case s.Bellatrix != nil:
+		return s.Bellatrix.Balances
+	case s.Capella != nil:
+		return s.Capella.Balances
+	case s.Deneb != nil:
+		return s.Deneb.Balances
+	}
+	return nil
+}
+
+// FinalizedCheckpoint returns the finalized checkpoint of the state
+func (s *BeaconState) FinalizedCheckpoint() beaconcommon.Checkpoint {
+	switch {
+	case s.Phase0 != nil:
+		return s.Phase0.FinalizedCheckpoint
+	case s.Altair != nil:
+		return s.Altair.FinalizedCheckpoint
+	case s.Bellatrix != nil:
+		return s.Bellatrix.FinalizedCheckpoint
+	case s.Capella != nil:
+		return s.Capella.FinalizedCheckpoint
+	case s.Deneb != nil:
+		return s.Deneb.FinalizedCheckpoint
+	}
+	return beaconcommon.Checkpoint{}
+}
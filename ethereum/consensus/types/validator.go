@@ -11,11 +11,108 @@ import (
 
 type Validator struct {
 	Index     beaconcommon.ValidatorIndex `json:"index" yaml:"index"`
-	Status    string                      `json:"status" yaml:"status"`
+	Status    ValidatorStatus             `json:"status" yaml:"status"`
 	Balance   beaconcommon.Gwei           `json:"balance" yaml:"balance"`
 	Validator *beaconphase0.Validator     `json:"validator" yaml:"validator"`
 }
 
+// Withdrawal credentials prefixes
+const (
+	BLSWithdrawalPrefix         byte = 0x00
+	ETH1AddressWithdrawalPrefix byte = 0x01
+	CompoundingWithdrawalPrefix byte = 0x02 // since Electra
+)
+
+// WithdrawalPrefix returns the prefix of the validator withdrawal credentials
+//
+// It returns false if validator details are missing
+func (val *Validator) WithdrawalPrefix() (byte, bool) {
+	if val.Validator == nil {
+		return 0, false
+	}
+	return val.Validator.WithdrawalCredentials[0], true
+}
+
+// hasWithdrawalPrefix indicates whether the validator withdrawal credentials start with prefix
+func (val *Validator) hasWithdrawalPrefix(prefix byte) bool {
+	p, ok := val.WithdrawalPrefix()
+	return ok && p == prefix
+}
+
+// HasBLSWithdrawalCredentials indicates whether the validator has 0x00 withdrawal credentials
+// (i.e. it can not withdraw until credentials are changed to an execution address)
+func (val *Validator) HasBLSWithdrawalCredentials() bool {
+	return val.hasWithdrawalPrefix(BLSWithdrawalPrefix)
+}
+
+// HasETH1WithdrawalCredentials indicates whether the validator has 0x01 withdrawal credentials
+func (val *Validator) HasETH1WithdrawalCredentials() bool {
+	return val.hasWithdrawalPrefix(ETH1AddressWithdrawalPrefix)
+}
+
+// HasCompoundingWithdrawalCredentials indicates whether the validator has 0x02 withdrawal credentials
+func (val *Validator) HasCompoundingWithdrawalCredentials() bool {
+	return val.hasWithdrawalPrefix(CompoundingWithdrawalPrefix)
+}
+
+// HasExecutionWithdrawalCredentials indicates whether the validator withdraws to an execution address
+// (i.e. has either 0x01 or 0x02 withdrawal credentials)
+func (val *Validator) HasExecutionWithdrawalCredentials() bool {
+	return val.HasETH1WithdrawalCredentials() || val.HasCompoundingWithdrawalCredentials()
+}
+
+// WithdrawalAddress returns the execution address the validator withdraws to
+//
+// It returns false if the validator does not have execution withdrawal credentials
+func (val *Validator) WithdrawalAddress() (beaconcommon.Eth1Address, bool) {
+	var addr beaconcommon.Eth1Address
+	if !val.HasExecutionWithdrawalCredentials() {
+		return addr, false
+	}
+	copy(addr[:], val.Validator.WithdrawalCredentials[12:])
+	return addr, true
+}
+
+// IsActive indicates whether the validator is active at epoch
+func (val *Validator) IsActive(epoch beaconcommon.Epoch) bool {
+	if val.Validator == nil {
+		return false
+	}
+	return val.Validator.ActivationEpoch <= epoch && epoch < val.Validator.ExitEpoch
+}
+
+// IsFullyWithdrawable indicates whether the validator balance is fully withdrawable at epoch
+func (val *Validator) IsFullyWithdrawable(epoch beaconcommon.Epoch) bool {
+	if val.Validator == nil {
+		return false
+	}
+	return val.HasExecutionWithdrawalCredentials() && val.Validator.WithdrawableEpoch <= epoch && val.Balance > 0
+}
+
+// IsPartiallyWithdrawable indicates whether the validator balance in excess of its maximum effective balance is withdrawable
+//
+// Maximum effective balance is MAX_EFFECTIVE_BALANCE_ELECTRA for validators with compounding withdrawal credentials,
+// MIN_ACTIVATION_BALANCE (or MAX_EFFECTIVE_BALANCE for pre-Electra specs) otherwise. Validators with
+// compounding withdrawal credentials are never partially withdrawable with a pre-Electra spec.
+func (val *Validator) IsPartiallyWithdrawable(spec *beaconcommon.Spec) bool {
+	if val.Validator == nil || !val.HasExecutionWithdrawalCredentials() {
+		return false
+	}
+
+	maxEffectiveBalance := spec.MIN_ACTIVATION_BALANCE
+	if maxEffectiveBalance == 0 {
+		maxEffectiveBalance = spec.MAX_EFFECTIVE_BALANCE
+	}
+	if val.HasCompoundingWithdrawalCredentials() {
+		if spec.MAX_EFFECTIVE_BALANCE_ELECTRA == 0 {
+			return false
+		}
+		maxEffectiveBalance = spec.MAX_EFFECTIVE_BALANCE_ELECTRA
+	}
+
+	return val.Validator.EffectiveBalance == maxEffectiveBalance && val.Balance > maxEffectiveBalance
+}
+
 type ValidatorBalance struct {
 	Index   beaconcommon.ValidatorIndex `json:"index" yaml:"index"`
 	Balance beaconcommon.Gwei           `json:"balance" yaml:"balance"`
@@ -24,7 +121,7 @@ type ValidatorBalance struct {
 func (val Validator) MarshalCSV() ([]string, error) {
 	record := []string{
 		strconv.FormatInt(int64(val.Index), 10),
-		val.Status,
+		val.Status.String(),
 		val.Balance.String(),
 	}
 
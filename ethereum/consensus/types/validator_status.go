@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidatorStatus is a validator status as defined by the Beacon API
+//
+// It is either a specific status (e.g. "active_ongoing") or a general status (e.g. "active")
+// which groups all specific statuses prefixed by it. General statuses are only used to filter validators.
+type ValidatorStatus string
+
+// Specific validator statuses
+const (
+	ValidatorStatusPendingInitialized ValidatorStatus = "pending_initialized"
+	ValidatorStatusPendingQueued      ValidatorStatus = "pending_queued"
+	ValidatorStatusActiveOngoing      ValidatorStatus = "active_ongoing"
+	ValidatorStatusActiveExiting      ValidatorStatus = "active_exiting"
+	ValidatorStatusActiveSlashed      ValidatorStatus = "active_slashed"
+	ValidatorStatusExitedUnslashed    ValidatorStatus = "exited_unslashed"
+	ValidatorStatusExitedSlashed      ValidatorStatus = "exited_slashed"
+	ValidatorStatusWithdrawalPossible ValidatorStatus = "withdrawal_possible"
+	ValidatorStatusWithdrawalDone     ValidatorStatus = "withdrawal_done"
+)
+
+// General validator statuses
+const (
+	ValidatorStatusPending    ValidatorStatus = "pending"
+	ValidatorStatusActive     ValidatorStatus = "active"
+	ValidatorStatusExited     ValidatorStatus = "exited"
+	ValidatorStatusWithdrawal ValidatorStatus = "withdrawal"
+)
+
+var validatorStatuses = map[ValidatorStatus]ValidatorStatus{
+	ValidatorStatusPendingInitialized: ValidatorStatusPending,
+	ValidatorStatusPendingQueued:      ValidatorStatusPending,
+	ValidatorStatusActiveOngoing:      ValidatorStatusActive,
+	ValidatorStatusActiveExiting:      ValidatorStatusActive,
+	ValidatorStatusActiveSlashed:      ValidatorStatusActive,
+	ValidatorStatusExitedUnslashed:    ValidatorStatusExited,
+	ValidatorStatusExitedSlashed:      ValidatorStatusExited,
+	ValidatorStatusWithdrawalPossible: ValidatorStatusWithdrawal,
+	ValidatorStatusWithdrawalDone:     ValidatorStatusWithdrawal,
+}
+
+// ParseValidatorStatus parses and validates a specific or general validator status
+func ParseValidatorStatus(s string) (ValidatorStatus, error) {
+	status := ValidatorStatus(s)
+	return status, status.Validate()
+}
+
+// Validate returns an error if status is neither a specific nor a general validator status
+func (status ValidatorStatus) Validate() error {
+	if status.IsSpecific() || status.IsGeneral() {
+		return nil
+	}
+	return fmt.Errorf("invalid validator status %q", string(status))
+}
+
+func (status ValidatorStatus) String() string { return string(status) }
+
+// IsSpecific indicates whether status is a specific status (e.g. "active_ongoing")
+func (status ValidatorStatus) IsSpecific() bool {
+	_, ok := validatorStatuses[status]
+	return ok
+}
+
+// IsGeneral indicates whether status is a general status (e.g. "active")
+func (status ValidatorStatus) IsGeneral() bool {
+	switch status {
+	case ValidatorStatusPending, ValidatorStatusActive, ValidatorStatusExited, ValidatorStatusWithdrawal:
+		return true
+	}
+	return false
+}
+
+// General returns the general status of a specific status
+//
+// It returns status itself if it is already general and an empty status if it is unknown
+func (status ValidatorStatus) General() ValidatorStatus {
+	if status.IsGeneral() {
+		return status
+	}
+	return validatorStatuses[status]
+}
+
+// Matches indicates whether status matches filter, filter being either a specific or a general status
+func (status ValidatorStatus) Matches(filter ValidatorStatus) bool {
+	if status == filter {
+		return true
+	}
+	return filter.IsGeneral() && strings.HasPrefix(string(status), string(filter)+"_")
+}
@@ -0,0 +1,56 @@
+//go:build !integration
+// +build !integration
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		valid    bool
+		specific bool
+		general  ValidatorStatus
+	}{
+		{status: "pending_initialized", valid: true, specific: true, general: ValidatorStatusPending},
+		{status: "pending_queued", valid: true, specific: true, general: ValidatorStatusPending},
+		{status: "active_ongoing", valid: true, specific: true, general: ValidatorStatusActive},
+		{status: "active_exiting", valid: true, specific: true, general: ValidatorStatusActive},
+		{status: "active_slashed", valid: true, specific: true, general: ValidatorStatusActive},
+		{status: "exited_unslashed", valid: true, specific: true, general: ValidatorStatusExited},
+		{status: "exited_slashed", valid: true, specific: true, general: ValidatorStatusExited},
+		{status: "withdrawal_possible", valid: true, specific: true, general: ValidatorStatusWithdrawal},
+		{status: "withdrawal_done", valid: true, specific: true, general: ValidatorStatusWithdrawal},
+		{status: "active", valid: true, general: ValidatorStatusActive},
+		{status: "withdrawal", valid: true, general: ValidatorStatusWithdrawal},
+		{status: "active_unknown"},
+		{status: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			status, err := ParseValidatorStatus(tt.status)
+			if !tt.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.specific, status.IsSpecific())
+			assert.Equal(t, !tt.specific, status.IsGeneral())
+			assert.Equal(t, tt.general, status.General())
+		})
+	}
+}
+
+func TestValidatorStatusMatches(t *testing.T) {
+	assert.True(t, ValidatorStatusActiveOngoing.Matches(ValidatorStatusActiveOngoing))
+	assert.True(t, ValidatorStatusActiveOngoing.Matches(ValidatorStatusActive))
+	assert.False(t, ValidatorStatusActiveOngoing.Matches(ValidatorStatusActiveExiting))
+	assert.False(t, ValidatorStatusActiveOngoing.Matches(ValidatorStatusExited))
+	assert.False(t, ValidatorStatusWithdrawalDone.Matches("withdrawal_"))
+}
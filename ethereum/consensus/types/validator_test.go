@@ -6,6 +6,9 @@ package types
 import (
 	"testing"
 
+	beaconcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	beaconphase0 "github.com/protolambda/zrnt/eth2/beacon/phase0"
+	"github.com/protolambda/zrnt/eth2/configs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +39,143 @@ func TestValidatorUnmarshalMarshalCSV(t *testing.T) {
 	})
 
 }
+
+const farFutureEpoch = beaconcommon.Epoch(18446744073709551615)
+
+// withdrawalCredentials returns credentials with given prefix and execution address 0x00..00ab
+func withdrawalCredentials(prefix byte) beaconcommon.Root {
+	creds := beaconcommon.Root{prefix}
+	creds[31] = 0xab
+	return creds
+}
+
+func newTestValidator(prefix byte, effectiveBalance, balance beaconcommon.Gwei) *Validator {
+	return &Validator{
+		Balance: balance,
+		Validator: &beaconphase0.Validator{
+			WithdrawalCredentials: withdrawalCredentials(prefix),
+			EffectiveBalance:      effectiveBalance,
+			ActivationEpoch:       10,
+			ExitEpoch:             farFutureEpoch,
+			WithdrawableEpoch:     farFutureEpoch,
+		},
+	}
+}
+
+func TestValidatorWithdrawalCredentials(t *testing.T) {
+	tests := []struct {
+		prefix      byte
+		bls         bool
+		eth1        bool
+		compounding bool
+		execution   bool
+	}{
+		{prefix: BLSWithdrawalPrefix, bls: true},
+		{prefix: ETH1AddressWithdrawalPrefix, eth1: true, execution: true},
+		{prefix: CompoundingWithdrawalPrefix, compounding: true, execution: true},
+		{prefix: 0x03},
+	}
+
+	for _, tt := range tests {
+		val := newTestValidator(tt.prefix, 32000000000, 32000000000)
+		prefix, ok := val.WithdrawalPrefix()
+		assert.True(t, ok)
+		assert.Equal(t, tt.prefix, prefix)
+		assert.Equal(t, tt.bls, val.HasBLSWithdrawalCredentials(), "prefix %#x", tt.prefix)
+		assert.Equal(t, tt.eth1, val.HasETH1WithdrawalCredentials(), "prefix %#x", tt.prefix)
+		assert.Equal(t, tt.compounding, val.HasCompoundingWithdrawalCredentials(), "prefix %#x", tt.prefix)
+		assert.Equal(t, tt.execution, val.HasExecutionWithdrawalCredentials(), "prefix %#x", tt.prefix)
+
+		addr, ok := val.WithdrawalAddress()
+		assert.Equal(t, tt.execution, ok, "prefix %#x", tt.prefix)
+		if ok {
+			assert.Equal(t, beaconcommon.Eth1Address{19: 0xab}, addr)
+		}
+	}
+
+	// validator details missing
+	val := &Validator{Index: 1}
+	_, ok := val.WithdrawalPrefix()
+	assert.False(t, ok)
+	assert.False(t, val.HasBLSWithdrawalCredentials())
+	assert.False(t, val.HasETH1WithdrawalCredentials())
+	assert.False(t, val.HasCompoundingWithdrawalCredentials())
+	assert.False(t, val.HasExecutionWithdrawalCredentials())
+}
+
+func TestValidatorIsActive(t *testing.T) {
+	val := newTestValidator(ETH1AddressWithdrawalPrefix, 32000000000, 32000000000)
+	val.Validator.ExitEpoch = 20
+
+	tests := []struct {
+		epoch    beaconcommon.Epoch
+		expected bool
+	}{
+		{epoch: 9, expected: false},
+		{epoch: 10, expected: true},
+		{epoch: 19, expected: true},
+		{epoch: 20, expected: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, val.IsActive(tt.epoch), "epoch %v", tt.epoch)
+	}
+
+	assert.False(t, (&Validator{}).IsActive(10))
+}
+
+func TestValidatorIsFullyWithdrawable(t *testing.T) {
+	tests := []struct {
+		desc              string
+		prefix            byte
+		withdrawableEpoch beaconcommon.Epoch
+		balance           beaconcommon.Gwei
+		expected          bool
+	}{
+		{desc: "eth1 credentials", prefix: ETH1AddressWithdrawalPrefix, withdrawableEpoch: 100, balance: 32000000000, expected: true},
+		{desc: "compounding credentials", prefix: CompoundingWithdrawalPrefix, withdrawableEpoch: 100, balance: 32000000000, expected: true},
+		{desc: "bls credentials", prefix: BLSWithdrawalPrefix, withdrawableEpoch: 100, balance: 32000000000},
+		{desc: "withdrawable epoch in the future", prefix: ETH1AddressWithdrawalPrefix, withdrawableEpoch: 101, balance: 32000000000},
+		{desc: "zero balance", prefix: ETH1AddressWithdrawalPrefix, withdrawableEpoch: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			val := newTestValidator(tt.prefix, 32000000000, tt.balance)
+			val.Validator.WithdrawableEpoch = tt.withdrawableEpoch
+			assert.Equal(t, tt.expected, val.IsFullyWithdrawable(100))
+		})
+	}
+}
+
+func TestValidatorIsPartiallyWithdrawable(t *testing.T) {
+	tests := []struct {
+		desc             string
+		prefix           byte
+		effectiveBalance beaconcommon.Gwei
+		balance          beaconcommon.Gwei
+		preElectra       bool
+		expected         bool
+	}{
+		{desc: "eth1 credentials with excess balance", prefix: ETH1AddressWithdrawalPrefix, effectiveBalance: 32000000000, balance: 32000000001, expected: true},
+		{desc: "eth1 credentials without excess balance", prefix: ETH1AddressWithdrawalPrefix, effectiveBalance: 32000000000, balance: 32000000000},
+		{desc: "eth1 credentials below max effective balance", prefix: ETH1AddressWithdrawalPrefix, effectiveBalance: 31000000000, balance: 32500000000},
+		{desc: "bls credentials with excess balance", prefix: BLSWithdrawalPrefix, effectiveBalance: 32000000000, balance: 33000000000},
+		{desc: "compounding credentials below max effective balance", prefix: CompoundingWithdrawalPrefix, effectiveBalance: 32000000000, balance: 33000000000},
+		{desc: "compounding credentials with excess balance", prefix: CompoundingWithdrawalPrefix, effectiveBalance: 2048000000000, balance: 2048000000001, expected: true},
+		{desc: "compounding credentials with pre-Electra spec", prefix: CompoundingWithdrawalPrefix, effectiveBalance: 0, balance: 1, preElectra: true},
+		{desc: "eth1 credentials with pre-Electra spec", prefix: ETH1AddressWithdrawalPrefix, effectiveBalance: 32000000000, balance: 32000000001, preElectra: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			spec := *configs.Mainnet
+			if tt.preElectra {
+				spec.MIN_ACTIVATION_BALANCE = 0
+				spec.MAX_EFFECTIVE_BALANCE_ELECTRA = 0
+			}
+			val := newTestValidator(tt.prefix, tt.effectiveBalance, tt.balance)
+			assert.Equal(t, tt.expected, val.IsPartiallyWithdrawable(&spec))
+		})
+	}
+}
@@ -74,6 +74,31 @@ func (c *Client) call(ctx context.Context, res interface{}, method string, param
 	)
 }
 
+// batchCall performs reqs in a single batch if the underlying client supports it and returns the error of each call
+//
+// The returned error is only set if the batch could not be performed
+func (c *Client) batchCall(ctx context.Context, reqs []*jsonrpc.Request, res []interface{}) (jsonrpc.BatchError, error) {
+	err := jsonrpc.BatchCall(ctx, c.client, reqs, res)
+
+	var batchErr jsonrpc.BatchError
+	if errors.As(err, &batchErr) {
+		return batchErr, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return make(jsonrpc.BatchError, len(reqs)), nil
+}
+
+func (c *Client) subscribe(ctx context.Context, channel interface{}, args ...interface{}) (geth.Subscription, error) {
+	subscriber, ok := c.client.(jsonrpc.Subscriber)
+	if !ok {
+		return nil, fmt.Errorf("JSON-RPC client does not support subscriptions")
+	}
+
+	return subscriber.Subscribe(ctx, "eth", channel, args...)
+}
+
 // ChainID retrieves the current chain ID
 func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
 	c.mu.Lock()
@@ -108,24 +133,20 @@ func (c *Client) BalanceAt(ctx context.Context, account gethcommon.Address, bloc
 }
 
 // BlockByHash returns the given full block.
-//
-// Note fetch of uncles blocks is not implemented yet.
 func (c *Client) BlockByHash(ctx context.Context, hash gethcommon.Hash) (*gethtypes.Block, error) {
 	return c.getBlock(ctx, "eth_getBlockByHash", hash, true)
 }
 
 // BlockByNumber returns a block from the current canonical chain. If number is nil, the
 // latest known block is returned.
-//
-// Note fetch of uncles blocks is not implemented yet.
 func (c *Client) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*gethtypes.Block, error) {
 	return c.getBlock(ctx, "eth_getBlockByNumber", types.ToBlockNumArg(blockNumber), true)
 }
 
 // HeaderByNumber returns header of a given block hash
 func (c *Client) HeaderByHash(ctx context.Context, hash gethcommon.Hash) (*gethtypes.Header, error) {
-	res := new(gethtypes.Header)
-	err := c.call(ctx, res, "eth_getBlockByHash", hash, false)
+	var res *gethtypes.Header
+	err := c.call(ctx, &res, "eth_getBlockByHash", hash, false)
 	if err == nil && res == nil {
 		err = geth.NotFound
 	}
@@ -134,8 +155,8 @@ func (c *Client) HeaderByHash(ctx context.Context, hash gethcommon.Hash) (*getht
 
 // HeaderByNumber returns header of a given block number
 func (c *Client) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*gethtypes.Header, error) {
-	res := new(gethtypes.Header)
-	err := c.call(ctx, res, "eth_getBlockByNumber", types.ToBlockNumArg(blockNumber), false)
+	var res *gethtypes.Header
+	err := c.call(ctx, &res, "eth_getBlockByNumber", types.ToBlockNumArg(blockNumber), false)
 	if err == nil && res == nil {
 		err = geth.NotFound
 	}
@@ -266,8 +287,14 @@ func (c *Client) FilterLogs(ctx context.Context, q geth.FilterQuery) ([]gethtype
 }
 
 // SubscribeFilterLogs subscribes to the results of a streaming filter query.
-func (c *Client) SubscribeFilterLogs(ctx context.Context, _ geth.FilterQuery, _ chan<- gethtypes.Log) (geth.Subscription, error) {
-	return nil, fmt.Errorf("not implemented")
+//
+// It requires the underlying JSON-RPC client to support subscriptions (e.g. a websocket client)
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q geth.FilterQuery, ch chan<- gethtypes.Log) (geth.Subscription, error) {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	return c.subscribe(ctx, ch, "logs", arg)
 }
 
 type feeHistoryResultMarshaling struct {
@@ -364,8 +391,11 @@ func (c *Client) StorageAt(ctx context.Context, account gethcommon.Address, key
 	return result, err
 }
 
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+//
+// It requires the underlying JSON-RPC client to support subscriptions (e.g. a websocket client)
 func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *gethtypes.Header) (geth.Subscription, error) {
-	return nil, fmt.Errorf("not implemented")
+	return c.subscribe(ctx, ch, "newHeads")
 }
 
 func (c *Client) SyncProgress(ctx context.Context) (*geth.SyncProgress, error) {
@@ -427,6 +457,90 @@ func (c *Client) TransactionReceipt(ctx context.Context, txHash gethcommon.Hash)
 	return r, err
 }
 
+// TransactionReceipts returns receipts of transactions by transaction hashes, loading them in a single batch.
+//
+// If some receipts could not be loaded, the returned error is a jsonrpc.BatchError holding the error
+// of each transaction (geth.NotFound for unknown or pending transactions) and loaded receipts are still returned.
+func (c *Client) TransactionReceipts(ctx context.Context, txHashes []gethcommon.Hash) ([]*gethtypes.Receipt, error) {
+	receipts := make([]*gethtypes.Receipt, len(txHashes))
+	reqs := make([]*jsonrpc.Request, len(txHashes))
+	res := make([]interface{}, len(txHashes))
+	for i, txHash := range txHashes {
+		reqs[i] = &jsonrpc.Request{
+			Method: "eth_getTransactionReceipt",
+			Params: []interface{}{txHash},
+		}
+		res[i] = &receipts[i]
+	}
+
+	errs, err := c.batchCall(ctx, reqs, res)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range receipts {
+		if errs[i] == nil && r == nil {
+			errs[i] = geth.NotFound
+		}
+	}
+
+	return receipts, batchErrorOrNil(errs)
+}
+
+// TransactionsByHash returns transactions with the given hashes, loading them in a single batch.
+//
+// If some transactions could not be loaded, the returned error is a jsonrpc.BatchError holding the error
+// of each transaction (geth.NotFound for unknown transactions) and loaded transactions are still returned.
+func (c *Client) TransactionsByHash(ctx context.Context, hashes []gethcommon.Hash) ([]*gethtypes.Transaction, error) {
+	rpcTxs := make([]*types.RPCTransaction, len(hashes))
+	reqs := make([]*jsonrpc.Request, len(hashes))
+	res := make([]interface{}, len(hashes))
+	for i, hash := range hashes {
+		reqs[i] = &jsonrpc.Request{
+			Method: "eth_getTransactionByHash",
+			Params: []interface{}{hash},
+		}
+		res[i] = &rpcTxs[i]
+	}
+
+	errs, err := c.batchCall(ctx, reqs, res)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*gethtypes.Transaction, len(hashes))
+	for i, rpcTx := range rpcTxs {
+		switch {
+		case errs[i] != nil:
+			continue
+		case rpcTx == nil:
+			errs[i] = geth.NotFound
+			continue
+		}
+
+		if _, r, _ := rpcTx.Tx.RawSignatureValues(); r == nil {
+			errs[i] = fmt.Errorf("server returned transaction without signature")
+			continue
+		}
+		if rpcTx.From != nil && rpcTx.BlockHash != nil {
+			setSenderFromServer(rpcTx.Tx, *rpcTx.From, *rpcTx.BlockHash)
+		}
+		txs[i] = rpcTx.Tx
+	}
+
+	return txs, batchErrorOrNil(errs)
+}
+
+// batchErrorOrNil returns errs if at least one call failed, nil otherwise
+func batchErrorOrNil(errs jsonrpc.BatchError) error {
+	for _, err := range errs {
+		if err != nil {
+			return errs
+		}
+	}
+	return nil
+}
+
 // TransactionSender returns the sender address of the given transaction. The transaction
 // must be known to the remote node and included in the blockchain at the given block and
 // index. The sender is the one derived by the protocol at the time of inclusion.
@@ -509,6 +623,10 @@ func (c *Client) getBlock(ctx context.Context, method string, args ...interface{
 	if err := json.Unmarshal(raw, &head); err != nil {
 		return nil, err
 	}
+	// null result for an unknown block
+	if head == nil {
+		return nil, geth.NotFound
+	}
 	if err := json.Unmarshal(raw, &body); err != nil {
 		return nil, err
 	}
@@ -527,28 +645,30 @@ func (c *Client) getBlock(ctx context.Context, method string, args ...interface{
 	}
 	// Load uncles because they are not included in the block response.
 	var uncles []*gethtypes.Header
-	// if len(body.UncleHashes) > 0 {
-	// 	uncles = make([]*gethtypes.Header, len(body.UncleHashes))
-	// 	reqs := make([]rpc.BatchElem, len(body.UncleHashes))
-	// 	for i := range reqs {
-	// 		reqs[i] = rpc.BatchElem{
-	// 			Method: "eth_getUncleByBlockHashAndIndex",
-	// 			Args:   []interface{}{body.Hash, hexutil.EncodeUint64(uint64(i))},
-	// 			Result: &uncles[i],
-	// 		}
-	// 	}
-	// 	if err := c.BatchCallContext(ctx, reqs); err != nil {
-	// 		return nil, err
-	// 	}
-	// 	for i := range reqs {
-	// 		if reqs[i].Error != nil {
-	// 			return nil, reqs[i].Error
-	// 		}
-	// 		if uncles[i] == nil {
-	// 			return nil, fmt.Errorf("got null header for uncle %d of block %x", i, body.Hash[:])
-	// 		}
-	// 	}
-	// }
+	if len(body.UncleHashes) > 0 {
+		uncles = make([]*gethtypes.Header, len(body.UncleHashes))
+		reqs := make([]*jsonrpc.Request, len(body.UncleHashes))
+		res := make([]interface{}, len(body.UncleHashes))
+		for i := range reqs {
+			reqs[i] = &jsonrpc.Request{
+				Method: "eth_getUncleByBlockHashAndIndex",
+				Params: []interface{}{body.Hash, gethhexutil.EncodeUint64(uint64(i))},
+			}
+			res[i] = &uncles[i]
+		}
+		errs, err := c.batchCall(ctx, reqs, res)
+		if err != nil {
+			return nil, err
+		}
+		for i := range reqs {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			if uncles[i] == nil {
+				return nil, fmt.Errorf("got null header for uncle %d of block %x", i, body.Hash[:])
+			}
+		}
+	}
 	// Fill the sender cache of transactions in the block.
 	txs := make([]*gethtypes.Transaction, len(body.Transactions))
 	for i, tx := range body.Transactions {
@@ -6,7 +6,10 @@ package jsonrpc
 import (
 	"context"
 	"embed"
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	geth "github.com/ethereum/go-ethereum"
@@ -19,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	httptestutils "github.com/kilnfi/go-utils/net/http/testutils"
+	"github.com/kilnfi/go-utils/net/jsonrpc"
 	jsonrpchttp "github.com/kilnfi/go-utils/net/jsonrpc/http"
 )
 
@@ -355,3 +359,297 @@ func testSendTransaction(t *testing.T, c *Client, mockCli *httptestutils.MockSen
 
 	require.NoError(t, err)
 }
+
+type fakeSubscriber struct {
+	jsonrpc.Client
+
+	namespace string
+	channel   interface{}
+	args      []interface{}
+}
+
+func (s *fakeSubscriber) Subscribe(_ context.Context, namespace string, channel interface{}, args ...interface{}) (jsonrpc.Subscription, error) {
+	s.namespace, s.channel, s.args = namespace, channel, args
+	return nil, nil
+}
+
+func TestClientNullResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewFromClient(jsonrpchttp.NewClientFromClient(mockCli))
+
+	// nodes answer a null result for unknown blocks and transactions
+	expectNull := func() {
+		req := httptestutils.NewGockRequest()
+		req.Post("/").
+			Reply(200).
+			JSON([]byte(`{"jsonrpc":"2.0","id":null,"result":null}`))
+		mockCli.EXPECT().Gock(req)
+	}
+
+	t.Run("BlockByHash", func(t *testing.T) {
+		expectNull()
+		_, err := c.BlockByHash(context.Background(), gethcommon.HexToHash("0x01"))
+		assert.Equal(t, geth.NotFound, err)
+	})
+	t.Run("BlockByNumber", func(t *testing.T) {
+		expectNull()
+		_, err := c.BlockByNumber(context.Background(), big.NewInt(1))
+		assert.Equal(t, geth.NotFound, err)
+	})
+	t.Run("HeaderByHash", func(t *testing.T) {
+		expectNull()
+		_, err := c.HeaderByHash(context.Background(), gethcommon.HexToHash("0x01"))
+		assert.Equal(t, geth.NotFound, err)
+	})
+	t.Run("HeaderByNumber", func(t *testing.T) {
+		expectNull()
+		_, err := c.HeaderByNumber(context.Background(), big.NewInt(1))
+		assert.Equal(t, geth.NotFound, err)
+	})
+	t.Run("TransactionReceipt", func(t *testing.T) {
+		expectNull()
+		_, err := c.TransactionReceipt(context.Background(), gethcommon.HexToHash("0x01"))
+		assert.Equal(t, geth.NotFound, err)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("NotSupported", func(t *testing.T) {
+		c := NewFromClient(jsonrpchttp.NewClientFromClient(httptestutils.NewMockSender(ctrl)))
+		_, err := c.SubscribeNewHead(context.Background(), make(chan *gethtypes.Header))
+		require.Error(t, err)
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		subscriber := new(fakeSubscriber)
+		c := NewFromClient(jsonrpc.WithIncrementalID()(jsonrpc.WithVersion("2.0")(subscriber)))
+		_, err := c.SubscribeNewHead(context.Background(), make(chan *gethtypes.Header))
+		require.NoError(t, err)
+		assert.Equal(t, "eth", subscriber.namespace)
+	})
+
+	t.Run("NewHead", func(t *testing.T) {
+		subscriber := new(fakeSubscriber)
+		ch := make(chan *gethtypes.Header)
+		_, err := NewFromClient(subscriber).SubscribeNewHead(context.Background(), ch)
+		require.NoError(t, err)
+		assert.Equal(t, "eth", subscriber.namespace)
+		assert.Equal(t, chan<- *gethtypes.Header(ch), subscriber.channel)
+		assert.Equal(t, []interface{}{"newHeads"}, subscriber.args)
+	})
+
+	t.Run("FilterLogs", func(t *testing.T) {
+		subscriber := new(fakeSubscriber)
+		q := geth.FilterQuery{Addresses: []gethcommon.Address{gethcommon.HexToAddress("0x1")}}
+		_, err := NewFromClient(subscriber).SubscribeFilterLogs(context.Background(), q, make(chan gethtypes.Log))
+		require.NoError(t, err)
+		assert.Equal(t, "eth", subscriber.namespace)
+		require.Len(t, subscriber.args, 2)
+		assert.Equal(t, "logs", subscriber.args[0])
+		arg, _ := toFilterArg(q)
+		assert.Equal(t, arg, subscriber.args[1])
+	})
+}
+
+func TestClientBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newClient := func() (*Client, *httptestutils.MockSender) {
+		mockCli := httptestutils.NewMockSender(ctrl)
+		return NewFromClient(jsonrpc.WithIncrementalID()(jsonrpc.WithVersion("2.0")(jsonrpchttp.NewClientFromClient(mockCli)))), mockCli
+	}
+
+	t.Run("BlockByHashWithUncles", func(t *testing.T) {
+		c, mockCli := newClient()
+		testBlockByHashWithUncles(t, c, mockCli, "2.0", "0", "1")
+	})
+	t.Run("TransactionReceipts", func(t *testing.T) {
+		c, mockCli := newClient()
+		testTransactionReceipts(t, c, mockCli, "2.0")
+	})
+	t.Run("TransactionsByHash", func(t *testing.T) {
+		c, mockCli := newClient()
+		testTransactionsByHash(t, c, mockCli)
+	})
+}
+
+func TestClientBatchWithoutDecorators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// requests have no ID, batched ones are sent with their index in the batch as ID
+	newClient := func() (*Client, *httptestutils.MockSender) {
+		mockCli := httptestutils.NewMockSender(ctrl)
+		return NewFromClient(jsonrpchttp.NewClientFromClient(mockCli)), mockCli
+	}
+
+	t.Run("BlockByHashWithUncles", func(t *testing.T) {
+		c, mockCli := newClient()
+		testBlockByHashWithUncles(t, c, mockCli, "", "null", "0")
+	})
+	t.Run("TransactionReceipts", func(t *testing.T) {
+		c, mockCli := newClient()
+		testTransactionReceipts(t, c, mockCli, "")
+	})
+	t.Run("TransactionsByHash", func(t *testing.T) {
+		c, mockCli := newClient()
+		testTransactionsByHash(t, c, mockCli)
+	})
+}
+
+// loadTestBlock returns the result of the eth_getBlockByHash testdata response
+func loadTestBlock(t *testing.T) map[string]interface{} {
+	res, _ := testdataFS.ReadFile("testdata/eth_getBlockByHash_0x0fb6d5609c9edab75bf587ea7449e6e6940d6e3df1992a1bd96ca8b74ffd16fc_true.json")
+	require.NotEmpty(t, res, "response should not be empty (check typo in testdata filename)")
+
+	msg := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(res, &msg))
+
+	return msg["result"].(map[string]interface{})
+}
+
+func newBatchResponse(t *testing.T, results ...interface{}) []byte {
+	var msgs []map[string]interface{}
+	for i, result := range results {
+		msg := map[string]interface{}{"jsonrpc": "2.0", "id": i}
+		if err, ok := result.(*jsonrpc.ErrorMsg); ok {
+			msg["error"] = err
+		} else {
+			msg["result"] = result
+		}
+		msgs = append(msgs, msg)
+	}
+
+	b, err := json.Marshal(msgs)
+	require.NoError(t, err)
+
+	return b
+}
+
+// testBlockByHashWithUncles expects requests with the given JSON-RPC version and IDs of the block and uncles calls
+func testBlockByHashWithUncles(t *testing.T, c *Client, mockCli *httptestutils.MockSender, version, blockID, unclesID string) {
+	uncle := &gethtypes.Header{
+		ParentHash: gethcommon.HexToHash("0x6019a4b3e4e3ba7b7b43d28d68492f99226b86e7dff0c607a16ef4d16a617503"),
+		Coinbase:   gethcommon.HexToAddress("0x52bc44d5378309EE2abF1539BF71dE1b7d7bE3b5"),
+		Difficulty: big.NewInt(12795344477503252),
+		Number:     big.NewInt(14082405),
+		GasLimit:   29999972,
+		Time:       uint64(1643215320),
+		Extra:      []byte{},
+	}
+
+	block := loadTestBlock(t)
+	block["uncles"] = []gethcommon.Hash{uncle.Hash()}
+	block["sha3Uncles"] = gethtypes.CalcUncleHash([]*gethtypes.Header{uncle})
+	blockResp, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": json.RawMessage(blockID), "result": block})
+	require.NoError(t, err)
+
+	blockReq := httptestutils.NewGockRequest()
+	blockReq.Post("/").
+		JSON([]byte(`{"jsonrpc":"` + version + `","method":"eth_getBlockByHash","params":["0x0fb6d5609c9edab75bf587ea7449e6e6940d6e3df1992a1bd96ca8b74ffd16fc",true],"id":` + blockID + `}`)).
+		Reply(200).
+		JSON(blockResp)
+
+	unclesReq := httptestutils.NewGockRequest()
+	unclesReq.Post("/").
+		JSON([]byte(`[{"jsonrpc":"` + version + `","method":"eth_getUncleByBlockHashAndIndex","params":["0x0fb6d5609c9edab75bf587ea7449e6e6940d6e3df1992a1bd96ca8b74ffd16fc","0x0"],"id":` + unclesID + `}]`)).
+		Reply(200).
+		JSON([]byte(`[{"jsonrpc":"2.0","id":` + unclesID + `,"result":` + string(mustMarshal(t, uncle)) + `}]`))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(blockReq),
+		mockCli.EXPECT().Gock(unclesReq),
+	)
+
+	b, err := c.BlockByHash(context.Background(), gethcommon.HexToHash("0x0fb6d5609c9edab75bf587ea7449e6e6940d6e3df1992a1bd96ca8b74ffd16fc"))
+
+	require.NoError(t, err)
+	require.Len(t, b.Uncles(), 1)
+	assert.Equal(t, uncle.Hash(), b.Uncles()[0].Hash())
+	assert.Equal(t, 277, b.Transactions().Len())
+}
+
+// testTransactionReceipts expects requests with the given JSON-RPC version
+func testTransactionReceipts(t *testing.T, c *Client, mockCli *httptestutils.MockSender, version string) {
+	hashes := []gethcommon.Hash{
+		gethcommon.HexToHash("0x01"),
+		gethcommon.HexToHash("0x02"),
+		gethcommon.HexToHash("0x03"),
+	}
+
+	receipt := &gethtypes.Receipt{
+		Type:              gethtypes.DynamicFeeTxType,
+		Status:            gethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs:              []*gethtypes.Log{},
+		TxHash:            hashes[0],
+		GasUsed:           21000,
+		BlockHash:         gethcommon.HexToHash("0x0fb6d5609c9edab75bf587ea7449e6e6940d6e3df1992a1bd96ca8b74ffd16fc"),
+		BlockNumber:       big.NewInt(14082406),
+	}
+	rpcErr := &jsonrpc.ErrorMsg{Code: -32000, Message: "internal error"}
+
+	reqMsgs := make([]string, len(hashes))
+	for i, hash := range hashes {
+		reqMsgs[i] = fmt.Sprintf(`{"jsonrpc":%q,"method":"eth_getTransactionReceipt","params":[%q],"id":%v}`, version, hash.Hex(), i)
+	}
+
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		JSON([]byte("[" + strings.Join(reqMsgs, ",") + "]")).
+		Reply(200).
+		JSON(newBatchResponse(t, receipt, nil, rpcErr))
+
+	mockCli.EXPECT().Gock(req)
+
+	receipts, err := c.TransactionReceipts(context.Background(), hashes)
+
+	require.Error(t, err)
+	require.IsType(t, jsonrpc.BatchError{}, err)
+	assert.Equal(t, jsonrpc.BatchError{nil, geth.NotFound, rpcErr}, err)
+
+	require.Len(t, receipts, 3)
+	require.NotNil(t, receipts[0])
+	assert.Equal(t, hashes[0], receipts[0].TxHash)
+	assert.Equal(t, uint64(21000), receipts[0].GasUsed)
+	assert.Nil(t, receipts[1])
+	assert.Nil(t, receipts[2])
+}
+
+func testTransactionsByHash(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	tx := loadTestBlock(t)["transactions"].([]interface{})[0]
+	hashes := []gethcommon.Hash{
+		gethcommon.HexToHash("0x1024b01a4af12c4de946cbdfe2e12a3123ad1689a1eebe1c9549efb92fe2ae20"),
+		gethcommon.HexToHash("0x02"),
+	}
+
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		Reply(200).
+		JSON(newBatchResponse(t, tx, nil))
+
+	mockCli.EXPECT().Gock(req)
+
+	txs, err := c.TransactionsByHash(context.Background(), hashes)
+
+	require.Error(t, err)
+	assert.Equal(t, jsonrpc.BatchError{nil, geth.NotFound}, err)
+
+	require.Len(t, txs, 2)
+	require.NotNil(t, txs[0])
+	assert.Equal(t, hashes[0], txs[0].Hash())
+	assert.Nil(t, txs[1])
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
@@ -0,0 +1,144 @@
+package httpmetrics
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CollectorOpts configures a Collector
+type CollectorOpts struct {
+	// Subsystem prefixes metric names (e.g. "consensus_client")
+	Subsystem string
+
+	// Label is the name of the label identifying the call (e.g. "endpoint" or "method")
+	Label string
+
+	// Calls describes the calls in metrics help (e.g. "calls to the beacon node API")
+	Calls string
+
+	// ErrorCode returns the code an error is counted under, it defaults to StatusCode
+	ErrorCode func(error) string
+}
+
+// Collector holds API client metrics counting requests, latency and errors by call
+type Collector struct {
+	errorCode func(error) string
+
+	// mux protects collectors that are swapped on registration
+	mux      sync.RWMutex
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewCollector creates a Collector
+func NewCollector(opts *CollectorOpts) *Collector {
+	errorCode := opts.ErrorCode
+	if errorCode == nil {
+		errorCode = StatusCode
+	}
+
+	return &Collector{
+		errorCode: errorCode,
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: opts.Subsystem,
+				Name:      "requests_total",
+				Help:      "Number of " + opts.Calls,
+			},
+			[]string{opts.Label},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem: opts.Subsystem,
+				Name:      "request_duration_seconds",
+				Help:      "Duration of " + opts.Calls,
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{opts.Label},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: opts.Subsystem,
+				Name:      "errors_total",
+				Help:      "Number of failed " + opts.Calls + " by error code",
+			},
+			[]string{opts.Label, "code"},
+		),
+	}
+}
+
+// Register registers metrics on reg
+//
+// If metrics have already been registered by another client, the registered collectors are re-used
+// so metrics of all clients are aggregated
+func (m *Collector) Register(reg prometheus.Registerer) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	requests, err := registerOrExisting(reg, m.requests)
+	if err != nil {
+		return err
+	}
+
+	duration, err := registerOrExisting(reg, m.duration)
+	if err != nil {
+		return err
+	}
+
+	errs, err := registerOrExisting(reg, m.errors)
+	if err != nil {
+		return err
+	}
+
+	m.requests, m.duration, m.errors = requests, duration, errs
+
+	return nil
+}
+
+func registerOrExisting[T prometheus.Collector](reg prometheus.Registerer, collector T) (T, error) {
+	err := reg.Register(collector)
+	if err != nil {
+		var alreadyRegisteredErr prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegisteredErr) {
+			if existing, ok := alreadyRegisteredErr.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+
+	return collector, nil
+}
+
+// Observe records a call that started at start and returned err
+func (m *Collector) Observe(call string, start time.Time, err error) {
+	m.mux.RLock()
+	requests, duration, errs := m.requests, m.duration, m.errors
+	m.mux.RUnlock()
+
+	requests.WithLabelValues(call).Inc()
+	duration.WithLabelValues(call).Observe(time.Since(start).Seconds())
+	if err != nil {
+		errs.WithLabelValues(call, m.errorCode(err)).Inc()
+	}
+}
+
+// StatusCode returns the response status code of err
+//
+// It defaults to "none" if no response was received
+func StatusCode(err error) string {
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if status, ok := detailedErr.StatusCode.(int); ok && status > 0 {
+			return strconv.Itoa(status)
+		}
+	}
+
+	return "none"
+}
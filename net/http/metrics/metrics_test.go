@@ -0,0 +1,77 @@
+//go:build !integration
+// +build !integration
+
+package httpmetrics
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCollector() *Collector {
+	return NewCollector(&CollectorOpts{
+		Subsystem: "test_client",
+		Label:     "method",
+		Calls:     "test calls",
+	})
+}
+
+func TestCollector(t *testing.T) {
+	m := newTestCollector()
+	reg := prometheus.NewRegistry()
+	require.NoError(t, m.Register(reg))
+
+	start := time.Now()
+	m.Observe("foo", start, nil)
+	m.Observe("foo", start, autorest.NewErrorWithResponse("test", "foo", &http.Response{StatusCode: 503}, "failed"))
+	m.Observe("foo", start, fmt.Errorf("connection refused"))
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.requests.WithLabelValues("foo")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.errors.WithLabelValues("foo", "503")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.errors.WithLabelValues("foo", "none")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.duration))
+
+	// a second collector registering on the same registry shares collectors
+	other := newTestCollector()
+	require.NoError(t, other.Register(reg))
+	assert.Same(t, m.requests, other.requests)
+}
+
+func TestCollectorErrorCode(t *testing.T) {
+	m := NewCollector(&CollectorOpts{
+		Subsystem: "test_client",
+		Label:     "method",
+		Calls:     "test calls",
+		ErrorCode: func(error) string { return "custom" },
+	})
+
+	m.Observe("foo", time.Now(), fmt.Errorf("error"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.errors.WithLabelValues("foo", "custom")))
+}
+
+func TestCollectorConcurrentRegister(t *testing.T) {
+	m := newTestCollector()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.Observe("foo", time.Now(), nil)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		require.NoError(t, m.Register(prometheus.NewRegistry()))
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,98 @@
+package httptestutils
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+type sseMsg struct {
+	event, data string
+}
+
+// SSEServer is a fake Server-Sent Events server allowing to test SSE consumers
+//
+// Every request received by the server opens an event stream. Events pushed with Send
+// are broadcasted to all currently opened streams.
+type SSEServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	streams  map[chan sseMsg]struct{}
+	requests []*http.Request
+}
+
+// NewSSEServer creates and starts a SSEServer
+func NewSSEServer() *SSEServer {
+	s := &SSEServer{
+		streams: make(map[chan sseMsg]struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *SSEServer) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := make(chan sseMsg, 64)
+	s.mu.Lock()
+	s.streams[ch] = struct{}{}
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case msg := <-ch:
+			if msg.event != "" {
+				fmt.Fprintf(rw, "event: %v\n", msg.event)
+			}
+			fmt.Fprintf(rw, "data: %v\n\n", msg.data)
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// Send broadcasts an event to all opened streams
+func (s *SSEServer) Send(event, data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.streams {
+		ch <- sseMsg{event: event, data: data}
+	}
+}
+
+// Streams returns the number of currently opened streams
+func (s *SSEServer) Streams() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.streams)
+}
+
+// Requests returns all requests received by the server
+func (s *SSEServer) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*http.Request{}, s.requests...)
+}
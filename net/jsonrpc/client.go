@@ -1,6 +1,9 @@
 package jsonrpc
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // go:generate mockgen -source client.go -destination testutils/client.go -package testutils Client
 
@@ -17,3 +20,48 @@ type ClientFunc func(ctx context.Context, req *Request, res interface{}) error
 func (f ClientFunc) Call(ctx context.Context, req *Request, res interface{}) error {
 	return f(ctx, req, res)
 }
+
+// BatchClient is implemented by clients able to perform several JSON-RPC calls in a single round trip
+type BatchClient interface {
+	// BatchCall performs a batch of JSON-RPC calls and store the result of reqs[i] in res[i]
+	//
+	// Requests IDs MUST be unique so responses can be matched, requests without ID are assigned one
+	// (their index in the batch) which must not collide with other IDs. If the batch could be performed
+	// but some calls failed, the returned error is a BatchError holding the error of each call.
+	BatchCall(ctx context.Context, reqs []*Request, res []interface{}) error
+}
+
+type BatchClientFunc func(ctx context.Context, reqs []*Request, res []interface{}) error
+
+func (f BatchClientFunc) BatchCall(ctx context.Context, reqs []*Request, res []interface{}) error {
+	return f(ctx, reqs, res)
+}
+
+// BatchCall performs reqs in a single batch if c is a BatchClient, otherwise calls are performed one by one
+//
+// In both cases, failed calls are reported in a BatchError
+func BatchCall(ctx context.Context, c Client, reqs []*Request, res []interface{}) error {
+	if len(reqs) != len(res) {
+		return fmt.Errorf("invalid batch: got %v requests but %v results", len(reqs), len(res))
+	}
+
+	if bc, ok := c.(BatchClient); ok {
+		return bc.BatchCall(ctx, reqs, res)
+	}
+
+	errs := make(BatchError, len(reqs))
+	failed := false
+	for i, req := range reqs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		errs[i] = c.Call(ctx, req, res[i])
+		failed = failed || errs[i] != nil
+	}
+
+	if failed {
+		return errs
+	}
+
+	return nil
+}
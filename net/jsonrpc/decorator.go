@@ -2,7 +2,12 @@ package jsonrpc
 
 import (
 	"context"
+	"fmt"
 	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kilnfi/go-utils/common/interfaces"
 )
 
 type ClientDecorator func(Client) Client
@@ -10,9 +15,8 @@ type ClientDecorator func(Client) Client
 // WithVersion automatically set JSON-RPC request version
 func WithVersion(v string) ClientDecorator {
 	return func(c Client) Client {
-		return ClientFunc(func(ctx context.Context, req *Request, res interface{}) error {
+		return decorate(c, func(req *Request) {
 			req.Version = v
-			return c.Call(ctx, req, res)
 		})
 	}
 }
@@ -21,9 +25,59 @@ func WithVersion(v string) ClientDecorator {
 func WithIncrementalID() ClientDecorator {
 	var idCounter uint32
 	return func(c Client) Client {
-		return ClientFunc(func(ctx context.Context, req *Request, res interface{}) error {
+		return decorate(c, func(req *Request) {
 			req.ID = atomic.AddUint32(&idCounter, 1) - 1
-			return c.Call(ctx, req, res)
 		})
 	}
 }
+
+// decorated is a client applying prepare to every request before passing it to next
+//
+// Optional interfaces of next (BatchClient, Subscriber, interfaces.Loggable) are checked at call time,
+// so decorating a client does not hide them
+type decorated struct {
+	next    Client
+	prepare func(*Request)
+}
+
+func decorate(c Client, prepare func(*Request)) Client {
+	return &decorated{next: c, prepare: prepare}
+}
+
+func (d *decorated) Call(ctx context.Context, req *Request, res interface{}) error {
+	d.prepare(req)
+	return d.next.Call(ctx, req, res)
+}
+
+// BatchCall applies prepare to every request and performs them in a single batch if the decorated client
+// is a BatchClient, otherwise calls are performed one by one
+func (d *decorated) BatchCall(ctx context.Context, reqs []*Request, res []interface{}) error {
+	for _, req := range reqs {
+		d.prepare(req)
+	}
+	return BatchCall(ctx, d.next, reqs, res)
+}
+
+// Subscribe passes the subscription to the decorated client, it fails if the decorated client is not a Subscriber
+func (d *decorated) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (Subscription, error) {
+	sub, ok := d.next.(Subscriber)
+	if !ok {
+		return nil, fmt.Errorf("JSON-RPC client %T does not support subscriptions", d.next)
+	}
+	return sub.Subscribe(ctx, namespace, channel, args...)
+}
+
+// Logger returns the logger of the decorated client, or nil if it is not loggable
+func (d *decorated) Logger() logrus.FieldLogger {
+	if loggable, ok := d.next.(interfaces.Loggable); ok {
+		return loggable.Logger()
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the decorated client if it is loggable
+func (d *decorated) SetLogger(logger logrus.FieldLogger) {
+	if loggable, ok := d.next.(interfaces.Loggable); ok {
+		loggable.SetLogger(logger)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kilnfi/go-utils/common/interfaces"
 	"github.com/kilnfi/go-utils/net/jsonrpc"
 	jsonrpctestutils "github.com/kilnfi/go-utils/net/jsonrpc/testutils"
 )
@@ -50,3 +53,136 @@ func TestWithIncrementalID(t *testing.T) {
 	err = c.Call(context.Background(), &jsonrpc.Request{}, nil)
 	require.NoError(t, err)
 }
+
+type subscriberClient struct {
+	*jsonrpctestutils.MockClient
+	namespace string
+	logger    logrus.FieldLogger
+}
+
+func (c *subscriberClient) Subscribe(_ context.Context, namespace string, _ interface{}, _ ...interface{}) (jsonrpc.Subscription, error) {
+	c.namespace = namespace
+	return nil, nil
+}
+
+func (c *subscriberClient) Logger() logrus.FieldLogger { return c.logger }
+
+func (c *subscriberClient) SetLogger(logger logrus.FieldLogger) { c.logger = logger }
+
+func TestDecoratorsPassThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("SubscriberAndLoggable", func(t *testing.T) {
+		mockCli := &subscriberClient{MockClient: jsonrpctestutils.NewMockClient(ctrl)}
+		c := jsonrpc.WithIncrementalID()(jsonrpc.WithVersion("2.0")(mockCli))
+
+		sub, ok := c.(jsonrpc.Subscriber)
+		require.True(t, ok, "decorated client should support subscriptions")
+		_, err := sub.Subscribe(context.Background(), "eth", make(chan struct{}))
+		require.NoError(t, err)
+		assert.Equal(t, "eth", mockCli.namespace)
+
+		loggable, ok := c.(interfaces.Loggable)
+		require.True(t, ok, "decorated client should be loggable")
+		logger := logrus.New()
+		loggable.SetLogger(logger)
+		assert.Equal(t, logger, mockCli.logger)
+		assert.Equal(t, logger, loggable.Logger())
+	})
+
+	t.Run("Client", func(t *testing.T) {
+		c := jsonrpc.WithVersion("2.0")(jsonrpctestutils.NewMockClient(ctrl))
+
+		_, err := c.(jsonrpc.Subscriber).Subscribe(context.Background(), "eth", make(chan struct{}))
+		require.Error(t, err)
+		assert.Nil(t, c.(interfaces.Loggable).Logger())
+	})
+}
+
+type batchClient struct {
+	*jsonrpctestutils.MockClient
+	*jsonrpctestutils.MockBatchClient
+}
+
+func TestDecoratorsBatchCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := &batchClient{
+		jsonrpctestutils.NewMockClient(ctrl),
+		jsonrpctestutils.NewMockBatchClient(ctrl),
+	}
+	c := jsonrpc.WithIncrementalID()(jsonrpc.WithVersion("2.0")(mockCli))
+
+	bc, ok := c.(jsonrpc.BatchClient)
+	require.True(t, ok, "decorated client should support batch calls")
+
+	reqs := []*jsonrpc.Request{{Method: "a"}, {Method: "b"}}
+	mockCli.MockBatchClient.EXPECT().BatchCall(gomock.Any(), reqs, gomock.Any())
+	err := bc.BatchCall(context.Background(), reqs, []interface{}{nil, nil})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*jsonrpc.Request{
+		{Version: "2.0", Method: "a", ID: uint32(0)},
+		{Version: "2.0", Method: "b", ID: uint32(1)},
+	}, reqs)
+}
+
+func TestDecoratorsBatchCallFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := jsonrpctestutils.NewMockClient(ctrl)
+	c := jsonrpc.WithVersion("2.0")(mockCli)
+
+	gomock.InOrder(
+		mockCli.EXPECT().Call(gomock.Any(), &jsonrpc.Request{Version: "2.0", Method: "a"}, gomock.Any()),
+		mockCli.EXPECT().Call(gomock.Any(), &jsonrpc.Request{Version: "2.0", Method: "b"}, gomock.Any()),
+	)
+
+	err := jsonrpc.BatchCall(context.Background(), c, []*jsonrpc.Request{{Method: "a"}, {Method: "b"}}, []interface{}{nil, nil})
+	require.NoError(t, err)
+}
+
+func TestBatchCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("BatchClient", func(t *testing.T) {
+		mockCli := &batchClient{
+			jsonrpctestutils.NewMockClient(ctrl),
+			jsonrpctestutils.NewMockBatchClient(ctrl),
+		}
+
+		reqs := []*jsonrpc.Request{{Method: "a"}}
+		mockCli.MockBatchClient.EXPECT().BatchCall(gomock.Any(), reqs, gomock.Any())
+		err := jsonrpc.BatchCall(context.Background(), mockCli, reqs, []interface{}{nil})
+		require.NoError(t, err)
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		mockCli := jsonrpctestutils.NewMockClient(ctrl)
+
+		callErr := &jsonrpc.ErrorMsg{Code: -32000, Message: "failed"}
+		gomock.InOrder(
+			mockCli.EXPECT().Call(gomock.Any(), &jsonrpc.Request{Method: "a"}, gomock.Any()),
+			mockCli.EXPECT().Call(gomock.Any(), &jsonrpc.Request{Method: "b"}, gomock.Any()).Return(callErr),
+		)
+
+		err := jsonrpc.BatchCall(
+			context.Background(),
+			mockCli,
+			[]*jsonrpc.Request{{Method: "a"}, {Method: "b"}},
+			[]interface{}{nil, nil},
+		)
+		require.Error(t, err)
+		assert.Equal(t, jsonrpc.BatchError{nil, callErr}, err)
+		assert.ErrorIs(t, err, callErr)
+	})
+
+	t.Run("InvalidLength", func(t *testing.T) {
+		err := jsonrpc.BatchCall(context.Background(), jsonrpctestutils.NewMockClient(ctrl), []*jsonrpc.Request{{}}, nil)
+		require.Error(t, err)
+	})
+}
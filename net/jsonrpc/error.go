@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ErrorMsg is a struct allowing to encode/decode a JSON-RPC response body
@@ -16,3 +17,28 @@ func (err ErrorMsg) Error() string {
 	b, _ := json.Marshal(err)
 	return fmt.Sprintf("JSON-RPC: %v", string(b))
 }
+
+// BatchError holds errors of a batch of calls, the i-th error being the error of the i-th call
+// or nil if it succeeded
+type BatchError []error
+
+func (errs BatchError) Error() string {
+	var msgs []string
+	for i, err := range errs {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("call %v: %v", i, err))
+		}
+	}
+	return fmt.Sprintf("JSON-RPC batch: %v failed call(s): %v", len(msgs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns errors of failed calls
+func (errs BatchError) Unwrap() []error {
+	var rv []error
+	for _, err := range errs {
+		if err != nil {
+			rv = append(rv, err)
+		}
+	}
+	return rv
+}
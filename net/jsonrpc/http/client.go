@@ -1,15 +1,19 @@
 package jsonrpchttp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 
 	kilnhttp "github.com/kilnfi/go-utils/net/http"
+	httpmetrics "github.com/kilnfi/go-utils/net/http/metrics"
 	httppreparer "github.com/kilnfi/go-utils/net/http/preparer"
 	"github.com/kilnfi/go-utils/net/jsonrpc"
 )
@@ -18,13 +22,16 @@ import (
 type Client struct {
 	client autorest.Sender
 
+	metrics *httpmetrics.Collector
+
 	logger logrus.FieldLogger
 }
 
 // NewClient creates a new client connected to a JSON-RPC server
 func NewClientFromClient(s autorest.Sender) *Client {
 	c := &Client{
-		client: s,
+		client:  s,
+		metrics: newMetrics(),
 	}
 
 	c.SetLogger(logrus.StandardLogger())
@@ -55,8 +62,15 @@ func (c *Client) SetLogger(logger logrus.FieldLogger) {
 	c.logger = logger.WithField("component", "jsonrpc.http-client")
 }
 
+// RegisterMetrics registers calls, latency and errors metrics by JSON-RPC method
+func (c *Client) RegisterMetrics(reg prometheus.Registerer) error {
+	return c.metrics.Register(reg)
+}
+
 func (c *Client) Call(ctx context.Context, r *jsonrpc.Request, res interface{}) error {
+	start := time.Now()
 	err := c.call(ctx, r, res)
+	c.metrics.Observe(r.Method, start, err)
 	if err != nil {
 		c.logger.
 			WithField("req.method", r.Method).
@@ -91,6 +105,139 @@ func (c *Client) call(ctx context.Context, r *jsonrpc.Request, res interface{})
 	return nil
 }
 
+// BatchCall performs a batch of JSON-RPC calls in a single HTTP request
+//
+// Responses are matched to requests by ID, so requests MUST have unique IDs. Requests without ID are
+// sent with their index in reqs as ID, reqs are not modified.
+func (c *Client) BatchCall(ctx context.Context, reqs []*jsonrpc.Request, res []interface{}) error {
+	start := time.Now()
+	errs, err := c.batchCall(ctx, reqs, res)
+
+	failed := false
+	for i, r := range reqs {
+		reqErr := err
+		if errs != nil {
+			reqErr = errs[i]
+		}
+
+		c.metrics.Observe(r.Method, start, reqErr)
+		if reqErr != nil {
+			failed = true
+			c.logger.
+				WithField("req.method", r.Method).
+				WithField("req.version", r.Version).
+				WithField("req.params", r.Params).
+				WithField("req.id", r.ID).
+				WithError(reqErr).Errorf("jsonrpc batch call failed")
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errs
+	}
+
+	return nil
+}
+
+// batchCall performs a JSON-RPC batch call
+//
+// It returns the error of each call, or an error if the batch could not be performed
+func (c *Client) batchCall(ctx context.Context, reqs []*jsonrpc.Request, res []interface{}) (jsonrpc.BatchError, error) {
+	if len(reqs) != len(res) {
+		return nil, fmt.Errorf("invalid batch: got %v requests but %v results", len(reqs), len(res))
+	}
+
+	if len(reqs) == 0 {
+		return make(jsonrpc.BatchError, 0), nil
+	}
+
+	// index requests by ID so responses can be matched
+	sent := make([]*jsonrpc.Request, len(reqs))
+	indexes := make(map[string]int, len(reqs))
+	for i, r := range reqs {
+		if r.ID == nil {
+			withID := *r
+			withID.ID = i
+			r = &withID
+		}
+		sent[i] = r
+
+		id, err := json.Marshal(r.ID)
+		if err != nil {
+			return nil, autorest.NewErrorWithError(err, "jsonrpchttp.Client", "BatchCall", nil, "Request")
+		}
+		if _, ok := indexes[string(id)]; ok {
+			return nil, autorest.NewErrorWithError(
+				fmt.Errorf("requests must have unique IDs, got %v", string(id)),
+				"jsonrpchttp.Client", "BatchCall", nil, "Request",
+			)
+		}
+		indexes[string(id)] = i
+	}
+
+	req, err := newBatchCallRequest(ctx, sent)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "jsonrpchttp.Client", "BatchCall", nil, "Request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "jsonrpchttp.Client", fmt.Sprintf("BatchCall(%v requests)", len(reqs)), resp, "Do")
+	}
+
+	msgs, err := inspectBatchCallResponse(resp)
+	if err != nil {
+		return nil, autorest.NewErrorWithError(err, "jsonrpchttp.Client", fmt.Sprintf("BatchCall(%v requests)", len(reqs)), resp, "Response")
+	}
+
+	errs := make(jsonrpc.BatchError, len(reqs))
+	received := make([]bool, len(reqs))
+	for _, msg := range msgs {
+		if msg.ID == nil {
+			continue
+		}
+
+		i, ok := indexes[compactID(*msg.ID)]
+		if !ok || received[i] {
+			c.logger.WithField("resp.id", string(*msg.ID)).Warnf("unexpected response in batch")
+			continue
+		}
+
+		received[i] = true
+		errs[i] = inspectCallResponseMsg(msg, res[i])
+	}
+
+	for i := range reqs {
+		if !received[i] {
+			errs[i] = fmt.Errorf("missing JSON-RPC response in batch")
+		}
+	}
+
+	return errs, nil
+}
+
+// compactID returns the compact JSON encoding of a response ID, so it can be compared to marshalled request IDs
+func compactID(id json.RawMessage) string {
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, id); err != nil {
+		return string(id)
+	}
+	return buf.String()
+}
+
+func newBatchCallRequest(ctx context.Context, reqs []*jsonrpc.Request) (*http.Request, error) {
+	return autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithPath("/"),
+		autorest.AsJSON(),
+		autorest.WithJSON(reqs),
+	).Prepare(newRequest(ctx))
+}
+
 // ByUnmarshallingResponse marshall JSON-RPC request message into http.Request body
 func newCallRequest(ctx context.Context, req *jsonrpc.Request) (*http.Request, error) {
 	return autorest.CreatePreparer(
@@ -109,7 +256,7 @@ func newRequest(ctx context.Context) *http.Request {
 // responseMsg is a struct allowing to encode/decode a JSON-RPC response body
 type responseMsg struct {
 	Version string           `json:"jsonrpc"`
-	Result  *json.RawMessage `json:"result,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"` // not a pointer so a null result is kept as "null"
 	Error   *json.RawMessage `json:"error,omitempty"`
 	ID      *json.RawMessage `json:"id,omitempty"`
 }
@@ -129,9 +276,9 @@ func inspectCallResponseMsg(msg *responseMsg, res interface{}) error {
 	}
 
 	if msg.Result != nil && res != nil {
-		err := json.Unmarshal(*msg.Result, res)
+		err := json.Unmarshal(msg.Result, res)
 		if err != nil {
-			return fmt.Errorf("failed to unmarshal JSON-RPC result %v into %T (%v)", string(*msg.Result), res, err)
+			return fmt.Errorf("failed to unmarshal JSON-RPC result %v into %T (%v)", string(msg.Result), res, err)
 		}
 		return nil
 	}
@@ -154,3 +301,38 @@ func inspectCallResponse(resp *http.Response, res interface{}) error {
 
 	return inspectCallResponseMsg(msg, res)
 }
+
+// inspectBatchCallResponse decodes a batch response
+//
+// If the server could not process the batch, it responds with a single response holding an error, which is returned
+func inspectBatchCallResponse(resp *http.Response) ([]*responseMsg, error) {
+	var raw json.RawMessage
+	err := autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessOK(),
+		autorest.ByUnmarshallingJSON(&raw),
+		autorest.ByClosing(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '{' {
+		msg := new(responseMsg)
+		if err := json.Unmarshal(raw, msg); err != nil {
+			return nil, err
+		}
+		if err := inspectCallResponseMsg(msg, nil); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON-RPC batch response %v", string(raw))
+	}
+
+	var msgs []*responseMsg
+	if err := json.Unmarshal(raw, &msgs); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
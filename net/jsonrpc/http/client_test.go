@@ -5,10 +5,13 @@ package jsonrpchttp
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -31,6 +34,8 @@ func TestCall(t *testing.T) {
 
 	t.Run("StatusOKAndValidResult", func(t *testing.T) { testCallStatusOKAndValidResult(t, c, mockCli) })
 	t.Run("StatusOKAndError", func(t *testing.T) { testCallStatusOKAndError(t, c, mockCli) })
+	t.Run("StatusOKAndNullResult", func(t *testing.T) { testCallStatusOKAndNullResult(t, c, mockCli) })
+	t.Run("StatusOKAndMissingResult", func(t *testing.T) { testCallStatusOKAndMissingResult(t, c, mockCli) })
 	t.Run("Status400", func(t *testing.T) { testCallStatus400(t, c, mockCli) })
 }
 
@@ -96,6 +101,53 @@ func testCallStatusOKAndError(t *testing.T, c *Client, mockCli *httptestutils.Mo
 	)
 }
 
+func testCallStatusOKAndNullResult(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		Reply(200).
+		JSON([]byte(`{"jsonrpc":"2.0","result":null,"id":0}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	res := new(string)
+	err := c.Call(
+		context.Background(),
+		&jsonrpc.Request{
+			Version: "2.0",
+			Method:  "concat",
+			ID:      0,
+		},
+		&res,
+	)
+
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// null result is not an error and leaves non pointer results untouched
+	mockCli.EXPECT().Gock(req)
+
+	s := "untouched"
+	err = c.Call(context.Background(), &jsonrpc.Request{Version: "2.0", Method: "concat", ID: 0}, &s)
+
+	require.NoError(t, err)
+	assert.Equal(t, "untouched", s)
+}
+
+func testCallStatusOKAndMissingResult(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		Reply(200).
+		JSON([]byte(`{"jsonrpc":"2.0","id":0}`))
+
+	mockCli.EXPECT().Gock(req)
+
+	var res string
+	err := c.Call(context.Background(), &jsonrpc.Request{Version: "2.0", Method: "concat", ID: 0}, &res)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing both result and error")
+}
+
 func testCallStatus400(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
 	req := httptestutils.NewGockRequest()
 	req.Post("/").
@@ -117,3 +169,145 @@ func testCallStatus400(t *testing.T, c *Client, mockCli *httptestutils.MockSende
 
 	require.Error(t, err)
 }
+
+func TestCallMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+	reg := prometheus.NewRegistry()
+	require.NoError(t, c.RegisterMetrics(reg))
+
+	okReq := httptestutils.NewGockRequest()
+	okReq.Post("/").
+		Reply(200).
+		JSON([]byte(`{"jsonrpc":"2.0","result":"abc","id":0}`))
+	errReq := httptestutils.NewGockRequest()
+	errReq.Post("/").
+		Reply(200).
+		JSON([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"invalid test method"},"id":0}`))
+
+	gomock.InOrder(
+		mockCli.EXPECT().Gock(okReq),
+		mockCli.EXPECT().Gock(errReq),
+	)
+
+	req := &jsonrpc.Request{Version: "2.0", Method: "concat", Params: []string{"a", "b", "c"}}
+	require.NoError(t, c.Call(context.Background(), req, nil))
+	require.Error(t, c.Call(context.Background(), req, nil))
+
+	expected := `
+# HELP jsonrpc_client_errors_total Number of failed JSON-RPC calls by error code
+# TYPE jsonrpc_client_errors_total counter
+jsonrpc_client_errors_total{code="-32000",method="concat"} 1
+# HELP jsonrpc_client_requests_total Number of JSON-RPC calls
+# TYPE jsonrpc_client_requests_total counter
+jsonrpc_client_requests_total{method="concat"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "jsonrpc_client_errors_total", "jsonrpc_client_requests_total"))
+}
+
+func TestBatchCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCli := httptestutils.NewMockSender(ctrl)
+	c := NewClientFromClient(mockCli)
+
+	t.Run("StatusOK", func(t *testing.T) { testBatchCallStatusOK(t, c, mockCli) })
+	t.Run("BatchError", func(t *testing.T) { testBatchCallBatchError(t, c, mockCli) })
+	t.Run("Status400", func(t *testing.T) { testBatchCallStatus400(t, c, mockCli) })
+	t.Run("DuplicateIDs", func(t *testing.T) { testBatchCallDuplicateIDs(t, c) })
+	t.Run("MissingIDs", func(t *testing.T) { testBatchCallMissingIDs(t, c, mockCli) })
+}
+
+func newBatchRequests() []*jsonrpc.Request {
+	return []*jsonrpc.Request{
+		{Version: "2.0", Method: "concat", Params: []string{"a", "b"}, ID: 0},
+		{Version: "2.0", Method: "concat", Params: []string{"c", "d"}, ID: 1},
+		{Version: "2.0", Method: "concat", Params: []string{"e", "f"}, ID: 2},
+	}
+}
+
+func testBatchCallStatusOK(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		JSON([]byte(`[{"jsonrpc":"2.0","method":"concat","params":["a","b"],"id":0},{"jsonrpc":"2.0","method":"concat","params":["c","d"],"id":1},{"jsonrpc":"2.0","method":"concat","params":["e","f"],"id":2}]`)).
+		Reply(200).
+		// responses may be returned in any order
+		JSON([]byte(`[{"jsonrpc":"2.0","result":"ef","id":2},{"jsonrpc":"2.0","result":"ab","id":0},{"jsonrpc":"2.0","result":"cd","id":1}]`))
+
+	mockCli.EXPECT().Gock(req)
+
+	res := make([]string, 3)
+	err := c.BatchCall(context.Background(), newBatchRequests(), []interface{}{&res[0], &res[1], &res[2]})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ab", "cd", "ef"}, res)
+}
+
+func testBatchCallBatchError(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		Reply(200).
+		JSON([]byte(`[{"jsonrpc":"2.0","result":"ab","id":0},{"jsonrpc":"2.0","error":{"code":-32000,"message":"invalid test method"},"id":1}]`))
+
+	mockCli.EXPECT().Gock(req)
+
+	res := make([]string, 3)
+	err := c.BatchCall(context.Background(), newBatchRequests(), []interface{}{&res[0], &res[1], &res[2]})
+
+	require.Error(t, err)
+	require.IsType(t, jsonrpc.BatchError{}, err)
+	errs := err.(jsonrpc.BatchError)
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, &jsonrpc.ErrorMsg{Code: -32000, Message: "invalid test method"}, errs[1])
+	assert.Error(t, errs[2], "missing response should be reported")
+	assert.Equal(t, "ab", res[0])
+}
+
+func testBatchCallStatus400(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		Reply(400)
+
+	mockCli.EXPECT().Gock(req)
+
+	err := c.BatchCall(context.Background(), newBatchRequests(), make([]interface{}, 3))
+
+	require.Error(t, err)
+	require.IsType(t, autorest.DetailedError{}, err)
+}
+
+func testBatchCallDuplicateIDs(t *testing.T, c *Client) {
+	reqs := newBatchRequests()
+	reqs[1].ID = 0
+
+	err := c.BatchCall(context.Background(), reqs, make([]interface{}, 3))
+
+	require.Error(t, err)
+}
+
+func testBatchCallMissingIDs(t *testing.T, c *Client, mockCli *httptestutils.MockSender) {
+	req := httptestutils.NewGockRequest()
+	req.Post("/").
+		JSON([]byte(`[{"jsonrpc":"2.0","method":"concat","params":["a","b"],"id":0},{"jsonrpc":"2.0","method":"concat","params":["c","d"],"id":1}]`)).
+		Reply(200).
+		JSON([]byte(`[{"jsonrpc":"2.0","result":"cd","id":1},{"jsonrpc":"2.0","result":"ab","id":0}]`))
+
+	mockCli.EXPECT().Gock(req)
+
+	reqs := []*jsonrpc.Request{
+		{Version: "2.0", Method: "concat", Params: []string{"a", "b"}},
+		{Version: "2.0", Method: "concat", Params: []string{"c", "d"}},
+	}
+	res := make([]string, 2)
+	err := c.BatchCall(context.Background(), reqs, []interface{}{&res[0], &res[1]})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ab", "cd"}, res)
+	assert.Nil(t, reqs[0].ID, "requests should not be modified")
+	assert.Nil(t, reqs[1].ID, "requests should not be modified")
+}
@@ -0,0 +1,32 @@
+package jsonrpchttp
+
+import (
+	"errors"
+	"strconv"
+
+	httpmetrics "github.com/kilnfi/go-utils/net/http/metrics"
+	"github.com/kilnfi/go-utils/net/jsonrpc"
+)
+
+// newMetrics creates client metrics labeled by JSON-RPC method
+func newMetrics() *httpmetrics.Collector {
+	return httpmetrics.NewCollector(&httpmetrics.CollectorOpts{
+		Subsystem: "jsonrpc_client",
+		Label:     "method",
+		Calls:     "JSON-RPC calls",
+		ErrorCode: errorCode,
+	})
+}
+
+// errorCode returns the JSON-RPC error code of err
+//
+// It defaults to the response status code if the server did not return a JSON-RPC error,
+// and to "none" if no response was received
+func errorCode(err error) string {
+	var rpcErr *jsonrpc.ErrorMsg
+	if errors.As(err, &rpcErr) {
+		return strconv.Itoa(rpcErr.Code)
+	}
+
+	return httpmetrics.StatusCode(err)
+}
@@ -0,0 +1,20 @@
+package jsonrpc
+
+import "context"
+
+// Subscriber is implemented by clients able to receive server notifications (e.g. websocket clients)
+type Subscriber interface {
+	// Subscribe calls "<namespace>_subscribe" with args and sends every notification result to channel
+	//
+	// channel MUST be a writable channel, notification results are unmarshalled into its element type.
+	Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (Subscription, error)
+}
+
+// Subscription is a subscription to server notifications
+type Subscription interface {
+	// Unsubscribe cancels the subscription and closes the Err channel
+	Unsubscribe()
+
+	// Err returns a channel receiving an error if the subscription fails
+	Err() <-chan error
+}
@@ -48,3 +48,40 @@ func (mr *MockClientMockRecorder) Call(ctx, req, res interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockClient)(nil).Call), ctx, req, res)
 }
+
+// MockBatchClient is a mock of BatchClient interface.
+type MockBatchClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchClientMockRecorder
+}
+
+// MockBatchClientMockRecorder is the mock recorder for MockBatchClient.
+type MockBatchClientMockRecorder struct {
+	mock *MockBatchClient
+}
+
+// NewMockBatchClient creates a new mock instance.
+func NewMockBatchClient(ctrl *gomock.Controller) *MockBatchClient {
+	mock := &MockBatchClient{ctrl: ctrl}
+	mock.recorder = &MockBatchClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchClient) EXPECT() *MockBatchClientMockRecorder {
+	return m.recorder
+}
+
+// BatchCall mocks base method.
+func (m *MockBatchClient) BatchCall(ctx context.Context, reqs []*jsonrpc.Request, res []interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCall", ctx, reqs, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCall indicates an expected call of BatchCall.
+func (mr *MockBatchClientMockRecorder) BatchCall(ctx, reqs, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCall", reflect.TypeOf((*MockBatchClient)(nil).BatchCall), ctx, reqs, res)
+}
@@ -0,0 +1,503 @@
+package jsonrpcws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kilnfi/go-utils/net/jsonrpc"
+)
+
+var (
+	// ErrClosed is returned by calls on a stopped client
+	ErrClosed = errors.New("jsonrpcws: client is closed")
+
+	// ErrConnectionLost is returned by calls which connection has been lost before receiving a response
+	ErrConnectionLost = errors.New("jsonrpcws: connection lost")
+)
+
+// Ensure Client implements jsonrpc interfaces
+var (
+	_ jsonrpc.Client     = (*Client)(nil)
+	_ jsonrpc.Subscriber = (*Client)(nil)
+)
+
+// Client is a JSON-RPC client over a websocket connection
+//
+// It supports calls, server notifications and subscriptions. If the connection is lost,
+// pending calls fail with ErrConnectionLost, then the client reconnects and re-subscribes
+// active subscriptions. Calls sent while disconnected wait for the connection to be re-established.
+// Notifications sent by the server while disconnected are lost, use OnReconnect to detect such gaps.
+type Client struct {
+	cfg *Config
+
+	dialer *websocket.Dialer
+
+	idCounter uint64
+
+	mux       sync.Mutex
+	conn      *websocket.Conn
+	connected chan struct{} // closed once conn is set
+	pending   map[string]*pendingCall
+	subs      map[string]*subscription   // by server subscription ID
+	active    map[*subscription]struct{} // all subscriptions to restore on reconnect
+	stopped   chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	writeMux  sync.Mutex
+	cancelRun context.CancelFunc
+
+	onNotification func(method string, params json.RawMessage)
+	onReconnect    func()
+
+	logger logrus.FieldLogger
+}
+
+type pendingCall struct {
+	respC chan *response
+
+	// onResult is called from the read loop before the response is delivered
+	onResult func(json.RawMessage) error
+}
+
+type response struct {
+	msg *message
+	err error
+}
+
+// message is a struct allowing to decode any JSON-RPC message received from the server
+type message struct {
+	Version string            `json:"jsonrpc"`
+	ID      *json.RawMessage  `json:"id,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Params  json.RawMessage   `json:"params,omitempty"`
+	Result  json.RawMessage   `json:"result,omitempty"`
+	Error   *jsonrpc.ErrorMsg `json:"error,omitempty"`
+}
+
+type subscriptionParams struct {
+	ID     string          `json:"subscription"`
+	Result json.RawMessage `json:"result"`
+}
+
+// NewClient creates a client connecting to the JSON-RPC server at cfg.Address
+//
+// Connection is established on Start
+func NewClient(cfg *Config) *Client {
+	c := &Client{
+		cfg: cfg,
+		dialer: &websocket.Dialer{
+			HandshakeTimeout: cfg.HandshakeTimeout.Duration,
+		},
+		connected: make(chan struct{}),
+		pending:   make(map[string]*pendingCall),
+		subs:      make(map[string]*subscription),
+		active:    make(map[*subscription]struct{}),
+		stopped:   make(chan struct{}),
+	}
+
+	c.SetLogger(logrus.StandardLogger())
+
+	return c
+}
+
+func (c *Client) Logger() logrus.FieldLogger {
+	return c.logger
+}
+
+func (c *Client) SetLogger(logger logrus.FieldLogger) {
+	c.logger = logger.WithField("component", "jsonrpc.ws-client")
+}
+
+// OnNotification sets a callback called on server notifications that do not belong to a subscription
+//
+// It must be set before the client starts
+func (c *Client) OnNotification(fn func(method string, params json.RawMessage)) {
+	c.onNotification = fn
+}
+
+// OnReconnect sets a callback called each time the connection has been re-established and subscriptions restored
+//
+// Notifications sent by the server while disconnected are lost, the callback allows to backfill them
+// (e.g. loading logs of blocks received while disconnected). It must be set before the client starts.
+func (c *Client) OnReconnect(fn func()) {
+	c.onReconnect = fn
+}
+
+// Start connects to the server
+func (c *Client) Start(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancelRun = cancel
+	c.setConn(runCtx, conn)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run(runCtx, conn)
+	}()
+
+	return nil
+}
+
+// Stop closes the connection, failing pending calls and active subscriptions with ErrClosed
+func (c *Client) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() {
+		close(c.stopped)
+		if c.cancelRun != nil {
+			c.cancelRun()
+		}
+
+		c.mux.Lock()
+		if c.conn != nil {
+			c.conn.Close()
+		}
+		subs := make([]*subscription, 0, len(c.active))
+		for sub := range c.active {
+			subs = append(subs, sub)
+		}
+		c.subs = make(map[string]*subscription)
+		c.active = make(map[*subscription]struct{})
+		c.mux.Unlock()
+
+		for _, sub := range subs {
+			sub.close(ErrClosed)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	conn, resp, err := c.dialer.DialContext(ctx, c.cfg.Address, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v: %w", c.cfg.Address, err)
+	}
+	return conn, nil
+}
+
+// run reads messages from conn and reconnects once conn is lost, until ctx is canceled
+func (c *Client) run(ctx context.Context, conn *websocket.Conn) {
+	for {
+		err := c.read(conn)
+		c.clearConn(conn)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.WithError(err).Warnf("connection lost, reconnecting")
+
+		for conn = nil; conn == nil; {
+			select {
+			case <-time.After(c.cfg.ReconnectDelay.Duration):
+			case <-ctx.Done():
+				return
+			}
+
+			conn, err = c.dial(ctx)
+			if err != nil {
+				c.logger.WithError(err).Warnf("reconnection failed")
+			}
+		}
+
+		// client may have been stopped while dialing
+		if !c.setConn(ctx, conn) {
+			conn.Close()
+			return
+		}
+		c.logger.Infof("reconnected")
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			c.resubscribe(ctx)
+			if c.onReconnect != nil && ctx.Err() == nil {
+				c.onReconnect()
+			}
+		}()
+	}
+}
+
+// setConn makes conn the current connection unless ctx is canceled, in which case it returns false
+func (c *Client) setConn(ctx context.Context, conn *websocket.Conn) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	// Stop cancels ctx before closing the current connection under lock, so checking under lock
+	// guarantees conn is either closed by Stop or not set at all
+	if ctx.Err() != nil {
+		return false
+	}
+
+	c.conn = conn
+	close(c.connected)
+	return true
+}
+
+// clearConn closes conn and fails calls waiting for a response on it
+func (c *Client) clearConn(conn *websocket.Conn) {
+	conn.Close()
+
+	c.mux.Lock()
+	c.conn = nil
+	c.connected = make(chan struct{})
+	pending := c.pending
+	c.pending = make(map[string]*pendingCall)
+	// subscription IDs are bound to the connection
+	c.subs = make(map[string]*subscription)
+	c.mux.Unlock()
+
+	for _, call := range pending {
+		call.respC <- &response{err: ErrConnectionLost}
+	}
+}
+
+// read handles messages received on conn until it fails
+func (c *Client) read(conn *websocket.Conn) error {
+	pingDone := make(chan struct{})
+	defer close(pingDone)
+
+	pingInterval := c.cfg.PingInterval.Duration
+	_ = conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	})
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.ping(conn, pingDone)
+	}()
+
+	for {
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+
+		msg := new(message)
+		if err := json.Unmarshal(b, msg); err != nil {
+			c.logger.WithError(err).Warnf("invalid JSON-RPC message")
+			continue
+		}
+
+		c.handle(msg)
+	}
+}
+
+func (c *Client) ping(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(c.cfg.PingInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.writeMux.Lock()
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.cfg.PingInterval.Duration))
+			c.writeMux.Unlock()
+			if err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (c *Client) handle(msg *message) {
+	if msg.ID == nil {
+		c.handleNotification(msg)
+		return
+	}
+
+	c.mux.Lock()
+	call, ok := c.pending[string(*msg.ID)]
+	delete(c.pending, string(*msg.ID))
+	c.mux.Unlock()
+
+	if !ok {
+		c.logger.WithField("id", string(*msg.ID)).Debugf("response to unknown call")
+		return
+	}
+
+	resp := &response{msg: msg}
+	if msg.Error == nil && call.onResult != nil {
+		resp.err = call.onResult(msg.Result)
+	}
+	call.respC <- resp
+}
+
+func (c *Client) handleNotification(msg *message) {
+	if !strings.HasSuffix(msg.Method, "_subscription") {
+		if c.onNotification != nil {
+			c.onNotification(msg.Method, msg.Params)
+		}
+		return
+	}
+
+	params := new(subscriptionParams)
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		c.logger.WithError(err).Warnf("invalid subscription notification")
+		return
+	}
+
+	c.mux.Lock()
+	sub, ok := c.subs[params.ID]
+	c.mux.Unlock()
+
+	if ok {
+		sub.deliver(params.Result)
+	}
+}
+
+// Call performs a JSON-RPC call and stores result in res
+//
+// Request ID is set by the client so responses can be matched to calls, and version defaults to "2.0"
+func (c *Client) Call(ctx context.Context, req *jsonrpc.Request, res interface{}) error {
+	err := c.call(ctx, req, res, nil)
+	if err != nil {
+		c.logger.
+			WithField("req.method", req.Method).
+			WithField("req.params", req.Params).
+			WithError(err).Errorf("jsonrpc call failed")
+	}
+
+	return err
+}
+
+func (c *Client) call(ctx context.Context, req *jsonrpc.Request, res interface{}, onResult func(json.RawMessage) error) error {
+	version := req.Version
+	if version == "" {
+		version = "2.0"
+	}
+
+	id := atomic.AddUint64(&c.idCounter, 1)
+	b, err := json.Marshal(&jsonrpc.Request{
+		Version: version,
+		Method:  req.Method,
+		ID:      id,
+		Params:  req.Params,
+	})
+	if err != nil {
+		return err
+	}
+
+	// responses are matched on the raw JSON ID, which for an integer is its decimal representation
+	key := strconv.FormatUint(id, 10)
+
+	conn, err := c.waitConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	call := &pendingCall{
+		respC:    make(chan *response, 1),
+		onResult: onResult,
+	}
+
+	c.mux.Lock()
+	if c.conn != conn {
+		c.mux.Unlock()
+		return ErrConnectionLost
+	}
+	c.pending[key] = call
+	c.mux.Unlock()
+
+	c.writeMux.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, b)
+	c.writeMux.Unlock()
+	if err != nil {
+		c.removePending(key)
+		return err
+	}
+
+	select {
+	case resp := <-call.respC:
+		if resp.err != nil {
+			return resp.err
+		}
+		return inspectResponseMsg(resp.msg, res)
+	case <-ctx.Done():
+		c.removePending(key)
+		return ctx.Err()
+	}
+}
+
+func (c *Client) removePending(key string) {
+	c.mux.Lock()
+	delete(c.pending, key)
+	c.mux.Unlock()
+}
+
+// waitConn returns current connection, waiting for it to be established if the client is reconnecting
+func (c *Client) waitConn(ctx context.Context) (*websocket.Conn, error) {
+	for {
+		c.mux.Lock()
+		conn, connected := c.conn, c.connected
+		c.mux.Unlock()
+
+		select {
+		case <-c.stopped:
+			return nil, ErrClosed
+		default:
+		}
+
+		if conn != nil {
+			return conn, nil
+		}
+
+		select {
+		case <-connected:
+		case <-c.stopped:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func inspectResponseMsg(msg *message, res interface{}) error {
+	if msg.Error != nil {
+		return msg.Error
+	}
+
+	if msg.Result == nil {
+		return fmt.Errorf("invalid JSON-RPC response missing both result and error")
+	}
+
+	if res != nil {
+		if err := json.Unmarshal(msg.Result, res); err != nil {
+			return fmt.Errorf("failed to unmarshal JSON-RPC result %v into %T (%v)", string(msg.Result), res, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,319 @@
+//go:build !integration
+// +build !integration
+
+package jsonrpcws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+	"github.com/kilnfi/go-utils/net/jsonrpc"
+)
+
+// fakeServer is a websocket JSON-RPC server supporting a few methods and subscriptions
+type fakeServer struct {
+	*httptest.Server
+
+	mux       sync.Mutex
+	conn      *websocket.Conn
+	subsCount int
+
+	subscribed   chan string
+	unsubscribed chan string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{
+		subscribed:   make(chan string, 10),
+		unsubscribed: make(chan string, 10),
+	}
+
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+
+		s.mux.Lock()
+		s.conn = conn
+		s.mux.Unlock()
+
+		s.serve(conn)
+	}))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeServer) serve(conn *websocket.Conn) {
+	defer conn.Close()
+	for {
+		req := new(struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		})
+		if err := conn.ReadJSON(req); err != nil {
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		var subID string
+		switch req.Method {
+		case "concat":
+			var rv string
+			for _, param := range req.Params {
+				var str string
+				_ = json.Unmarshal(param, &str)
+				rv += str
+			}
+			resp["result"] = rv
+		case "eth_subscribe":
+			s.mux.Lock()
+			s.subsCount++
+			id := fmt.Sprintf("0x%x", s.subsCount)
+			s.mux.Unlock()
+			resp["result"] = id
+			subID = id
+		case "eth_unsubscribe":
+			var id string
+			_ = json.Unmarshal(req.Params[0], &id)
+			s.unsubscribed <- id
+			resp["result"] = true
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+
+		if err := s.write(conn, resp); err != nil {
+			return
+		}
+
+		if subID != "" {
+			s.subscribed <- subID
+		}
+	}
+}
+
+func (s *fakeServer) write(conn *websocket.Conn, msg interface{}) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return conn.WriteJSON(msg)
+}
+
+// notify sends a notification on the current connection
+func (s *fakeServer) notify(t *testing.T, method string, params interface{}) {
+	s.mux.Lock()
+	conn := s.conn
+	s.mux.Unlock()
+
+	require.NoError(t, s.write(conn, map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}))
+}
+
+// drop closes the current connection
+func (s *fakeServer) drop() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.conn.Close()
+}
+
+// newTestClient returns a started client connected to s, setup is called before starting it
+func newTestClient(t *testing.T, s *fakeServer, setup ...func(*Client)) *Client {
+	c := NewClient((&Config{
+		Address:        "ws" + strings.TrimPrefix(s.URL, "http"),
+		ReconnectDelay: &kilntypes.Duration{Duration: 10 * time.Millisecond},
+	}).SetDefault())
+	for _, fn := range setup {
+		fn(c)
+	}
+
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { require.NoError(t, c.Stop(context.Background())) })
+
+	return c
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatalf("timeout")
+	}
+	var zero T
+	return zero
+}
+
+func TestCall(t *testing.T) {
+	s := newFakeServer(t)
+	c := newTestClient(t, s)
+
+	t.Run("ValidResult", func(t *testing.T) {
+		var res string
+		err := c.Call(context.Background(), &jsonrpc.Request{Method: "concat", Params: []string{"a", "b", "c"}}, &res)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", res)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		err := c.Call(context.Background(), &jsonrpc.Request{Method: "unknown"}, nil)
+		require.Error(t, err)
+		assert.Equal(t, &jsonrpc.ErrorMsg{Code: -32601, Message: "method not found"}, err)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var res string
+				err := c.Call(context.Background(), &jsonrpc.Request{Method: "concat", Params: []string{"a", fmt.Sprint(i)}}, &res)
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("a%v", i), res)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestOnNotification(t *testing.T) {
+	s := newFakeServer(t)
+
+	c := NewClient((&Config{Address: "ws" + strings.TrimPrefix(s.URL, "http")}).SetDefault())
+	notifications := make(chan string, 1)
+	c.OnNotification(func(method string, params json.RawMessage) {
+		notifications <- method + " " + string(params)
+	})
+	require.NoError(t, c.Start(context.Background()))
+	defer func() { require.NoError(t, c.Stop(context.Background())) }()
+
+	s.notify(t, "test_event", []int{1})
+	assert.Equal(t, "test_event [1]", receive(t, notifications))
+}
+
+func TestSubscribe(t *testing.T) {
+	s := newFakeServer(t)
+	c := newTestClient(t, s)
+
+	t.Run("InvalidChannel", func(t *testing.T) {
+		_, err := c.Subscribe(context.Background(), "eth", make(<-chan string), "test")
+		require.Error(t, err)
+	})
+
+	ch := make(chan string)
+	sub, err := c.Subscribe(context.Background(), "eth", ch, "test")
+	require.NoError(t, err)
+	id := receive(t, s.subscribed)
+
+	s.notify(t, "eth_subscription", map[string]interface{}{"subscription": id, "result": "a"})
+	s.notify(t, "eth_subscription", map[string]interface{}{"subscription": "0xunknown", "result": "x"})
+	s.notify(t, "eth_subscription", map[string]interface{}{"subscription": id, "result": "b"})
+	assert.Equal(t, "a", receive(t, ch))
+	assert.Equal(t, "b", receive(t, ch))
+
+	sub.Unsubscribe()
+	assert.Equal(t, id, receive(t, s.unsubscribed))
+
+	err, ok := <-sub.Err()
+	assert.False(t, ok, "Err channel should be closed")
+	assert.NoError(t, err)
+}
+
+func TestSubscribeInvalidNotification(t *testing.T) {
+	s := newFakeServer(t)
+	c := newTestClient(t, s)
+
+	ch := make(chan int)
+	sub, err := c.Subscribe(context.Background(), "eth", ch, "test")
+	require.NoError(t, err)
+	id := receive(t, s.subscribed)
+
+	s.notify(t, "eth_subscription", map[string]interface{}{"subscription": id, "result": "not an int"})
+	assert.Error(t, receive(t, sub.Err()))
+	assert.Equal(t, id, receive(t, s.unsubscribed))
+}
+
+func TestReconnect(t *testing.T) {
+	s := newFakeServer(t)
+	reconnected := make(chan struct{}, 1)
+	c := newTestClient(t, s, func(c *Client) {
+		c.OnReconnect(func() { reconnected <- struct{}{} })
+	})
+
+	ch := make(chan string)
+	sub, err := c.Subscribe(context.Background(), "eth", ch, "test")
+	require.NoError(t, err)
+	id := receive(t, s.subscribed)
+
+	s.drop()
+
+	// subscription is restored with a new ID on the new connection
+	newID := receive(t, s.subscribed)
+	assert.NotEqual(t, id, newID)
+
+	// reconnection is reported once subscriptions are restored so notifications missed meanwhile can be backfilled
+	receive(t, reconnected)
+
+	s.notify(t, "eth_subscription", map[string]interface{}{"subscription": newID, "result": "a"})
+	assert.Equal(t, "a", receive(t, ch))
+
+	var res string
+	err = c.Call(context.Background(), &jsonrpc.Request{Method: "concat", Params: []string{"a", "b"}}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "ab", res)
+
+	sub.Unsubscribe()
+	assert.Equal(t, newID, receive(t, s.unsubscribed))
+}
+
+func TestStop(t *testing.T) {
+	s := newFakeServer(t)
+
+	c := NewClient((&Config{Address: "ws" + strings.TrimPrefix(s.URL, "http")}).SetDefault())
+	require.NoError(t, c.Start(context.Background()))
+
+	sub, err := c.Subscribe(context.Background(), "eth", make(chan string), "test")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, ErrClosed, receive(t, sub.Err()))
+
+	err = c.Call(context.Background(), &jsonrpc.Request{Method: "concat"}, nil)
+	assert.Equal(t, ErrClosed, err)
+}
+
+func TestStopWhileReconnecting(t *testing.T) {
+	s := newFakeServer(t)
+
+	c := NewClient((&Config{Address: "ws" + strings.TrimPrefix(s.URL, "http")}).SetDefault())
+	require.NoError(t, c.Start(context.Background()))
+	require.NoError(t, c.Stop(context.Background()))
+
+	// connection dialed by run concurrently to Stop is not set
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn, err := c.dial(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.False(t, c.setConn(ctx, conn))
+	c.mux.Lock()
+	assert.Nil(t, c.conn)
+	c.mux.Unlock()
+}
@@ -0,0 +1,52 @@
+package jsonrpcws
+
+import (
+	"time"
+
+	kilntypes "github.com/kilnfi/go-utils/common/types"
+)
+
+type Config struct {
+	// Address is the websocket URL of the JSON-RPC server (e.g. ws://localhost:8546)
+	Address string
+
+	// HandshakeTimeout is the maximum duration of the websocket handshake
+	HandshakeTimeout *kilntypes.Duration
+
+	// ReconnectDelay is the delay between reconnection attempts after the connection is lost
+	ReconnectDelay *kilntypes.Duration
+
+	// PingInterval is the interval at which pings are sent to detect dead connections
+	PingInterval *kilntypes.Duration
+
+	// NotificationsBuffer is the number of notifications buffered by subscription
+	// before the subscription fails because its channel is not read fast enough
+	NotificationsBuffer int
+}
+
+const (
+	defaultHandshakeTimeout    = 10 * time.Second
+	defaultReconnectDelay      = time.Second
+	defaultPingInterval        = 30 * time.Second
+	defaultNotificationsBuffer = 1000
+)
+
+func (cfg *Config) SetDefault() *Config {
+	if cfg.HandshakeTimeout == nil {
+		cfg.HandshakeTimeout = &kilntypes.Duration{Duration: defaultHandshakeTimeout}
+	}
+
+	if cfg.ReconnectDelay == nil {
+		cfg.ReconnectDelay = &kilntypes.Duration{Duration: defaultReconnectDelay}
+	}
+
+	if cfg.PingInterval == nil {
+		cfg.PingInterval = &kilntypes.Duration{Duration: defaultPingInterval}
+	}
+
+	if cfg.NotificationsBuffer == 0 {
+		cfg.NotificationsBuffer = defaultNotificationsBuffer
+	}
+
+	return cfg
+}
@@ -0,0 +1,226 @@
+package jsonrpcws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/kilnfi/go-utils/net/jsonrpc"
+)
+
+// ErrSubscriptionQueueOverflow is sent on a subscription Err channel if notifications are not consumed fast enough
+var ErrSubscriptionQueueOverflow = errors.New("jsonrpcws: subscription queue overflow")
+
+// Subscribe calls "<namespace>_subscribe" with args and sends every notification result to channel
+//
+// Subscription is automatically restored after a reconnection. If it can not be restored,
+// the error is sent on the subscription Err channel.
+func (c *Client) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (jsonrpc.Subscription, error) {
+	chanVal := reflect.ValueOf(channel)
+	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, fmt.Errorf("invalid subscription channel %T, expected a writable channel", channel)
+	}
+
+	sub := &subscription{
+		client:    c,
+		namespace: namespace,
+		args:      args,
+		channel:   chanVal,
+		queue:     make(chan json.RawMessage, c.cfg.NotificationsBuffer),
+		quit:      make(chan struct{}),
+		errC:      make(chan error, 1),
+	}
+
+	c.mux.Lock()
+	c.active[sub] = struct{}{}
+	c.mux.Unlock()
+
+	if err := c.subscribe(ctx, sub); err != nil {
+		sub.close(nil)
+		return nil, err
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		sub.forward()
+	}()
+
+	return sub, nil
+}
+
+// subscribe calls the server to subscribe and registers sub under the subscription ID returned by the server
+//
+// Registration happens in the read loop, so no notification sent right after the response is missed
+func (c *Client) subscribe(ctx context.Context, sub *subscription) error {
+	req := &jsonrpc.Request{
+		Method: sub.namespace + "_subscribe",
+		Params: sub.args,
+	}
+
+	return c.call(ctx, req, nil, func(result json.RawMessage) error {
+		var id string
+		if err := json.Unmarshal(result, &id); err != nil {
+			return fmt.Errorf("invalid subscription ID %v: %w", string(result), err)
+		}
+
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		if _, ok := c.active[sub]; ok {
+			c.subs[id] = sub
+			sub.setID(id)
+		}
+
+		return nil
+	})
+}
+
+// resubscribe restores active subscriptions after a reconnection
+func (c *Client) resubscribe(ctx context.Context) {
+	c.mux.Lock()
+	subs := make([]*subscription, 0, len(c.active))
+	for sub := range c.active {
+		subs = append(subs, sub)
+	}
+	c.mux.Unlock()
+
+	for _, sub := range subs {
+		if err := c.subscribe(ctx, sub); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.WithError(err).WithField("namespace", sub.namespace).Errorf("failed to restore subscription")
+			sub.close(fmt.Errorf("failed to restore subscription: %w", err))
+		}
+	}
+}
+
+// unsubscribe removes sub from the client and returns its server subscription ID if it was registered
+func (c *Client) unsubscribe(sub *subscription) string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	delete(c.active, sub)
+	id := sub.getID()
+	if c.subs[id] == sub {
+		delete(c.subs, id)
+		return id
+	}
+
+	return ""
+}
+
+// subscription forwards notifications from the read loop to the user channel
+//
+// Notifications are queued so a slow consumer does not block the read loop
+type subscription struct {
+	client *Client
+
+	namespace string
+	args      []interface{}
+	channel   reflect.Value
+
+	queue chan json.RawMessage
+	quit  chan struct{}
+	errC  chan error
+
+	closeOnce sync.Once
+
+	mux sync.Mutex
+	id  string
+}
+
+func (sub *subscription) Err() <-chan error {
+	return sub.errC
+}
+
+// Unsubscribe unsubscribes from the server and closes the Err channel
+func (sub *subscription) Unsubscribe() {
+	sub.close(nil)
+}
+
+func (sub *subscription) setID(id string) {
+	sub.mux.Lock()
+	sub.id = id
+	sub.mux.Unlock()
+}
+
+func (sub *subscription) getID() string {
+	sub.mux.Lock()
+	defer sub.mux.Unlock()
+	return sub.id
+}
+
+// deliver queues a notification result, failing the subscription if the queue is full
+//
+// It is called from the read loop so it must not block
+func (sub *subscription) deliver(result json.RawMessage) {
+	select {
+	case sub.queue <- result:
+	case <-sub.quit:
+	default:
+		go sub.close(ErrSubscriptionQueueOverflow)
+	}
+}
+
+// close stops the subscription, sending err on the Err channel if not nil
+func (sub *subscription) close(err error) {
+	sub.closeOnce.Do(func() {
+		close(sub.quit)
+
+		id := sub.client.unsubscribe(sub)
+		if id != "" {
+			sub.client.callUnsubscribe(sub.namespace, id)
+		}
+
+		if err != nil {
+			sub.errC <- err
+		}
+		close(sub.errC)
+	})
+}
+
+// forward sends queued notifications to the user channel until the subscription is closed
+func (sub *subscription) forward() {
+	elemType := sub.channel.Type().Elem()
+	for {
+		var result json.RawMessage
+		select {
+		case result = <-sub.queue:
+		case <-sub.quit:
+			return
+		}
+
+		val := reflect.New(elemType)
+		if err := json.Unmarshal(result, val.Interface()); err != nil {
+			sub.close(fmt.Errorf("failed to unmarshal notification %v into %v: %w", string(result), elemType, err))
+			return
+		}
+
+		chosen, _, _ := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.quit)},
+			{Dir: reflect.SelectSend, Chan: sub.channel, Send: val.Elem()},
+		})
+		if chosen == 0 {
+			return
+		}
+	}
+}
+
+// callUnsubscribe unsubscribes from the server, ignoring failures as the subscription
+// is dropped by the server anyway once the connection is closed
+func (c *Client) callUnsubscribe(namespace, id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.HandshakeTimeout.Duration)
+	defer cancel()
+
+	req := &jsonrpc.Request{
+		Method: namespace + "_unsubscribe",
+		Params: []interface{}{id},
+	}
+	if err := c.call(ctx, req, nil, nil); err != nil {
+		c.logger.WithError(err).WithField("subscription", id).Debugf("failed to unsubscribe")
+	}
+}